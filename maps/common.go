@@ -10,12 +10,316 @@ import (
 
 type Config struct {
 	TagName string
+	// TypeField, if non-empty, is the key under which the name of the encoded
+	// struct's type will be stored in each map produced by Marshal or
+	// MarshalSlice. This gives consumers of polymorphic slices a
+	// discriminator to switch on. The name is that returned by
+	// reflect.Type.Name; it is not qualified by the type's package, so types
+	// of the same name from different packages are indistinguishable, and it
+	// is empty for anonymous struct types. Encoding a struct with a field
+	// whose key is TypeField returns an error.
+	TypeField string
+	// ExpandNilStructPointers, if true, causes nil pointer-to-struct fields to
+	// be encoded as a map of the pointed-to type's zero value rather than as
+	// nil, giving the produced maps a stable shape. Nil pointers within that
+	// zero value are not themselves expanded, so recursive types terminate.
+	// Pointers to structs that are not encoded as maps -- eg. *time.Time --
+	// are stored as the pointer, and are never expanded.
+	ExpandNilStructPointers bool
+	// RoundFloats, if true, causes all float32 and float64 values produced for
+	// struct fields -- including those returned by MarshalMapValue, such as
+	// null.Float64's -- to be rounded to FloatPrecision decimal places. A
+	// negative FloatPrecision disables rounding.
+	RoundFloats    bool
+	FloatPrecision int
+	// TimeFormat, if non-empty, is the layout with which all time.Time and
+	// non-nil *time.Time values produced for struct fields -- including those
+	// returned by MarshalMapValue, such as null.Time's -- will be formatted
+	// into strings. If empty, time values are left as-is.
+	TimeFormat string
+	// DurationsAsStrings, if true, causes all time.Duration values produced
+	// for struct fields -- including those returned by MarshalMapValue, such
+	// as null.Duration's -- to be stored as strings, formatted as by
+	// time.Duration's String method; eg. "1h0m0s".
+	DurationsAsStrings bool
+	// ComplexAsObject, if true, causes all complex64 and complex128 values
+	// produced for struct fields to be stored as a map of their float64 parts,
+	// {"real": r, "imag": i}, which -- unlike complex numbers -- can be
+	// encoded by encoding/json and most other encoders.
+	ComplexAsObject bool
+	// KeyCase is applied to the Go names of struct fields that have not been
+	// given an explicit name by a map tag, in the maps produced by Marshal and
+	// read by Unmarshal. Nested structs are encoded with the same Config, so
+	// their keys are converted as well.
+	KeyCase KeyCase
+	// NameFunc, if non-nil, is applied to the Go names of struct fields that
+	// have not been given an explicit name by a map tag, before KeyCase. It
+	// allows arbitrary mappings -- eg. the stripping of a prefix -- that the
+	// fixed KeyCase conventions cannot express. Functions cannot be compared,
+	// so encoders built with a NameFunc are cached per Config; build such a
+	// Config once and reuse it, rather than building one per call.
+	NameFunc func(fieldName string) string
+	// OmitZero and OmitNil, if true, cause every struct field to be treated
+	// as if it had been tagged with the omitZero or omitNil option,
+	// respectively.
+	OmitZero bool
+	OmitNil  bool
+	// OmitEmpty, if true, causes struct fields holding an array, slice, map,
+	// or string of length zero to be omitted, as if they had been tagged with
+	// the omitEmpty option. Individual fields may opt out with the
+	// noOmitEmpty option.
+	OmitEmpty bool
+	// NullMapValue selects how the null values returned by the
+	// MarshalMapValue implementations of struct fields -- those of fields
+	// whose IsNil methods return true -- are stored. By default they are
+	// stored as returned.
+	NullMapValue NullMapValue
+	// NullSentinel is the value stored in place of null values when
+	// NullMapValue is NullMapValueSentinel. Choosing a value no field can hold
+	// -- eg. a dedicated, unexported struct type -- lets consumers of the
+	// produced maps tell null fields apart from both absent fields and fields
+	// holding a valid zero value.
+	NullSentinel interface{}
+	// ExplicitNullFlags, if true, causes struct fields holding nullable values
+	// -- the database/sql Null* types, and types implementing encoding.IsNiler
+	// with a ValueOrZero method, like the pyrrho/encoding/types/null types --
+	// to be stored as a map of their underlying value and validity,
+	// {"value": v, "valid": b}, rather than as the value-or-nil returned by
+	// MarshalMapValue. This is intended for debugging and auditing.
+	ExplicitNullFlags bool
+	// NilCollectionsAsEmpty, if true, causes nil slices and maps produced for
+	// struct fields to be replaced by empty, non-nil slices and maps of the
+	// same type. Fields omitted by omitNil or omitEmpty are still omitted.
+	NilCollectionsAsEmpty bool
+	// MaxFields, if positive, is the maximum number of keys -- counted after
+	// omitted fields have been dropped, and including TypeField -- that any
+	// single map produced by Marshal may hold. Nested structs are limited
+	// individually. Exceeding the limit stops encoding, and returns an error.
+	// This bounds the memory spent on untrusted, pathologically wide structs.
+	MaxFields int
+	// InterfaceResolver, if non-nil, is called by Unmarshal for each non-nil
+	// value it would assign to a field, or element, of interface type. path
+	// locates the value within the document, in the syntax of
+	// DecodeError.Path. If InterfaceResolver returns true, the value is
+	// decoded into a new value of the returned type -- which must be
+	// assignable to the interface -- as if that were the field's type. This
+	// allows polymorphic documents to be decoded; eg. by switching on a
+	// discriminator key such as TypeField. If it returns false, the value is
+	// assigned as-is.
+	InterfaceResolver func(path string, src interface{}) (reflect.Type, bool)
+	// PanicOnError, if true, disables the recovery Marshal, MarshalSlice, and
+	// Unmarshal perform to convert errors raised while encoding or decoding
+	// into returned errors.
+	// The panic will instead propagate with its original stack, which can ease
+	// the debugging of encoder bugs. It should not be set in production code.
+	PanicOnError bool
 }
 
 var defaultConfig = &Config{
 	TagName: "map",
 }
 
+// Option is a functional option that modifies a Config. Options are applied
+// with Config.With.
+type Option func(*Config)
+
+// WithTagName returns an Option that sets the struct tag name a Config will
+// read field names and options from.
+func WithTagName(name string) Option {
+	return func(cfg *Config) {
+		cfg.TagName = name
+	}
+}
+
+// WithTypeField returns an Option that sets the key under which a Config will
+// store the encoded struct's type name. An empty name disables the type field.
+func WithTypeField(name string) Option {
+	return func(cfg *Config) {
+		cfg.TypeField = name
+	}
+}
+
+// WithExpandNilStructPointers returns an Option that sets whether a Config will
+// expand nil pointer-to-struct fields into maps of zero values.
+func WithExpandNilStructPointers(expand bool) Option {
+	return func(cfg *Config) {
+		cfg.ExpandNilStructPointers = expand
+	}
+}
+
+// WithRoundFloats returns an Option that enables the rounding of float values
+// to the given number of decimal places. A negative precision disables
+// rounding.
+func WithRoundFloats(precision int) Option {
+	return func(cfg *Config) {
+		cfg.RoundFloats = precision >= 0
+		cfg.FloatPrecision = precision
+	}
+}
+
+// WithTimeFormat returns an Option that sets the layout a Config will format
+// time values with. An empty layout leaves time values as time.Time.
+func WithTimeFormat(layout string) Option {
+	return func(cfg *Config) {
+		cfg.TimeFormat = layout
+	}
+}
+
+// WithDurationsAsStrings returns an Option that sets whether a Config will
+// store time.Duration values as strings.
+func WithDurationsAsStrings(asStrings bool) Option {
+	return func(cfg *Config) {
+		cfg.DurationsAsStrings = asStrings
+	}
+}
+
+// WithComplexAsObject returns an Option that sets whether a Config will store
+// complex values as a map of their real and imaginary parts.
+func WithComplexAsObject(asObject bool) Option {
+	return func(cfg *Config) {
+		cfg.ComplexAsObject = asObject
+	}
+}
+
+// WithKeyCase returns an Option that sets the case a Config will convert the
+// names of untagged struct fields to.
+func WithKeyCase(kc KeyCase) Option {
+	return func(cfg *Config) {
+		cfg.KeyCase = kc
+	}
+}
+
+// WithNameFunc returns an Option that sets the function a Config will apply to
+// the names of untagged struct fields. A nil fn disables the mapping.
+func WithNameFunc(fn func(fieldName string) string) Option {
+	return func(cfg *Config) {
+		cfg.NameFunc = fn
+	}
+}
+
+// WithOmitZero returns an Option that sets whether a Config will omit every
+// zero-valued struct field.
+func WithOmitZero(omit bool) Option {
+	return func(cfg *Config) {
+		cfg.OmitZero = omit
+	}
+}
+
+// WithOmitNil returns an Option that sets whether a Config will omit every
+// nil-valued struct field.
+func WithOmitNil(omit bool) Option {
+	return func(cfg *Config) {
+		cfg.OmitNil = omit
+	}
+}
+
+// WithOmitEmpty returns an Option that sets whether a Config will omit struct
+// fields holding empty arrays, slices, maps, and strings.
+func WithOmitEmpty(omit bool) Option {
+	return func(cfg *Config) {
+		cfg.OmitEmpty = omit
+	}
+}
+
+// WithNullMapValue returns an Option that sets how a Config will store null
+// values returned by MarshalMapValue.
+func WithNullMapValue(nmv NullMapValue) Option {
+	return func(cfg *Config) {
+		cfg.NullMapValue = nmv
+	}
+}
+
+// WithNullSentinel returns an Option that sets a Config to store the null values
+// returned by MarshalMapValue as sentinel; it sets both NullMapValue, to
+// NullMapValueSentinel, and NullSentinel.
+func WithNullSentinel(sentinel interface{}) Option {
+	return func(cfg *Config) {
+		cfg.NullMapValue = NullMapValueSentinel
+		cfg.NullSentinel = sentinel
+	}
+}
+
+// WithExplicitNullFlags returns an Option that sets whether a Config will store
+// nullable values as maps of their underlying value and validity.
+func WithExplicitNullFlags(explicit bool) Option {
+	return func(cfg *Config) {
+		cfg.ExplicitNullFlags = explicit
+	}
+}
+
+// WithNilCollectionsAsEmpty returns an Option that sets whether a Config will
+// replace nil slices and maps with empty ones.
+func WithNilCollectionsAsEmpty(empty bool) Option {
+	return func(cfg *Config) {
+		cfg.NilCollectionsAsEmpty = empty
+	}
+}
+
+// WithMaxFields returns an Option that sets the maximum number of keys a Config
+// will produce in a single map. A non-positive max disables the limit.
+func WithMaxFields(max int) Option {
+	return func(cfg *Config) {
+		cfg.MaxFields = max
+	}
+}
+
+// WithInterfaceResolver returns an Option that sets the function a Config will
+// use to choose the concrete types of values decoded into interfaces. A nil fn
+// disables resolution.
+func WithInterfaceResolver(fn func(path string, src interface{}) (reflect.Type, bool)) Option {
+	return func(cfg *Config) {
+		cfg.InterfaceResolver = fn
+	}
+}
+
+// WithPanicOnError returns an Option that sets whether a Config will let panics
+// raised while encoding propagate, rather than recovering them into errors.
+func WithPanicOnError(panicOnError bool) Option {
+	return func(cfg *Config) {
+		cfg.PanicOnError = panicOnError
+	}
+}
+
+// JSONCompatibleConfig returns a new Config that reads field names from `json`
+// struct tags, omits empty fields, and leaves keys untransformed.
+func JSONCompatibleConfig() *Config {
+	return defaultConfig.With(
+		WithTagName("json"),
+		WithOmitEmpty(true),
+		WithKeyCase(KeyCaseNone),
+	)
+}
+
+// CompactConfig returns a new Config that omits every zero-valued or
+// nil-valued field.
+func CompactConfig() *Config {
+	return defaultConfig.With(
+		WithOmitZero(true),
+		WithOmitNil(true),
+	)
+}
+
+// Clone returns a pointer to a new copy of cfg. Modifying the returned Config
+// will not affect cfg.
+//
+// The options of a Config are used as part of the keys of the encoder caches,
+// so a Config that has been used to Marshal should not be modified in place.
+// Clone or With should be used to construct variants instead.
+func (cfg *Config) Clone() *Config {
+	ret := *cfg
+	return &ret
+}
+
+// With returns a pointer to a new copy of cfg with the given opts applied, in
+// order. cfg will not be modified.
+func (cfg *Config) With(opts ...Option) *Config {
+	ret := cfg.Clone()
+	for _, opt := range opts {
+		opt(ret)
+	}
+	return ret
+}
+
 // The below code is a lightly editied version of code written by the Go Authors
 // for the encoding/json package. As such, it remains under the BSD-style
 // license it was originally copywritten under.
@@ -30,9 +334,10 @@ type field struct {
 	nameBytes []byte                 // []byte(name)
 	equalFold func(s, t []byte) bool // bytes.EqualFold or equivalent
 
-	tagged bool
-	index  []int
-	typ    reflect.Type
+	tagged  bool
+	inlined bool // found within a struct tagged with the inline option
+	index   []int
+	typ     reflect.Type
 
 	options tagOptions
 }
@@ -68,15 +373,28 @@ func (fields byIndex) Less(i, j int) bool {
 	return len(l.index) < len(r.index)
 }
 
+// fieldCacheKey identifies a cached set of fields by their struct type, and the
+// options of the Config that typeFields depends on.
+type fieldCacheKey struct {
+	t       reflect.Type
+	tagName string
+	keyCase KeyCase
+	nameCfg *Config // cfg, if cfg.NameFunc is non-nil; see encoderFnCacheKey
+}
+
 var fieldCache struct {
-	value atomic.Value // map[reflect.Type][]field
+	value atomic.Value // map[fieldCacheKey][]field
 	mu    sync.Mutex   // used only by writers
 }
 
 // cachedTypeFields caches the return of typeFields to avoid repeated work.
 func cachedTypeFields(t reflect.Type, cfg *Config) []field {
-	m, _ := fieldCache.value.Load().(map[reflect.Type][]field)
-	f := m[t]
+	key := fieldCacheKey{t, cfg.TagName, cfg.KeyCase, nil}
+	if cfg.NameFunc != nil {
+		key.nameCfg = cfg
+	}
+	m, _ := fieldCache.value.Load().(map[fieldCacheKey][]field)
+	f := m[key]
 	if f != nil {
 		return f
 	}
@@ -89,12 +407,12 @@ func cachedTypeFields(t reflect.Type, cfg *Config) []field {
 	}
 
 	fieldCache.mu.Lock()
-	m, _ = fieldCache.value.Load().(map[reflect.Type][]field)
-	newM := make(map[reflect.Type][]field, len(m)+1)
+	m, _ = fieldCache.value.Load().(map[fieldCacheKey][]field)
+	newM := make(map[fieldCacheKey][]field, len(m)+1)
 	for k, v := range m {
 		newM[k] = v
 	}
-	newM[t] = f
+	newM[key] = f
 	fieldCache.value.Store(newM)
 	fieldCache.mu.Unlock()
 	return f
@@ -102,7 +420,8 @@ func cachedTypeFields(t reflect.Type, cfg *Config) []field {
 
 // typeFields returns a list of fields that should be recognized for the given
 // type. The algorithm is breadth-first search over the set of structs to
-// include - the top struct and then any reachable anonymous structs.
+// include - the top struct and then any reachable anonymous structs, or structs
+// tagged with the inline option.
 func typeFields(t reflect.Type, cfg *Config) []field {
 	// Anonymous fields to explore at the current level and the next.
 	current := []field{}
@@ -115,8 +434,9 @@ func typeFields(t reflect.Type, cfg *Config) []field {
 	// Types already visited at an earlier level.
 	visited := map[reflect.Type]bool{}
 
-	// Fields found.
-	var fields []field
+	// Fields found. Most structs have no embedded fields, so the top-level
+	// field count is a good guess at the final length.
+	fields := make([]field, 0, t.NumField())
 
 	for len(next) > 0 {
 		current, next = next, current[:0]
@@ -158,7 +478,10 @@ func typeFields(t reflect.Type, cfg *Config) []field {
 				}
 				// TODO: Consider adding a check to ensure `name` is a valid key
 				if name == "" {
-					name = sf.Name
+					// Untagged fields are named, and so resolved below, by
+					// their final keys; KeyCase and NameFunc may map
+					// distinct Go names to the same key.
+					name = cfg.fieldName(sf.Name)
 				}
 
 				index := make([]int, len(f.index)+1)
@@ -172,15 +495,20 @@ func typeFields(t reflect.Type, cfg *Config) []field {
 					sft = sft.Elem()
 				}
 
+				// Fields tagged with the inline option have their fields
+				// hoisted into the parent, as if they had been embedded.
+				inline := opts.Contains("inline") && sft.Kind() == reflect.Struct
+
 				// Record the found field and index sequence ...
-				if tagged || !isEmbedded || sft.Kind() != reflect.Struct {
-					fields = append(fields, fillField(field{
+				if !inline && (tagged || !isEmbedded || sft.Kind() != reflect.Struct) {
+					fields = append(fields, field{
 						name:    name,
 						tagged:  tagged,
+						inlined: f.inlined,
 						index:   index,
 						typ:     sft,
 						options: opts,
-					}))
+					})
 					if count[f.typ] > 1 {
 						// If there were multiple instances, add a second, so
 						// that the annihilation code will see a duplicate.
@@ -195,11 +523,12 @@ func typeFields(t reflect.Type, cfg *Config) []field {
 				// next round.
 				nextCount[sft]++
 				if nextCount[sft] == 1 {
-					next = append(next, fillField(field{
-						name:  sft.Name(),
-						index: index,
-						typ:   sft,
-					}))
+					next = append(next, field{
+						name:    sft.Name(),
+						inlined: f.inlined || inline,
+						index:   index,
+						typ:     sft,
+					})
 				}
 			}
 		}
@@ -253,6 +582,13 @@ func typeFields(t reflect.Type, cfg *Config) []field {
 		// is an error in Go -- we mirror the compile-time "ambiguous selector"
 		// error as closely as possible at runtime. With a panic.
 		contended := fields[i : i+count]
+		// Unlike embedded fields, inlined fields are not shadowed; they must
+		// not collide with any other field.
+		for _, f := range contended {
+			if f.inlined {
+				panic(fmt.Errorf("encmap: inlined field name '%s' collides with another field in %s", f.name, t.Name()))
+			}
+		}
 		taggedIndex := -1
 		for j, f := range contended {
 			// A shorter index length indicates a more dominant field. The
@@ -287,6 +623,11 @@ func typeFields(t reflect.Type, cfg *Config) []field {
 	}
 
 	fields = out
+	// Only the surviving fields need their name bytes and fold functions; the
+	// hidden fields and queued anonymous structs never do.
+	for i := range fields {
+		fields[i] = fillField(fields[i])
+	}
 	sort.Sort(byIndex(fields))
 
 	return fields
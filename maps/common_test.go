@@ -0,0 +1,118 @@
+package maps_test
+
+import (
+	"testing"
+
+	"github.com/pyrrho/encoding/maps"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigClone(t *testing.T) {
+	require := require.New(t)
+
+	orig := &maps.Config{TagName: "map", TypeField: "__type"}
+	clone := orig.Clone()
+	require.Equal(orig, clone)
+	require.False(orig == clone)
+
+	clone.TagName = "map_key"
+	clone.TypeField = ""
+	require.Equal("map", orig.TagName)
+	require.Equal("__type", orig.TypeField)
+}
+
+func TestConfigWith(t *testing.T) {
+	require := require.New(t)
+
+	orig := &maps.Config{TagName: "map"}
+	variant := orig.With(
+		maps.WithTagName("map_key"),
+		maps.WithTypeField("__type"),
+	)
+	require.Equal(&maps.Config{TagName: "map_key", TypeField: "__type"}, variant)
+	// The original must be unmodified.
+	require.Equal(&maps.Config{TagName: "map"}, orig)
+
+	// Options are applied in order.
+	last := orig.With(maps.WithTagName("a"), maps.WithTagName("b"))
+	require.Equal("b", last.TagName)
+
+	// With no options, With is equivalent to Clone.
+	require.Equal(orig, orig.With())
+	require.False(orig == orig.With())
+
+	// Variant configs are usable for marshaling.
+	s := &DifferentTags{
+		42,
+		3.14,
+		"Hello World",
+		complex(1, 2),
+	}
+	actual, err := variant.Marshal(s)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{
+		"__type":      "DifferentTags",
+		"field_one":   42,
+		"field_two":   float64(3.14),
+		"field_three": "Hello World",
+		"field_four":  complex(1, 2),
+	}, actual)
+}
+
+type JSONPresetStruct struct {
+	Name    string   `json:"name"`
+	Count   int      `json:"count"`
+	Tags    []string `json:"tags"`
+	Pointer *int     `json:"pointer"`
+}
+
+type CompactPresetStruct struct {
+	Name    string
+	Count   int
+	Tags    []string
+	Pointer *int
+}
+
+func TestPresetConfigs(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		err    error
+		actual map[string]interface{}
+	)
+
+	// JSONCompatibleConfig reads json tags and drops empty values, but keeps
+	// zero and nil values.
+	actual, err = maps.JSONCompatibleConfig().Marshal(&JSONPresetStruct{
+		Tags: []string{},
+	})
+	require.NoError(err)
+	require.Equal(map[string]interface{}{
+		"count":   0,
+		"pointer": (*int)(nil),
+	}, actual)
+
+	// CompactConfig drops zero and nil values.
+	s := &CompactPresetStruct{
+		Tags: []string{},
+	}
+	actual, err = maps.CompactConfig().Marshal(s)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{}, actual)
+
+	s.Name = "name"
+	s.Count = 1
+	actual, err = maps.CompactConfig().Marshal(s)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{
+		"Name":  "name",
+		"Count": 1,
+	}, actual)
+
+	// Each call returns a fresh Config.
+	a, b := maps.CompactConfig(), maps.CompactConfig()
+	require.Equal(a, b)
+	require.False(a == b)
+	a.TagName = "json"
+	require.Equal("map", maps.CompactConfig().TagName)
+}
@@ -1,8 +1,11 @@
 package maps
 
 import (
+	"database/sql"
 	"errors"
+	"fmt"
 	"reflect"
+	"strconv"
 )
 
 func Unmarshal(src interface{}, v interface{}) error {
@@ -19,6 +22,45 @@ type Unmarshaler interface {
 
 var unmarshalerType = reflect.TypeOf(new(Unmarshaler)).Elem()
 
+// Validator is implemented by types that check their own invariants. After
+// Unmarshal has populated a struct -- either the destination itself or a
+// nested struct field -- it will call Validate on that struct, and return the
+// first error encountered. Nested structs are validated before the structs
+// that contain them.
+type Validator interface {
+	Validate() error
+}
+
+// DecodeError is returned by Unmarshal when a value in src cannot be assigned
+// to, or converted to the type of, its destination. Errors reported by Scanners
+// and Validators are returned as they are if raised for the destination itself,
+// or wrapped with the name of the field they were raised for otherwise; they
+// may be recovered with errors.Is and errors.As.
+type DecodeError struct {
+	// Path locates the value within src, in the syntax accepted by
+	// types.RawJSON.Get; eg. "user.addresses[0].zip".
+	Path string
+	// Key is the map key under which the value was found; the last key of
+	// Path.
+	Key string
+	// Expected is the type of the destination.
+	Expected reflect.Type
+	// Got is the type of the value.
+	Got reflect.Type
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("encoding/maps: cannot unmarshal %s into a value of type %s at %q", e.Got, e.Expected, e.Path)
+}
+
+// joinPath appends key to path, in the syntax of DecodeError.Path.
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
 func (cfg *Config) Unmarshal(src interface{}, v interface{}) error {
 	err := cfg.unmarshal(src, v)
 	if err != nil {
@@ -27,12 +69,317 @@ func (cfg *Config) Unmarshal(src interface{}, v interface{}) error {
 	return nil
 }
 
-func (cfg *Config) unmarshal(src interface{}, v interface{}) error {
+func (cfg *Config) unmarshal(src interface{}, v interface{}) (err error) {
 	rv := reflect.ValueOf(v)
 	if rv.Kind() != reflect.Ptr {
 		return errors.New("encoding/maps: cannot unmarshal into non-pointer")
 	} else if rv.IsNil() {
 		return errors.New("encoding/maps: cannot unmarshal into nil pointer")
 	}
-	return errors.New("enc_map.Scan has not yet been implemented")
+	if s, ok := v.(Scanner); ok {
+		return s.ScanMap(src)
+	}
+	m, ok := src.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("encoding/maps: cannot unmarshal %T, src must be a map[string]interface{}", src)
+	}
+
+	// typeFields panics on ambiguous field names; convert those panics to
+	// errors, as marshal does.
+	defer cfg.recoverError(&err)
+	return cfg.decodeStruct("", m, rv.Elem())
+}
+
+// decodeStruct assigns the values of src to the fields of the struct dst, using
+// the same field names Marshal would use to encode dst. Keys in src with no
+// corresponding field, and fields with no corresponding key, are ignored; unless
+// the field has been tagged with a `default=` option, in which case it will be
+// assigned that default.
+// Fields of embedded structs are assigned as if they were fields of dst, and
+// nil embedded pointers will be allocated as needed. path locates src within
+// the document being unmarshaled.
+func (cfg *Config) decodeStruct(path string, src map[string]interface{}, dst reflect.Value) error {
+	if dst.Kind() != reflect.Struct {
+		return fmt.Errorf("encoding/maps: cannot unmarshal into non-struct type %s", dst.Type())
+	}
+	for _, f := range cachedTypeFields(dst.Type(), cfg) {
+		key := f.name
+		val, ok := src[key]
+		if !ok {
+			def, ok := f.options.getOption("default")
+			if !ok {
+				continue
+			}
+			var err error
+			if val, err = parseDefault(def, f.typ); err != nil {
+				return fmt.Errorf("encoding/maps: invalid default %q for field %s of %s: %v", def, f.name, dst.Type(), err)
+			}
+		}
+		fv, err := allocFieldByIndex(dst, f.index)
+		if err != nil {
+			return err
+		}
+		decode := cfg.decodeValue
+		if f.options.Contains("value") {
+			decode = decodeAsValue
+		}
+		if err := decode(joinPath(path, key), val, fv); err != nil {
+			if de, ok := err.(*DecodeError); ok {
+				if de.Key == "" {
+					de.Key = key
+				}
+				return de
+			}
+			return fmt.Errorf("encoding/maps: cannot unmarshal field %s of %s: %w", f.name, dst.Type(), err)
+		}
+	}
+	return validateStruct(dst)
+}
+
+// parseDefault converts def, the value of a field's `default=` tag option, into
+// a value that decodeValue can assign to a field of type t. Bools and numbers
+// are parsed according to the kind of t, or of the type t points to. Any other
+// default is left as a string, to be scanned or converted by decodeValue.
+func parseDefault(def string, t reflect.Type) (interface{}, error) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	v := reflect.New(t).Elem()
+	switch t.Kind() {
+	case reflect.Bool:
+		b, err := strconv.ParseBool(def)
+		if err != nil {
+			return nil, err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(def, 10, t.Bits())
+		if err != nil {
+			return nil, err
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(def, 10, t.Bits())
+		if err != nil {
+			return nil, err
+		}
+		v.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(def, t.Bits())
+		if err != nil {
+			return nil, err
+		}
+		v.SetFloat(f)
+	default:
+		return def, nil
+	}
+	return v.Interface(), nil
+}
+
+// decodeAsValue is the decoding counterpart of the value tag option. src is
+// assigned to dst as-is, without recursion, scanning, or coercion; nil zeroes
+// dst, and any other src must be assignable to dst's type.
+func decodeAsValue(path string, src interface{}, dst reflect.Value) error {
+	if src == nil {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+	srcv := reflect.ValueOf(src)
+	if !srcv.Type().AssignableTo(dst.Type()) {
+		return &DecodeError{Path: path, Expected: dst.Type(), Got: srcv.Type()}
+	}
+	dst.Set(srcv)
+	return nil
+}
+
+// validateStruct calls Validate on dst, or on the address of dst, if either
+// implements Validator.
+func validateStruct(dst reflect.Value) error {
+	if !dst.CanInterface() {
+		return nil
+	}
+	if v, ok := dst.Interface().(Validator); ok {
+		return v.Validate()
+	}
+	if dst.CanAddr() {
+		if v, ok := dst.Addr().Interface().(Validator); ok {
+			return v.Validate()
+		}
+	}
+	return nil
+}
+
+// decodeValue assigns src to dst. Types that implement Scanner -- directly or
+// through an addressable pointer receiver -- are always given src via ScanMap.
+// Beyond that, values that are directly assignable to dst's type -- eg. the
+// time.Time and types.SFPoint values produced by their respective
+// MarshalMapValue implementations -- are assigned as-is without any coercion.
+// Otherwise, in order of precedence,
+//   - interfaces are decoded into the concrete type chosen by the Config's
+//     InterfaceResolver, if it chooses one,
+//   - nil pointers are allocated, and src is decoded into the pointed-to value,
+//   - types implementing the database/sql Scanner interface -- including the
+//     pyrrho/encoding/types/null types -- are passed src via Scan,
+//   - structs are decoded from a map[string]interface{} src,
+//   - slices and arrays of structs are decoded from a []map[string]interface{}
+//     src, and
+//   - numbers, strings, and bools are converted to dst's type if that
+//     conversion does not lose information.
+//
+// path locates src within the document being unmarshaled.
+func (cfg *Config) decodeValue(path string, src interface{}, dst reflect.Value) error {
+	if dst.Kind() != reflect.Interface && dst.Type().Implements(scannerType) {
+		return decodeScanner(src, dst)
+	}
+	if dst.Kind() != reflect.Ptr && dst.CanAddr() && reflect.PtrTo(dst.Type()).Implements(scannerType) {
+		return decodeAddrScanner(src, dst)
+	}
+	if dst.Kind() == reflect.Interface && src != nil && cfg.InterfaceResolver != nil {
+		if t, ok := cfg.InterfaceResolver(path, src); ok {
+			return cfg.decodeInterface(path, src, dst, t)
+		}
+	}
+	if src != nil {
+		srcv := reflect.ValueOf(src)
+		if srcv.Type().AssignableTo(dst.Type()) {
+			dst.Set(srcv)
+			return nil
+		}
+	}
+	if dst.Kind() == reflect.Ptr {
+		if src == nil {
+			dst.Set(reflect.Zero(dst.Type()))
+			return nil
+		}
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+		return cfg.decodeValue(path, src, dst.Elem())
+	}
+	if dst.CanAddr() {
+		if s, ok := dst.Addr().Interface().(sql.Scanner); ok {
+			return s.Scan(src)
+		}
+	}
+	if src == nil {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+	if m, ok := src.(map[string]interface{}); ok && dst.Kind() == reflect.Struct {
+		return cfg.decodeStruct(path, m, dst)
+	}
+	if ms, ok := src.([]map[string]interface{}); ok && (dst.Kind() == reflect.Slice || dst.Kind() == reflect.Array) {
+		return cfg.decodeStructSlice(path, ms, dst)
+	}
+	if cv, ok := convertValue(reflect.ValueOf(src), dst.Type()); ok {
+		dst.Set(cv)
+		return nil
+	}
+	return &DecodeError{Path: path, Expected: dst.Type(), Got: reflect.TypeOf(src)}
+}
+
+// decodeInterface decodes src into a new value of type t, as chosen by the
+// Config's InterfaceResolver, and assigns that value to the interface dst.
+func (cfg *Config) decodeInterface(path string, src interface{}, dst reflect.Value, t reflect.Type) error {
+	if t == nil || !t.AssignableTo(dst.Type()) {
+		return fmt.Errorf("encoding/maps: InterfaceResolver chose %v at %q, which cannot be assigned to a value of type %s", t, path, dst.Type())
+	}
+	v := reflect.New(t).Elem()
+	if err := cfg.decodeValue(path, src, v); err != nil {
+		return err
+	}
+	dst.Set(v)
+	return nil
+}
+
+// decodeStructSlice is the decoding counterpart of structSliceEncoder. Each map
+// in src is decoded into the corresponding element of dst; slices are
+// reallocated to the length of src, and arrays must be at least that long. Nil
+// maps leave their elements zeroed.
+func (cfg *Config) decodeStructSlice(path string, src []map[string]interface{}, dst reflect.Value) error {
+	if dst.Kind() == reflect.Slice {
+		if src == nil {
+			dst.Set(reflect.Zero(dst.Type()))
+			return nil
+		}
+		dst.Set(reflect.MakeSlice(dst.Type(), len(src), len(src)))
+	} else if len(src) > dst.Len() {
+		return fmt.Errorf("encoding/maps: cannot unmarshal %d elements into a value of type %s", len(src), dst.Type())
+	} else {
+		dst.Set(reflect.Zero(dst.Type()))
+	}
+	for i, m := range src {
+		if m == nil {
+			continue
+		}
+		if err := cfg.decodeValue(path+"["+strconv.Itoa(i)+"]", m, dst.Index(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// allocFieldByIndex is the decoding counterpart of fieldByIndex. Rather than
+// returning an invalid reflect.Value when it encounters a nil embedded pointer,
+// it will allocate a new value for that pointer.
+func allocFieldByIndex(v reflect.Value, index []int) (reflect.Value, error) {
+	for _, i := range index {
+		if v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				if !v.CanSet() {
+					return reflect.Value{}, fmt.Errorf("encoding/maps: cannot set embedded pointer to unexported struct %s", v.Type().Elem())
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(i)
+	}
+	return v, nil
+}
+
+// convertValue attempts to convert the scalar v into a value of type t. Numeric
+// conversions are only performed if the value survives the conversion intact;
+// no overflow, truncation, or change of sign. Strings and bools will only be
+// converted to other string and bool types, respectively.
+func convertValue(v reflect.Value, t reflect.Type) (reflect.Value, bool) {
+	switch v.Kind() {
+	case reflect.Bool:
+		if t.Kind() == reflect.Bool {
+			return v.Convert(t), true
+		}
+	case reflect.String:
+		if t.Kind() == reflect.String {
+			return v.Convert(t), true
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		switch t.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+			cv := v.Convert(t)
+			// Converting back to the source type and comparing catches
+			// overflow and truncation. Comparing signs catches conversions
+			// between signed and unsigned integers that wrap in both
+			// directions.
+			if cv.Convert(v.Type()).Interface() != v.Interface() ||
+				isNegative(cv) != isNegative(v) {
+				return reflect.Value{}, false
+			}
+			return cv, true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+func isNegative(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() < 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() < 0
+	}
+	return false
 }
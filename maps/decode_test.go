@@ -1 +1,652 @@
 package maps_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/pyrrho/encoding/maps"
+	"github.com/pyrrho/encoding/types"
+	"github.com/pyrrho/encoding/types/null"
+	"github.com/stretchr/testify/require"
+)
+
+type TypedValueStruct struct {
+	When      null.Time
+	NeverWhen null.Time
+	Where     types.SFPoint
+}
+
+func TestUnmarshalTypedValues(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		err    error
+		data   map[string]interface{}
+		actual TypedValueStruct
+	)
+
+	now := time.Date(2018, time.June, 4, 12, 30, 0, 0, time.UTC)
+	s := TypedValueStruct{
+		When:      null.NewTime(now),
+		NeverWhen: null.NullTime(),
+		Where:     types.NewSFPointXY(1.2, 2.3),
+	}
+
+	data, err = maps.Marshal(s)
+	require.NoError(err)
+	// Sanity check; these values must be typed, not coerced to strings or
+	// maps.
+	require.Equal(now, data["When"])
+	require.Equal(nil, data["NeverWhen"])
+	require.Equal(types.NewSFPointXY(1.2, 2.3), data["Where"])
+
+	err = maps.Unmarshal(data, &actual)
+	require.NoError(err)
+	require.Equal(s, actual)
+
+	// Typed values assign directly into fields of the same type.
+	type Plain struct {
+		Instant time.Time
+		Where   types.SFPoint
+	}
+	var plain Plain
+	err = maps.Unmarshal(map[string]interface{}{
+		"Instant": now,
+		"Where":   types.NewSFPointXY(1.2, 2.3),
+	}, &plain)
+	require.NoError(err)
+	require.Equal(Plain{now, types.NewSFPointXY(1.2, 2.3)}, plain)
+}
+
+func TestUnmarshalErrors(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		err error
+		s   TypedValueStruct
+	)
+
+	err = maps.Unmarshal(map[string]interface{}{}, s)
+	require.Error(err)
+
+	err = maps.Unmarshal(map[string]interface{}{}, (*TypedValueStruct)(nil))
+	require.Error(err)
+
+	err = maps.Unmarshal([]int{1, 2, 3}, &s)
+	require.Error(err)
+
+	// Scanners report their own errors.
+	err = maps.Unmarshal(map[string]interface{}{"Where": "here"}, &s)
+	require.Error(err)
+	require.Contains(err.Error(), "SFPoint:")
+
+	type Plain struct{ Instant time.Time }
+	var plain Plain
+	err = maps.Unmarshal(map[string]interface{}{"Instant": "yesterday"}, &plain)
+	require.Error(err)
+	require.Contains(err.Error(), "encoding/maps:")
+
+	// Ambiguous and colliding field names are reported as errors, as they are
+	// by Marshal.
+	err = maps.Unmarshal(map[string]interface{}{"Name": 1}, &AmbiguousStruct{})
+	require.Error(err)
+	require.Contains(err.Error(), "ambiguous")
+
+	err = maps.Unmarshal(map[string]interface{}{"CreatedAt": 1}, &CollidingDocument{})
+	require.Error(err)
+	require.Contains(err.Error(), "CreatedAt")
+}
+
+type AmbiguousLeft struct {
+	A int `map:"Name"`
+}
+
+type AmbiguousRight struct {
+	B int `map:"Name"`
+}
+
+type AmbiguousStruct struct {
+	AmbiguousLeft
+	AmbiguousRight
+}
+
+func TestUnmarshalRoundTrip(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		err  error
+		data map[string]interface{}
+	)
+
+	simple := SimpleStruct{42, 3.14, "Hello World", complex(1, 2)}
+	data, err = maps.Marshal(simple)
+	require.NoError(err)
+	var actualSimple SimpleStruct
+	err = maps.Unmarshal(data, &actualSimple)
+	require.NoError(err)
+	require.Equal(simple, actualSimple)
+
+	nested := ParentStruct{map[int]int{1: 2, 3: 4}, NestedStruct{5, 6.7}}
+	data, err = maps.Marshal(nested)
+	require.NoError(err)
+	var actualNested ParentStruct
+	err = maps.Unmarshal(data, &actualNested)
+	require.NoError(err)
+	require.Equal(nested, actualNested)
+
+	embedded := TopLevelStruct{42, WeMust{Go{Deeper{0, 2}}}}
+	data, err = maps.Marshal(embedded)
+	require.NoError(err)
+	var actualEmbedded TopLevelStruct
+	err = maps.Unmarshal(data, &actualEmbedded)
+	require.NoError(err)
+	require.Equal(embedded, actualEmbedded)
+
+	contended := LevelOne{
+		LevelTwoLeft{100, "foo", 3.14},
+		LevelTwoRight{200, LevelThree{}},
+	}
+	data, err = maps.Marshal(contended)
+	require.NoError(err)
+	var actualContended LevelOne
+	err = maps.Unmarshal(data, &actualContended)
+	require.NoError(err)
+	// LevelTwoLeft.AnInt is shadowed by the tagged LevelTwoRight.AnInt, so it
+	// is neither encoded nor decoded.
+	contended.LevelTwoLeft.AnInt = 0
+	require.Equal(contended, actualContended)
+}
+
+func TestUnmarshalTags(t *testing.T) {
+	require := require.New(t)
+
+	var actual SimpleStructWithTags
+	err := maps.Unmarshal(map[string]interface{}{
+		"FieldOne":    42,
+		"FieldTwo":    3.14, // explicitly ignored
+		"field_three": "Hello World",
+		"field_four":  complex(1, 2), // unexported
+		"Unknown":     "ignored",
+	}, &actual)
+	require.NoError(err)
+	require.Equal(SimpleStructWithTags{FieldOne: 42, FieldThree: "Hello World"}, actual)
+}
+
+type DefaultedStruct struct {
+	Name    string     `map:"name,default=foo"`
+	Count   int        `map:"count,default=3"`
+	Ratio   float64    `map:",default=0.5"`
+	Enabled bool       `map:",default=true"`
+	Limit   *uint8     `map:",default=8"`
+	Null    null.Int64 `map:",default=42"`
+	Plain   int
+}
+
+func TestUnmarshalDefaults(t *testing.T) {
+	require := require.New(t)
+
+	var actual DefaultedStruct
+	err := maps.Unmarshal(map[string]interface{}{}, &actual)
+	require.NoError(err)
+	limit := uint8(8)
+	require.Equal(DefaultedStruct{
+		Name:    "foo",
+		Count:   3,
+		Ratio:   0.5,
+		Enabled: true,
+		Limit:   &limit,
+		Null:    null.NewInt64(42),
+	}, actual)
+
+	// Defaults are only used for missing keys; present keys, even nil ones,
+	// are decoded as usual.
+	actual = DefaultedStruct{}
+	err = maps.Unmarshal(map[string]interface{}{
+		"name":    "bar",
+		"count":   0,
+		"Enabled": false,
+		"Null":    nil,
+	}, &actual)
+	require.NoError(err)
+	require.Equal("bar", actual.Name)
+	require.Equal(0, actual.Count)
+	require.Equal(false, actual.Enabled)
+	require.False(actual.Null.Valid)
+	require.Equal(0.5, actual.Ratio)
+
+	var bad struct {
+		Count int `map:",default=many"`
+	}
+	err = maps.Unmarshal(map[string]interface{}{}, &bad)
+	require.Error(err)
+	require.Contains(err.Error(), "many")
+}
+
+type AsValueFields struct {
+	Any    interface{}        `map:",value"`
+	Child  TaggedAsValueChild `map:",value"`
+	Null   null.Int64         `map:",value"`
+	Scalar int64              `map:",value"`
+}
+
+func TestUnmarshalAsValue(t *testing.T) {
+	require := require.New(t)
+
+	s := AsValueFields{
+		Any:    map[string]interface{}{"nested": []int{1, 2}},
+		Child:  TaggedAsValueChild{3.14, true},
+		Null:   null.NewInt64(42),
+		Scalar: 7,
+	}
+	data, err := maps.Marshal(s)
+	require.NoError(err)
+	require.Equal(s.Any, data["Any"])
+
+	var actual AsValueFields
+	err = maps.Unmarshal(data, &actual)
+	require.NoError(err)
+	require.Equal(s, actual)
+
+	// Values are assigned without coercion ...
+	err = maps.Unmarshal(map[string]interface{}{"Scalar": 7}, &actual)
+	require.Error(err)
+	err = maps.Unmarshal(map[string]interface{}{"Null": int64(42)}, &actual)
+	require.Error(err)
+	err = maps.Unmarshal(map[string]interface{}{
+		"Child": map[string]interface{}{"AFloat": 3.14},
+	}, &actual)
+	require.Error(err)
+
+	// ... and nil zeroes the field.
+	err = maps.Unmarshal(map[string]interface{}{"Any": nil, "Null": nil}, &actual)
+	require.NoError(err)
+	require.Nil(actual.Any)
+	require.Equal(null.Int64{}, actual.Null)
+}
+
+type EmbeddedPointerParent struct {
+	AnInt int
+	*EmbeddedPointerChild
+}
+
+type EmbeddedPointerChild struct {
+	AString string
+}
+
+func TestUnmarshalEmbeddedPointer(t *testing.T) {
+	require := require.New(t)
+
+	var actual EmbeddedPointerParent
+	err := maps.Unmarshal(map[string]interface{}{
+		"AnInt":   42,
+		"AString": "Hello World",
+	}, &actual)
+	require.NoError(err)
+	require.Equal(42, actual.AnInt)
+	require.NotNil(actual.EmbeddedPointerChild)
+	require.Equal("Hello World", actual.AString)
+}
+
+type NullAndPointerStruct struct {
+	AnInt     null.Int64
+	AString   null.String
+	NullFloat null.Float64
+	IntP      *int
+	NilIntP   *int
+}
+
+func TestUnmarshalNullsAndPointers(t *testing.T) {
+	require := require.New(t)
+
+	var actual NullAndPointerStruct
+	err := maps.Unmarshal(map[string]interface{}{
+		"AnInt":     int64(42),
+		"AString":   "Hello World",
+		"NullFloat": nil,
+		"IntP":      7,
+		"NilIntP":   nil,
+	}, &actual)
+	require.NoError(err)
+	require.Equal(null.NewInt64(42), actual.AnInt)
+	require.Equal(null.NewString("Hello World"), actual.AString)
+	require.Equal(null.NullFloat64(), actual.NullFloat)
+	require.NotNil(actual.IntP)
+	require.Equal(7, *actual.IntP)
+	require.Nil(actual.NilIntP)
+}
+
+func TestUnmarshalConversions(t *testing.T) {
+	require := require.New(t)
+
+	type Numbers struct {
+		AnInt   int
+		AUint8  uint8
+		AFloat  float32
+		AString string
+	}
+	var (
+		err    error
+		actual Numbers
+	)
+
+	// JSON-style float64s can be converted to integers if they're whole, and
+	// in range.
+	err = maps.Unmarshal(map[string]interface{}{
+		"AnInt":  float64(42),
+		"AUint8": 255,
+		"AFloat": 1.5,
+	}, &actual)
+	require.NoError(err)
+	require.Equal(Numbers{AnInt: 42, AUint8: 255, AFloat: 1.5}, actual)
+
+	err = maps.Unmarshal(map[string]interface{}{"AnInt": 4.2}, &actual)
+	require.Error(err)
+	require.Contains(err.Error(), "AnInt")
+
+	err = maps.Unmarshal(map[string]interface{}{"AUint8": 256}, &actual)
+	require.Error(err)
+	require.Contains(err.Error(), "AUint8")
+
+	err = maps.Unmarshal(map[string]interface{}{"AUint8": -1}, &actual)
+	require.Error(err)
+
+	err = maps.Unmarshal(map[string]interface{}{"AString": 42}, &actual)
+	require.Error(err)
+	require.Contains(err.Error(), "AString")
+}
+
+// Accumulator implements maps.Scanner with a pointer receiver, recording every
+// value it is asked to scan.
+type Accumulator struct {
+	Seen []interface{}
+}
+
+func (a *Accumulator) ScanMap(src interface{}) error {
+	if s, ok := src.(string); ok && s == "fail" {
+		return errors.New("Accumulator: asked to fail")
+	}
+	a.Seen = append(a.Seen, src)
+	return nil
+}
+
+type AccumulatorParent struct {
+	AnInt int
+	Acc   Accumulator
+	AccP  *Accumulator
+}
+
+func TestUnmarshalScanner(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		err    error
+		actual AccumulatorParent
+	)
+
+	// Fields are given the raw value through ScanMap, be it a sub-map ...
+	err = maps.Unmarshal(map[string]interface{}{
+		"AnInt": 42,
+		"Acc":   map[string]interface{}{"foo": "bar"},
+		"AccP":  "Hello World",
+	}, &actual)
+	require.NoError(err)
+	require.Equal(42, actual.AnInt)
+	require.Equal([]interface{}{map[string]interface{}{"foo": "bar"}}, actual.Acc.Seen)
+	require.NotNil(actual.AccP)
+	require.Equal([]interface{}{"Hello World"}, actual.AccP.Seen)
+
+	// ... or a nil. Scanners accumulate state across calls.
+	err = maps.Unmarshal(map[string]interface{}{
+		"Acc":  nil,
+		"AccP": 3.14,
+	}, &actual)
+	require.NoError(err)
+	require.Equal([]interface{}{map[string]interface{}{"foo": "bar"}, nil}, actual.Acc.Seen)
+	require.Equal([]interface{}{"Hello World", 3.14}, actual.AccP.Seen)
+
+	// Top-level targets that implement Scanner are given the whole src, even if
+	// it isn't a map.
+	var top Accumulator
+	err = maps.Unmarshal([]int{1, 2, 3}, &top)
+	require.NoError(err)
+	require.Equal([]interface{}{[]int{1, 2, 3}}, top.Seen)
+
+	// Errors from ScanMap are returned.
+	err = maps.Unmarshal(map[string]interface{}{"Acc": "fail"}, &actual)
+	require.Error(err)
+	require.Contains(err.Error(), "Accumulator: asked to fail")
+}
+
+// NonNegative implements maps.Validator, rejecting negative Values with a
+// NegativeError.
+type NonNegative struct {
+	Value int
+}
+
+func (n NonNegative) Validate() error {
+	if n.Value < 0 {
+		return &NegativeError{n.Value}
+	}
+	return nil
+}
+
+type NegativeError struct {
+	Value int
+}
+
+func (e *NegativeError) Error() string {
+	return "NonNegative: Value must not be negative"
+}
+
+type NonNegativeParent struct {
+	Name  string
+	Child NonNegative
+}
+
+// Validate is implemented with a pointer receiver, and rejects empty Names.
+func (p *NonNegativeParent) Validate() error {
+	if p.Name == "" {
+		return errors.New("NonNegativeParent: Name must not be empty")
+	}
+	return nil
+}
+
+func TestUnmarshalValidator(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	var n NonNegative
+	err = maps.Unmarshal(map[string]interface{}{"Value": 42}, &n)
+	require.NoError(err)
+	require.Equal(42, n.Value)
+
+	// Validation errors are returned from Unmarshal ...
+	err = maps.Unmarshal(map[string]interface{}{"Value": -1}, &n)
+	require.Error(err)
+	require.Equal("NonNegative: Value must not be negative", err.Error())
+
+	// ... including those of nested structs, which are validated first ...
+	var p NonNegativeParent
+	err = maps.Unmarshal(map[string]interface{}{
+		"Child": map[string]interface{}{"Value": -1},
+	}, &p)
+	require.Error(err)
+	require.Contains(err.Error(), "NonNegative: Value must not be negative")
+	var negErr *NegativeError
+	require.True(errors.As(err, &negErr))
+	require.Equal(-1, negErr.Value)
+
+	// ... and those of pointer-receiver Validators.
+	err = maps.Unmarshal(map[string]interface{}{
+		"Child": map[string]interface{}{"Value": 1},
+	}, &p)
+	require.Error(err)
+	require.Contains(err.Error(), "NonNegativeParent: Name must not be empty")
+
+	err = maps.Unmarshal(map[string]interface{}{
+		"Name":  "parent",
+		"Child": map[string]interface{}{"Value": 1},
+	}, &p)
+	require.NoError(err)
+}
+
+type DecodeErrorLeaf struct {
+	Count int `map:"count"`
+}
+
+type DecodeErrorMiddle struct {
+	Leaf   DecodeErrorLeaf   `map:"leaf"`
+	Leaves []DecodeErrorLeaf `map:"leaves"`
+}
+
+type DecodeErrorRoot struct {
+	Middle DecodeErrorMiddle `map:"middle"`
+}
+
+func TestUnmarshalDecodeError(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		err    error
+		de     *maps.DecodeError
+		actual DecodeErrorRoot
+	)
+
+	err = maps.Unmarshal(map[string]interface{}{
+		"middle": map[string]interface{}{
+			"leaf": map[string]interface{}{"count": "many"},
+		},
+	}, &actual)
+	require.True(errors.As(err, &de))
+	require.Equal("middle.leaf.count", de.Path)
+	require.Equal("count", de.Key)
+	require.Equal(reflect.TypeOf(0), de.Expected)
+	require.Equal(reflect.TypeOf(""), de.Got)
+	require.Contains(err.Error(), "encoding/maps:")
+	require.Contains(err.Error(), `"middle.leaf.count"`)
+
+	// Lossy conversions are reported the same way.
+	err = maps.Unmarshal(map[string]interface{}{
+		"middle": map[string]interface{}{
+			"leaf": map[string]interface{}{"count": 1.5},
+		},
+	}, &actual)
+	require.True(errors.As(err, &de))
+	require.Equal("middle.leaf.count", de.Path)
+	require.Equal(reflect.TypeOf(1.5), de.Got)
+
+	// Slice elements are located by index.
+	err = maps.Unmarshal(map[string]interface{}{
+		"middle": map[string]interface{}{
+			"leaves": []map[string]interface{}{
+				{"count": 1},
+				{"count": true},
+			},
+		},
+	}, &actual)
+	require.True(errors.As(err, &de))
+	require.Equal("middle.leaves[1].count", de.Path)
+	require.Equal("count", de.Key)
+
+	// A mismatch at the struct itself names the struct's key.
+	err = maps.Unmarshal(map[string]interface{}{"middle": 42}, &actual)
+	require.True(errors.As(err, &de))
+	require.Equal("middle", de.Path)
+	require.Equal("middle", de.Key)
+	require.Equal(reflect.TypeOf(DecodeErrorMiddle{}), de.Expected)
+}
+
+type Shape interface {
+	Area() float64
+}
+
+type ShapeSquare struct {
+	Side float64 `map:"side"`
+}
+
+func (s ShapeSquare) Area() float64 { return s.Side * s.Side }
+
+type ShapeRect struct {
+	W float64 `map:"w"`
+	H float64 `map:"h"`
+}
+
+func (r *ShapeRect) Area() float64 { return r.W * r.H }
+
+type ShapeHolder struct {
+	Shape  Shape       `map:"shape"`
+	Shapes []Shape     `map:"shapes"`
+	Any    interface{} `map:"any"`
+}
+
+func TestUnmarshalInterfaceResolver(t *testing.T) {
+	require := require.New(t)
+
+	var paths []string
+	cfg := (&maps.Config{TagName: "map"}).With(maps.WithInterfaceResolver(
+		func(path string, src interface{}) (reflect.Type, bool) {
+			paths = append(paths, path)
+			m, ok := src.(map[string]interface{})
+			if !ok {
+				return nil, false
+			}
+			switch m["__type"] {
+			case "A":
+				return reflect.TypeOf(ShapeSquare{}), true
+			case "B":
+				return reflect.TypeOf(&ShapeRect{}), true
+			case "bad":
+				return reflect.TypeOf(0), true
+			}
+			return nil, false
+		}))
+
+	var actual ShapeHolder
+	err := cfg.Unmarshal(map[string]interface{}{
+		"shape": map[string]interface{}{"__type": "A", "side": 2.0},
+		"shapes": []map[string]interface{}{
+			{"__type": "B", "w": 2.0, "h": 3.0},
+			{"__type": "A", "side": 1.0},
+		},
+		"any": "untouched",
+	}, &actual)
+	require.NoError(err)
+	require.Equal(ShapeSquare{Side: 2}, actual.Shape)
+	require.Equal([]Shape{&ShapeRect{W: 2, H: 3}, ShapeSquare{Side: 1}}, actual.Shapes)
+	require.Equal("untouched", actual.Any)
+	require.Equal([]string{"shape", "shapes[0]", "shapes[1]", "any"}, paths)
+
+	// Unresolved values are assigned as-is, or fail as they would without a
+	// resolver.
+	actual = ShapeHolder{}
+	err = cfg.Unmarshal(map[string]interface{}{
+		"any": map[string]interface{}{"__type": "C"},
+	}, &actual)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{"__type": "C"}, actual.Any)
+
+	err = cfg.Unmarshal(map[string]interface{}{
+		"shape": map[string]interface{}{"__type": "C"},
+	}, &actual)
+	var de *maps.DecodeError
+	require.True(errors.As(err, &de))
+	require.Equal("shape", de.Path)
+
+	// Resolved types must implement the interface ...
+	err = cfg.Unmarshal(map[string]interface{}{
+		"shape": map[string]interface{}{"__type": "bad"},
+	}, &actual)
+	require.Error(err)
+	require.Contains(err.Error(), "InterfaceResolver")
+
+	// ... and decode errors are reported at their full path.
+	err = cfg.Unmarshal(map[string]interface{}{
+		"shapes": []map[string]interface{}{
+			{"__type": "A", "side": "wide"},
+		},
+	}, &actual)
+	require.True(errors.As(err, &de))
+	require.Equal("shapes[0].side", de.Path)
+}
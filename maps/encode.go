@@ -1,11 +1,15 @@
 package maps
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"reflect"
 	"runtime"
+	"strconv"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/pyrrho/encoding"
 )
@@ -19,7 +23,29 @@ func Marshal(src interface{}) (map[string]interface{}, error) {
 }
 
 func MarshalSlice(src interface{}) ([]map[string]interface{}, error) {
-	ret, err := defaultConfig.marshalSlice(src)
+	return defaultConfig.MarshalSliceContext(context.Background(), src)
+}
+
+// MarshalSliceContext is MarshalSlice, but checks ctx between the encoding of
+// each element of src. If ctx is done, encoding stops and ctx.Err() is
+// returned.
+func MarshalSliceContext(ctx context.Context, src interface{}) ([]map[string]interface{}, error) {
+	return defaultConfig.MarshalSliceContext(ctx, src)
+}
+
+// MarshalMap encodes each value of src, a map of structs or pointers-to-structs,
+// as Marshal does, and returns a map of the results under the same keys. Nil
+// values are stored as nil.
+func MarshalMap(src interface{}) (map[interface{}]interface{}, error) {
+	ret, err := defaultConfig.marshalMap(src)
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func MarshalWithConfig(src interface{}, cfg *Config) (map[string]interface{}, error) {
+	ret, err := cfg.marshal(src)
 	if err != nil {
 		return nil, err
 	}
@@ -32,6 +58,10 @@ type Marshaler interface {
 
 var marshalerType = reflect.TypeOf(new(Marshaler)).Elem()
 
+var timeType = reflect.TypeOf(time.Time{})
+
+var isNilerType = reflect.TypeOf(new(encoding.IsNiler)).Elem()
+
 func (cfg *Config) Marshal(src interface{}) (map[string]interface{}, error) {
 	ret, err := cfg.marshal(src)
 	if err != nil {
@@ -41,13 +71,52 @@ func (cfg *Config) Marshal(src interface{}) (map[string]interface{}, error) {
 }
 
 func (cfg *Config) MarshalSlice(src interface{}) ([]map[string]interface{}, error) {
-	ret, err := cfg.marshalSlice(src)
+	return cfg.MarshalSliceContext(context.Background(), src)
+}
+
+// MarshalSliceContext is cfg.MarshalSlice, but checks ctx between the encoding
+// of each element of src. If ctx is done, encoding stops and ctx.Err() is
+// returned.
+func (cfg *Config) MarshalSliceContext(ctx context.Context, src interface{}) ([]map[string]interface{}, error) {
+	ret, err := cfg.marshalSlice(ctx, src)
 	if err != nil {
 		return nil, err
 	}
 	return ret, nil
 }
 
+// MarshalMap is the package-level MarshalMap, but encodes with cfg.
+func (cfg *Config) MarshalMap(src interface{}) (map[interface{}]interface{}, error) {
+	ret, err := cfg.marshalMap(src)
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// recoverError is deferred by the entry points of the encoder and decoder to
+// convert panics raised while encoding or decoding -- including those raised by
+// typeFields for ambiguous field names -- into errors, which are stored in
+// *err. Runtime errors, raw strings, and values not of type `error` are not
+// converted; they're re-panicked. If cfg.PanicOnError is set, nothing is
+// recovered at all, leaving the stack intact.
+func (cfg *Config) recoverError(err *error) {
+	if cfg.PanicOnError {
+		return
+	}
+	if r := recover(); r != nil {
+		if _, ok := r.(runtime.Error); ok {
+			panic(r)
+		} else if s, ok := r.(string); ok {
+			panic(s)
+		} else if e, ok := r.(error); !ok {
+			panic(r)
+		} else {
+			*err = e
+		}
+	}
+}
+
 func (cfg *Config) marshal(src interface{}) (m map[string]interface{}, err error) {
 	srcv := reflect.ValueOf(src)
 	if srcv.Kind() == reflect.Ptr {
@@ -58,27 +127,14 @@ func (cfg *Config) marshal(src interface{}) (m map[string]interface{}, err error
 	}
 
 	// Any panics after this point should be converted to errors, and returned
-	// normally. Unless it's a runtime error, it's a raw string, or it's not of
-	// type `error`. In which case, do panic.
-	defer func() {
-		if r := recover(); r != nil {
-			if _, ok := r.(runtime.Error); ok {
-				panic(r)
-			} else if s, ok := r.(string); ok {
-				panic(s)
-			} else if e, ok := r.(error); !ok {
-				panic(r)
-			} else {
-				err = e
-			}
-		}
-	}()
+	// normally; see recoverError.
+	defer cfg.recoverError(&err)
 
 	ret := lookupEncodeFn(srcv.Type(), cfg)(srcv, cfg)
 	return ret.(map[string]interface{}), nil
 }
 
-func (cfg *Config) marshalSlice(src interface{}) (m []map[string]interface{}, err error) {
+func (cfg *Config) marshalSlice(ctx context.Context, src interface{}) (m []map[string]interface{}, err error) {
 	srcv := reflect.ValueOf(src)
 	if srcv.Kind() == reflect.Ptr {
 		srcv = srcv.Elem()
@@ -86,40 +142,159 @@ func (cfg *Config) marshalSlice(src interface{}) (m []map[string]interface{}, er
 	if !(srcv.Kind() == reflect.Array || srcv.Kind() == reflect.Slice) {
 		return nil, errors.New("src must be a slice, array, or pointer to either")
 	}
+	et := srcv.Type().Elem()
+	for et.Kind() == reflect.Ptr {
+		et = et.Elem()
+	}
+	if et.Kind() != reflect.Struct && et.Kind() != reflect.Interface {
+		return nil, errors.New("src must be a slice or array of structs, or pointers-to-structs")
+	}
 
 	// Any panics after this point should be converted to errors, and returned
-	// normally. Unless it's a runtime error, it's a raw string, or it's not of
-	// type `error`. In which case, do panic.
-	defer func() {
-		if r := recover(); r != nil {
-			if _, ok := r.(runtime.Error); ok {
-				panic(r)
-			} else if s, ok := r.(string); ok {
-				panic(s)
-			} else if e, ok := r.(error); !ok {
-				panic(r)
-			} else {
-				err = e
-			}
-		}
-	}()
+	// normally; see recoverError.
+	defer cfg.recoverError(&err)
 
 	m = make([]map[string]interface{}, srcv.Len())
 	for i := 0; i < srcv.Len(); i++ {
-		elemv := srcv.Index(i)
-		if elemv.Kind() == reflect.Ptr || elemv.Kind() == reflect.Interface {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if m[i], err = cfg.encodeElem(srcv.Index(i)); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+func (cfg *Config) marshalMap(src interface{}) (m map[interface{}]interface{}, err error) {
+	srcv := reflect.ValueOf(src)
+	if srcv.Kind() == reflect.Ptr {
+		srcv = srcv.Elem()
+	}
+	if srcv.Kind() != reflect.Map {
+		return nil, errors.New("src must be a map, or pointer-to-map")
+	}
+	et := srcv.Type().Elem()
+	for et.Kind() == reflect.Ptr {
+		et = et.Elem()
+	}
+	if et.Kind() != reflect.Struct && et.Kind() != reflect.Interface {
+		return nil, errors.New("src must be a map of structs, or pointers-to-structs")
+	}
+
+	// Any panics after this point should be converted to errors, and returned
+	// normally; see recoverError.
+	defer cfg.recoverError(&err)
+
+	m = make(map[interface{}]interface{}, srcv.Len())
+	iter := srcv.MapRange()
+	for iter.Next() {
+		elemv := iter.Value()
+		for elemv.Kind() == reflect.Ptr || elemv.Kind() == reflect.Interface {
+			if elemv.IsNil() {
+				break
+			}
 			elemv = elemv.Elem()
 		}
-		m[i] = (lookupEncodeFn(elemv.Type(), cfg)(elemv, cfg)).(map[string]interface{})
+		if elemv.Kind() == reflect.Ptr || elemv.Kind() == reflect.Interface {
+			// A nil element has no fields to encode.
+			m[iter.Key().Interface()] = nil
+			continue
+		}
+		if elemv.Kind() != reflect.Struct {
+			return nil, fmt.Errorf("src must be a map of structs; found a %s", elemv.Type())
+		}
+		m[iter.Key().Interface()] = lookupEncodeFn(elemv.Type(), cfg)(elemv, cfg)
 	}
 	return m, nil
 }
 
+// encodeElem encodes elemv, an element of the slice or array passed to
+// MarshalSlice or EncodeEach, into a map. Nil pointer and interface elements
+// are encoded as nil maps. Elements that are not encoded as maps -- eg. those
+// of an []interface{} holding ints -- result in an error.
+func (cfg *Config) encodeElem(elemv reflect.Value) (map[string]interface{}, error) {
+	for elemv.Kind() == reflect.Ptr || elemv.Kind() == reflect.Interface {
+		if elemv.IsNil() {
+			return nil, nil
+		}
+		elemv = elemv.Elem()
+	}
+	if elemv.Kind() == reflect.Struct {
+		if m, ok := lookupEncodeFn(elemv.Type(), cfg)(elemv, cfg).(map[string]interface{}); ok {
+			return m, nil
+		}
+	}
+	return nil, fmt.Errorf("src must be a slice or array of structs; found a %s", elemv.Type())
+}
+
+// Encoder encodes the elements of slices and arrays into maps one at a time,
+// as MarshalSlice does, without buffering the results. Encoders share the
+// cached encode functions of their Config, and are safe for concurrent use.
+type Encoder struct {
+	cfg *Config
+}
+
+// NewEncoder returns a new Encoder that will encode with cfg. If cfg is nil,
+// the default Config used by Marshal will be used.
+func NewEncoder(cfg *Config) *Encoder {
+	if cfg == nil {
+		cfg = defaultConfig
+	}
+	return &Encoder{cfg}
+}
+
+// EncodeEach encodes each element of src, in order, and passes the resulting
+// map to fn. src must be a slice or array of structs, or pointers-to-structs, or
+// a pointer to either. Nil elements are passed to fn as nil maps. The map is not
+// retained by enc, and may be modified by fn. If fn returns an error, encoding
+// stops and that error is returned.
+func (enc *Encoder) EncodeEach(src interface{}, fn func(map[string]interface{}) error) (err error) {
+	cfg := enc.cfg
+	srcv := reflect.ValueOf(src)
+	if srcv.Kind() == reflect.Ptr {
+		srcv = srcv.Elem()
+	}
+	if !(srcv.Kind() == reflect.Array || srcv.Kind() == reflect.Slice) {
+		return errors.New("src must be a slice, array, or pointer to either")
+	}
+	et := srcv.Type().Elem()
+	for et.Kind() == reflect.Ptr {
+		et = et.Elem()
+	}
+	if et.Kind() != reflect.Struct && et.Kind() != reflect.Interface {
+		return errors.New("src must be a slice or array of structs, or pointers-to-structs")
+	}
+
+	// Any panics after this point should be converted to errors, and returned
+	// normally; see recoverError.
+	defer cfg.recoverError(&err)
+
+	for i := 0; i < srcv.Len(); i++ {
+		m, err := cfg.encodeElem(srcv.Index(i))
+		if err != nil {
+			return err
+		}
+		if err := fn(m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 type encodeFn func(src reflect.Value, cfg *Config) interface{}
 
+// encoderFnCacheKey identifies a cached encodeFn by its type and the options
+// of the Config it was built with. A Config holding a NameFunc is not
+// comparable, so only the scalar options the construction of an encodeFn
+// depends on are used. Functions cannot be compared either, so encodeFns built
+// with a NameFunc are keyed by the Config that holds it; every other option is
+// read from the Config passed at encode time.
 type encoderFnCacheKey struct {
-	t reflect.Type
-	c Config
+	t       reflect.Type
+	tagName string
+	keyCase KeyCase
+	nameCfg *Config // cfg, if cfg.NameFunc is non-nil; nil otherwise
 }
 
 // `encodeFnCache` is based on encode/json's encoderCache. It stores the given
@@ -128,7 +303,10 @@ type encoderFnCacheKey struct {
 var encodeFnCache sync.Map // map[encoderFnCacheKey]encodeFn
 
 func lookupEncodeFn(t reflect.Type, cfg *Config) encodeFn {
-	key := encoderFnCacheKey{t, *cfg}
+	key := encoderFnCacheKey{t, cfg.TagName, cfg.KeyCase, nil}
+	if cfg.NameFunc != nil {
+		key.nameCfg = cfg
+	}
 	// Early-out on quick cache-hits.
 	if fn, ok := encodeFnCache.Load(key); ok {
 		return fn.(encodeFn)
@@ -178,9 +356,30 @@ func newEncodeValueFn(t reflect.Type, cfg *Config, firstPass bool) encodeFn {
 			newEncodeValueFn(t, cfg, false),
 		)
 	}
+	if isSQLNullType(t) {
+		return encodeSQLNull
+	}
+	// time.Time has no exported fields, but is meaningful as a value; store it
+	// as one, rather than as an empty map.
+	if t == timeType {
+		return encodeInterface
+	}
 	switch t.Kind() {
 	case reflect.Struct:
 		return newStructEncoder(t, cfg)
+	case reflect.Ptr:
+		// Only pointers to structs that would themselves be encoded as maps, or
+		// to database/sql Null* values, are followed. Others -- eg. *time.Time
+		// -- are stored as the pointer.
+		if encodesAsMap(t.Elem(), cfg) || isSQLNullType(t.Elem()) {
+			return newPtrEncoder(t, cfg)
+		}
+		return encodeInterface
+	case reflect.Slice, reflect.Array:
+		if encodesAsMap(t.Elem(), cfg) {
+			return newStructSliceEncoder(t, cfg)
+		}
+		return encodeInterface
 	default:
 		// We assume that if the type is non-nilable, and not a struct, we can
 		// just return an enclosing interface{}, and call it good.
@@ -188,6 +387,61 @@ func newEncodeValueFn(t reflect.Type, cfg *Config, firstPass bool) encodeFn {
 	}
 }
 
+// isSQLNullType returns true if t is one of the database/sql Null* types --
+// sql.NullString, sql.NullInt64, sql.Null[T], etc. -- each of which is a struct
+// of a value followed by a Valid bool.
+func isSQLNullType(t reflect.Type) bool {
+	if t.PkgPath() != "database/sql" || !strings.HasPrefix(t.Name(), "Null") {
+		return false
+	}
+	if t.Kind() != reflect.Struct || t.NumField() != 2 {
+		return false
+	}
+	valid := t.Field(1)
+	return valid.Name == "Valid" && valid.Type.Kind() == reflect.Bool
+}
+
+// encodeSQLNull encodes a database/sql Null* value as its bare value, or nil if
+// it is not valid, matching the behavior of the pyrrho/encoding/types/null
+// wrappers.
+func encodeSQLNull(src reflect.Value, cfg *Config) interface{} {
+	if !src.Field(1).Bool() {
+		return nil
+	}
+	return encodeInterface(src.Field(0), cfg)
+}
+
+// isNullableType returns true if t is one of the database/sql Null* types, or
+// a type -- like those in pyrrho/encoding/types/null -- that reports its own
+// validity through encoding.IsNiler and exposes its underlying value through a
+// ValueOrZero method. Pointers are not nullable in this sense; they are nil.
+func isNullableType(t reflect.Type) bool {
+	if isSQLNullType(t) {
+		return true
+	}
+	if t.Kind() == reflect.Ptr || t.Kind() == reflect.Interface || !t.Implements(isNilerType) {
+		return false
+	}
+	m, ok := t.MethodByName("ValueOrZero")
+	return ok && m.Type.NumIn() == 1 && m.Type.NumOut() == 1
+}
+
+// encodeNullFlags encodes src, a value of a nullable type (see
+// isNullableType), as a map of its value and its validity; see
+// Config.ExplicitNullFlags.
+func encodeNullFlags(src reflect.Value) interface{} {
+	if isSQLNullType(src.Type()) {
+		return map[string]interface{}{
+			"value": src.Field(0).Interface(),
+			"valid": src.Field(1).Bool(),
+		}
+	}
+	return map[string]interface{}{
+		"value": src.MethodByName("ValueOrZero").Call(nil)[0].Interface(),
+		"valid": !src.Interface().(encoding.IsNiler).IsNil(),
+	}
+}
+
 func encodeInterface(src reflect.Value, cfg *Config) interface{} {
 	if !src.CanInterface() {
 		panic(errors.New("How did you get here with a non-interfaceable value?"))
@@ -244,6 +498,69 @@ func newConditionalEncoder(cond func(src reflect.Value) bool, tru encodeFn, fls
 	return cm.marshalValue
 }
 
+type ptrEncoder struct {
+	elemEnc encodeFn
+}
+
+func (pe *ptrEncoder) encode(src reflect.Value, cfg *Config) interface{} {
+	if src.IsNil() {
+		if !cfg.ExpandNilStructPointers {
+			return nil
+		}
+		// Don't expand nil pointers found within the expanded zero value;
+		// recursive types would never terminate.
+		inner := cfg.Clone()
+		inner.ExpandNilStructPointers = false
+		return pe.elemEnc(reflect.Zero(src.Type().Elem()), inner)
+	}
+	return pe.elemEnc(src.Elem(), cfg)
+}
+
+func newPtrEncoder(t reflect.Type, cfg *Config) encodeFn {
+	pe := &ptrEncoder{lookupEncodeFn(t.Elem(), cfg)}
+	return pe.encode
+}
+
+// encodesAsMap returns true if values of type t -- a struct, or a pointer to
+// one -- will be encoded as maps by a structEncoder. Types that implement
+// Marshaler, the database/sql Null* types, and structs with no encodable fields
+// (eg. time.Time) are not.
+func encodesAsMap(t reflect.Type, cfg *Config) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || isSQLNullType(t) {
+		return false
+	}
+	if t.Implements(marshalerType) || reflect.PtrTo(t).Implements(marshalerType) {
+		return false
+	}
+	return len(cachedTypeFields(t, cfg)) > 0
+}
+
+// structSliceEncoder encodes slices and arrays of structs, or of pointers to
+// structs, as a []map[string]interface{}. Nil pointer elements are encoded as
+// nil maps, unless expanded by ExpandNilStructPointers.
+type structSliceEncoder struct {
+	elemEnc encodeFn
+}
+
+func (sse *structSliceEncoder) encode(src reflect.Value, cfg *Config) interface{} {
+	if src.Kind() == reflect.Slice && src.IsNil() {
+		return []map[string]interface{}(nil)
+	}
+	ret := make([]map[string]interface{}, src.Len())
+	for i := range ret {
+		ret[i], _ = sse.elemEnc(src.Index(i), cfg).(map[string]interface{})
+	}
+	return ret
+}
+
+func newStructSliceEncoder(t reflect.Type, cfg *Config) encodeFn {
+	sse := &structSliceEncoder{lookupEncodeFn(t.Elem(), cfg)}
+	return sse.encode
+}
+
 func fieldByIndex(v reflect.Value, index []int) reflect.Value {
 	for _, i := range index {
 		if v.Kind() == reflect.Ptr {
@@ -268,38 +585,223 @@ func typeByIndex(t reflect.Type, index []int) reflect.Type {
 }
 
 type structEncoder struct {
-	fields    []field
-	fieldEncs []encodeFn
+	fields     []field
+	fieldEncs  []encodeFn
+	marshalers []bool // fields encoded by MarshalMapValue
+	nullables  []bool // see isNullableType
 }
 
 func (se *structEncoder) encode(src reflect.Value, cfg *Config) interface{} {
-	ret := make(map[string]interface{}, len(se.fields))
+	ret := make(map[string]interface{}, len(se.fields)+1)
+	if cfg.TypeField != "" {
+		for _, f := range se.fields {
+			if f.name == cfg.TypeField {
+				panic(fmt.Errorf("encoding/maps: type field '%s' collides with a field of %s", cfg.TypeField, src.Type()))
+			}
+		}
+		ret[cfg.TypeField] = src.Type().Name()
+	}
 	for i, f := range se.fields {
 		fv := fieldByIndex(src, f.index)
 		if !fv.IsValid() ||
-			(f.options.Contains("omitZero") && encoding.IsValueZero(fv)) ||
-			(f.options.Contains("omitNil") && encoding.IsValueNil(fv)) {
+			((cfg.OmitZero || f.options.Contains("omitZero")) && encoding.IsValueZero(fv)) ||
+			((cfg.OmitNil || f.options.Contains("omitNil")) && encoding.IsValueNil(fv)) ||
+			(omitEmpty(f, cfg) && isEmptyValue(fv)) {
 			continue
 		}
 		if !src.CanInterface() {
 			panic(fmt.Errorf("How did you get here with a non-interfaceable value?"))
 		}
-		ret[f.name] = se.fieldEncs[i](fv, cfg)
+		var v interface{}
+		if cfg.ExplicitNullFlags && se.nullables[i] {
+			v = encodeNullFlags(fv)
+		} else {
+			v = se.fieldEncs[i](fv, cfg)
+		}
+		if se.marshalers[i] && cfg.NullMapValue != NullMapValueAsIs && isNullField(fv) {
+			if cfg.NullMapValue == NullMapValueOmit {
+				continue
+			}
+			v = cfg.nullMapValue()
+		}
+		if cfg.RoundFloats && cfg.FloatPrecision >= 0 {
+			v = roundFloat(v, cfg.FloatPrecision)
+		}
+		if cfg.TimeFormat != "" {
+			v = formatTime(v, cfg.TimeFormat)
+		}
+		if cfg.DurationsAsStrings {
+			if d, ok := v.(time.Duration); ok {
+				v = d.String()
+			}
+		}
+		if cfg.ComplexAsObject {
+			v = complexObject(v)
+		}
+		if cfg.NilCollectionsAsEmpty {
+			v = emptyNilCollection(v)
+		}
+		ret[f.name] = v
+		if cfg.MaxFields > 0 && len(ret) > cfg.MaxFields {
+			panic(fmt.Errorf("encoding/maps: encoding %s would produce more than the maximum of %d fields", src.Type(), cfg.MaxFields))
+		}
 	}
 	return ret
 }
 
+// NullMapValue identifies how the null values returned by MarshalMapValue are
+// stored in the maps produced by Marshal. Types differ in how they represent
+// null; the pyrrho/encoding/types/null scalars and null.RawJSON return nil,
+// while the null SF types return the JSON 'null' keyword as a []byte. A value
+// is null if the field it was returned for is a nil pointer, or reports itself
+// as nil through an IsNil method; see encoding.IsNiler.
+type NullMapValue int
+
+const (
+	// NullMapValueAsIs stores null values as they were returned by
+	// MarshalMapValue.
+	NullMapValueAsIs NullMapValue = iota
+	// NullMapValueNil stores every null value as nil.
+	NullMapValueNil
+	// NullMapValueJSON stores every null value as the JSON 'null' keyword,
+	// []byte("null").
+	NullMapValueJSON
+	// NullMapValueOmit omits fields holding null values.
+	NullMapValueOmit
+	// NullMapValueSentinel stores every null value as the Config's
+	// NullSentinel.
+	NullMapValueSentinel
+)
+
+// nullMapValue returns the value cfg stores in place of null values.
+func (cfg *Config) nullMapValue() interface{} {
+	switch cfg.NullMapValue {
+	case NullMapValueJSON:
+		return []byte("null")
+	case NullMapValueSentinel:
+		return cfg.NullSentinel
+	}
+	return nil
+}
+
+// isNullField returns true if fv, a field encoded by MarshalMapValue, is null;
+// either a nil pointer or interface, or a value whose IsNil method -- see
+// encoding.IsNiler -- returns true. The value MarshalMapValue returned is not
+// consulted, as valid data may be indistinguishable from null once encoded;
+// eg. a null.ByteSlice whose base64 encoding is "null".
+func isNullField(fv reflect.Value) bool {
+	if (fv.Kind() == reflect.Ptr || fv.Kind() == reflect.Interface) && fv.IsNil() {
+		return true
+	}
+	return encoding.IsValueNil(fv)
+}
+
+// omitEmpty returns true if f should be omitted when empty, either because it
+// has been tagged with the omitEmpty option, or because cfg.OmitEmpty is set
+// and f has not been tagged with the noOmitEmpty option. noOmitEmpty only opts
+// out of the omission of empty values; an empty field may still be omitted by
+// omitZero.
+func omitEmpty(f field, cfg *Config) bool {
+	if f.options.Contains("omitEmpty") {
+		return true
+	}
+	return cfg.OmitEmpty && !f.options.Contains("noOmitEmpty")
+}
+
+// isEmptyValue returns true if v is an array, slice, map, or string of length
+// zero.
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	}
+	return false
+}
+
+// roundFloat rounds v to prec decimal places if it is a float32 or float64 (or
+// a type derived from either), and returns it unmodified otherwise. Rounding is
+// performed through strconv's decimal formatting, rather than by scaling, to
+// avoid overflow at large precisions.
+func roundFloat(v interface{}, prec int) interface{} {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Float32, reflect.Float64:
+		bits := rv.Type().Bits()
+		f, err := strconv.ParseFloat(strconv.FormatFloat(rv.Float(), 'f', prec, bits), bits)
+		if err != nil {
+			return v
+		}
+		ret := reflect.New(rv.Type()).Elem()
+		ret.SetFloat(f)
+		return ret.Interface()
+	}
+	return v
+}
+
+// formatTime formats v with layout if it is a time.Time or a non-nil
+// *time.Time, and returns it unmodified otherwise.
+func formatTime(v interface{}, layout string) interface{} {
+	switch t := v.(type) {
+	case time.Time:
+		return t.Format(layout)
+	case *time.Time:
+		if t != nil {
+			return t.Format(layout)
+		}
+	}
+	return v
+}
+
+// complexObject returns v as a map of its real and imaginary parts if it is a
+// complex number, and returns v unmodified otherwise.
+func complexObject(v interface{}) interface{} {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Complex64, reflect.Complex128:
+		c := rv.Complex()
+		return map[string]interface{}{
+			"real": real(c),
+			"imag": imag(c),
+		}
+	}
+	return v
+}
+
+// emptyNilCollection returns an empty, non-nil slice or map of the same type as
+// v if v is a nil slice or map, and returns v unmodified otherwise.
+func emptyNilCollection(v interface{}) interface{} {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Slice:
+		if rv.IsNil() {
+			return reflect.MakeSlice(rv.Type(), 0, 0).Interface()
+		}
+	case reflect.Map:
+		if rv.IsNil() {
+			return reflect.MakeMap(rv.Type()).Interface()
+		}
+	}
+	return v
+}
+
 func newStructEncoder(t reflect.Type, cfg *Config) encodeFn {
 	fields := cachedTypeFields(t, cfg)
 	se := structEncoder{
-		fields:    fields,
-		fieldEncs: make([]encodeFn, len(fields)),
+		fields:     fields,
+		fieldEncs:  make([]encodeFn, len(fields)),
+		marshalers: make([]bool, len(fields)),
+		nullables:  make([]bool, len(fields)),
 	}
 	for i, f := range fields {
+		// Fields tagged with the value option are stored as their raw Go
+		// values, bypassing both MarshalMapValue and struct encoding.
 		if f.options.Contains("value") {
 			se.fieldEncs[i] = encodeInterface
 		} else {
-			se.fieldEncs[i] = lookupEncodeFn(typeByIndex(t, f.index), cfg)
+			ft := typeByIndex(t, f.index)
+			se.fieldEncs[i] = lookupEncodeFn(ft, cfg)
+			se.marshalers[i] = ft.Implements(marshalerType) || reflect.PtrTo(ft).Implements(marshalerType)
+			se.nullables[i] = isNullableType(ft)
 		}
 	}
 	return se.encode
@@ -1,9 +1,16 @@
 package maps_test
 
 import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/pyrrho/encoding/maps"
+	"github.com/pyrrho/encoding/types/null"
 	"github.com/stretchr/testify/require"
 )
 
@@ -105,6 +112,196 @@ func TestSimpleUntaggedStructSlice(t *testing.T) {
 	require.Equal(expected, actual)
 }
 
+// CancelingElement cancels its context when it is encoded.
+type CancelingElement struct {
+	AnInt  int
+	cancel context.CancelFunc
+}
+
+func (ce CancelingElement) MarshalMapValue() (interface{}, error) {
+	ce.cancel()
+	return map[string]interface{}{"AnInt": ce.AnInt}, nil
+}
+
+func TestMarshalSliceContext(t *testing.T) {
+	require := require.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	encoded := 0
+	s := []CancelingElement{
+		{1, func() { encoded++; cancel() }},
+		{2, func() { encoded++ }},
+		{3, func() { encoded++ }},
+	}
+
+	actual, err := maps.MarshalSliceContext(ctx, s)
+	require.Equal(context.Canceled, err)
+	require.Nil(actual)
+	require.Equal(1, encoded)
+
+	// A live context encodes every element.
+	encoded = 0
+	s[0].cancel = func() { encoded++ }
+	slice, err := maps.MarshalSliceContext(context.Background(), s)
+	require.NoError(err)
+	require.Len(slice, 3)
+	require.Equal(3, encoded)
+}
+
+func TestEncoderEncodeEach(t *testing.T) {
+	require := require.New(t)
+
+	s := []SimpleStruct{
+		{42, 3.14, "Hello World", complex(1, 2)},
+		{2, 6.28, "Goodby World", complex(2, 1)},
+		{7, 1.41, "Hello Again", complex(3, 3)},
+	}
+	expected, err := maps.MarshalSlice(s)
+	require.NoError(err)
+
+	enc := maps.NewEncoder(nil)
+	var actual []map[string]interface{}
+	err = enc.EncodeEach(&s, func(m map[string]interface{}) error {
+		actual = append(actual, m)
+		return nil
+	})
+	require.NoError(err)
+	require.Len(actual, 3)
+	require.Equal(expected, actual)
+
+	// The Encoder's Config is used for every element.
+	enc = maps.NewEncoder((&maps.Config{TagName: "map"}).With(maps.WithTypeField("__type")))
+	calls := 0
+	err = enc.EncodeEach(s, func(m map[string]interface{}) error {
+		require.Equal("SimpleStruct", m["__type"])
+		require.Equal(s[calls].FieldThree, m["FieldThree"])
+		calls++
+		return nil
+	})
+	require.NoError(err)
+	require.Equal(3, calls)
+
+	// Errors returned by the callback stop encoding.
+	stop := errors.New("stop")
+	calls = 0
+	err = enc.EncodeEach(s, func(m map[string]interface{}) error {
+		calls++
+		return stop
+	})
+	require.Equal(stop, err)
+	require.Equal(1, calls)
+
+	err = enc.EncodeEach(s[0], func(m map[string]interface{}) error { return nil })
+	require.Error(err)
+
+	// Elements that are not structs are rejected, rather than panicking.
+	noop := func(m map[string]interface{}) error { return nil }
+	require.Error(enc.EncodeEach([]int{1}, noop))
+	require.Error(enc.EncodeEach([]interface{}{1}, noop))
+	_, err = maps.MarshalSlice([]int{1})
+	require.Error(err)
+
+	// Nil elements are passed as nil maps.
+	var got []map[string]interface{}
+	err = enc.EncodeEach([]*SimpleStruct{nil, &s[0]}, func(m map[string]interface{}) error {
+		got = append(got, m)
+		return nil
+	})
+	require.NoError(err)
+	require.Len(got, 2)
+	require.Nil(got[0])
+	require.Equal(42, got[1]["FieldOne"])
+}
+
+func TestSimpleUntaggedStructMap(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		err              error
+		actual, expected map[interface{}]interface{}
+	)
+
+	s := map[string]SimpleStruct{
+		"first": {
+			42,
+			3.14,
+			"Hello World",
+			complex(1, 2),
+		},
+		"second": {
+			2,
+			6.28,
+			"Goodby World",
+			complex(2, 1),
+		},
+	}
+	sp := map[string]*SimpleStruct{
+		"first":  &SimpleStruct{42, 3.14, "Hello World", complex(1, 2)},
+		"second": &SimpleStruct{2, 6.28, "Goodby World", complex(2, 1)},
+	}
+	expected = map[interface{}]interface{}{
+		"first": map[string]interface{}{
+			"FieldOne":   42,
+			"FieldTwo":   float64(3.14),
+			"FieldThree": "Hello World",
+			"FieldFour":  complex(1, 2),
+		},
+		"second": map[string]interface{}{
+			"FieldOne":   2,
+			"FieldTwo":   6.28,
+			"FieldThree": "Goodby World",
+			"FieldFour":  complex(2, 1),
+		},
+	}
+
+	actual, err = maps.MarshalMap(s)
+	require.NoError(err)
+	require.Equal(expected, actual)
+
+	actual, err = maps.MarshalMap(&s)
+	require.NoError(err)
+	require.Equal(expected, actual)
+
+	actual, err = maps.MarshalMap(sp)
+	require.NoError(err)
+	require.Equal(expected, actual)
+
+	// Nil elements are preserved as nil.
+	sp["third"] = nil
+	actual, err = maps.MarshalMap(sp)
+	require.NoError(err)
+	require.Contains(actual, "third")
+	require.Nil(actual["third"])
+
+	// Maps of non-structs are rejected.
+	_, err = maps.MarshalMap(map[string]int{"one": 1})
+	require.Error(err)
+	_, err = maps.MarshalMap(s["first"])
+	require.Error(err)
+}
+
+func TestNestedStructMap(t *testing.T) {
+	require := require.New(t)
+
+	s := map[int]NestedStruct{
+		1: {1, 1.5},
+		2: {2, 2.5},
+	}
+	actual, err := maps.MarshalMap(s)
+	require.NoError(err)
+	require.Equal(map[interface{}]interface{}{
+		1: map[string]interface{}{"AnInt": 1, "AFloat": 1.5},
+		2: map[string]interface{}{"AnInt": 2, "AFloat": 2.5},
+	}, actual)
+
+	// Configs are honored.
+	cfg := (&maps.Config{TagName: "map"}).With(maps.WithKeyCase(maps.KeyCaseSnake))
+	actual, err = cfg.MarshalMap(s)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{"an_int": 1, "a_float": 1.5}, actual[1])
+}
+
 type SimpleStructWithInterface struct {
 	FieldOne int
 	FieldTwo interface{}
@@ -190,6 +387,56 @@ type NestedStruct struct {
 	AFloat float64
 }
 
+type StructSlices struct {
+	Slice []NestedStruct
+	Array [2]NestedStruct
+	Ptrs  []*NestedStruct
+	Nil   []NestedStruct
+	// Neither Marshalers nor structs without fields are converted.
+	Nulls []null.Int64
+	Times []time.Time
+	Ints  []int
+}
+
+func TestStructSlices(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Now()
+	s := StructSlices{
+		Slice: []NestedStruct{{1, 1.5}, {2, 2.5}},
+		Array: [2]NestedStruct{{3, 3.5}},
+		Ptrs:  []*NestedStruct{{4, 4.5}, nil},
+		Nulls: []null.Int64{null.NewInt64(5)},
+		Times: []time.Time{now},
+		Ints:  []int{6},
+	}
+	actual, err := maps.Marshal(s)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{
+		"Slice": []map[string]interface{}{
+			{"AnInt": 1, "AFloat": 1.5},
+			{"AnInt": 2, "AFloat": 2.5},
+		},
+		"Array": []map[string]interface{}{
+			{"AnInt": 3, "AFloat": 3.5},
+			{"AnInt": 0, "AFloat": 0.0},
+		},
+		"Ptrs": []map[string]interface{}{
+			{"AnInt": 4, "AFloat": 4.5},
+			nil,
+		},
+		"Nil":   []map[string]interface{}(nil),
+		"Nulls": []null.Int64{null.NewInt64(5)},
+		"Times": []time.Time{now},
+		"Ints":  []int{6},
+	}, actual)
+
+	// The encoded slices decode back into their structs.
+	var decoded StructSlices
+	require.NoError(maps.Unmarshal(actual, &decoded))
+	require.Equal(s, decoded)
+}
+
 func TestNestedStructsAndMaps(t *testing.T) {
 	require := require.New(t)
 
@@ -310,6 +557,54 @@ func TestContendingEmbeddedStructs(t *testing.T) {
 	require.Equal(expected, actual)
 }
 
+type DeepLevelOne struct {
+	AnInt        int
+	DeepLevelTwo // embedded
+}
+
+type DeepLevelTwo struct {
+	DeepLevelThree // embedded, without a tag
+}
+
+type DeepLevelThree struct {
+	// Renamed by its own tag, three structs deep; the name propagates up
+	// through the untagged embeddings.
+	AString string `map:"deep_string"`
+	AFloat  float64
+}
+
+type ShadowedDeepLevelOne struct {
+	// Shadows `DeepLevelThree.AString`, despite its tag, by virtue of depth.
+	Shallow      string `map:"deep_string"`
+	DeepLevelTwo        // embedded
+}
+
+func TestDeeplyEmbeddedTaggedFields(t *testing.T) {
+	require := require.New(t)
+
+	s := DeepLevelOne{42, DeepLevelTwo{DeepLevelThree{"deep", 6.28}}}
+	actual, err := maps.Marshal(s)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{
+		"AnInt":       42,
+		"deep_string": "deep",
+		"AFloat":      6.28,
+	}, actual)
+
+	var decoded DeepLevelOne
+	err = maps.Unmarshal(actual, &decoded)
+	require.NoError(err)
+	require.Equal(s, decoded)
+
+	shadowed := ShadowedDeepLevelOne{"shallow", DeepLevelTwo{DeepLevelThree{"deep", 6.28}}}
+	actual, err = maps.Marshal(shadowed)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{
+		"deep_string": "shallow",
+		"AFloat":      6.28,
+	}, actual)
+}
+
 type MarahalerParent struct {
 	AnInt            int
 	AnArrayIshStruct MarshalerImplementor
@@ -352,6 +647,38 @@ func TestMarshalerInterface(t *testing.T) {
 	require.Equal(expected, actual)
 }
 
+type SQLNullStruct struct {
+	Count    sql.NullInt64
+	Missing  sql.NullInt64
+	Name     sql.NullString
+	PtrCount *sql.NullInt64
+	Wrapped  null.Int64
+}
+
+func TestSQLNullTypes(t *testing.T) {
+	require := require.New(t)
+
+	s := SQLNullStruct{
+		Count:    sql.NullInt64{Int64: 42, Valid: true},
+		Missing:  sql.NullInt64{Int64: 7, Valid: false},
+		Name:     sql.NullString{String: "name", Valid: true},
+		PtrCount: &sql.NullInt64{Int64: 13, Valid: true},
+		Wrapped:  null.NewInt64(42),
+	}
+	actual, err := maps.Marshal(s)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{
+		"Count":    int64(42),
+		"Missing":  nil,
+		"Name":     "name",
+		"PtrCount": int64(13),
+		"Wrapped":  int64(42),
+	}, actual)
+
+	// Raw sql.Null* fields are encoded as the null wrappers are.
+	require.Equal(actual["Wrapped"], actual["Count"])
+}
+
 type DifferentTags struct {
 	FieldOne   int        `map_key:"field_one"`
 	FieldTwo   float64    `map_key:"field_two"`
@@ -385,6 +712,40 @@ func TestDifferentTags(t *testing.T) {
 	require.Equal(expected, actual)
 }
 
+type MultiplyTagged struct {
+	FieldOne int    `map:"map_one" json:"json_one"`
+	FieldTwo string `map:"map_two" json:"-"`
+}
+
+func TestTagNamesAreCachedSeparately(t *testing.T) {
+	require := require.New(t)
+
+	s := &MultiplyTagged{42, "Hello World"}
+
+	// Marshal with the default `map` tags first, so that the fields of
+	// MultiplyTagged are cached.
+	actual, err := maps.Marshal(s)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{
+		"map_one": 42,
+		"map_two": "Hello World",
+	}, actual)
+
+	actual, err = maps.MarshalWithConfig(s, &maps.Config{TagName: "json"})
+	require.NoError(err)
+	require.Equal(map[string]interface{}{
+		"json_one": 42,
+	}, actual)
+
+	var decoded MultiplyTagged
+	err = (&maps.Config{TagName: "json"}).Unmarshal(map[string]interface{}{
+		"json_one": 7,
+		"map_two":  "ignored",
+	}, &decoded)
+	require.NoError(err)
+	require.Equal(MultiplyTagged{FieldOne: 7}, decoded)
+}
+
 type PossiblyNotValues struct {
 	Int1  int  `map:",omitZero"`
 	Int2  int  `map:",omitZero"`
@@ -419,6 +780,102 @@ func TestOmitZeroNil(t *testing.T) {
 	require.Equal(expected, actual)
 }
 
+type OptionCases struct {
+	Lower  int `map:",omitzero"`
+	Pascal int `map:",OmitZero"`
+	Camel  int `map:",omitZero"`
+	Upper  int `map:",OMITZERO"`
+}
+
+func TestTagOptionsAreCaseInsensitive(t *testing.T) {
+	require := require.New(t)
+
+	// Every spelling of omitZero omits a zero value ...
+	actual, err := maps.Marshal(&OptionCases{})
+	require.NoError(err)
+	require.Equal(map[string]interface{}{}, actual)
+
+	// ... and keeps a non-zero value.
+	actual, err = maps.Marshal(&OptionCases{1, 2, 3, 4})
+	require.NoError(err)
+	require.Equal(map[string]interface{}{
+		"Lower":  1,
+		"Pascal": 2,
+		"Camel":  3,
+		"Upper":  4,
+	}, actual)
+}
+
+type PossiblyEmptyValues struct {
+	Str      string         `map:",omitEmpty"`
+	Slice    []int          `map:",omitEmpty"`
+	Map      map[string]int `map:",OMITEMPTY"`
+	Array    [0]int         `map:",omitEmpty"`
+	Kept     []int          `map:",noOmitEmpty"`
+	Untagged string
+	NilSlice []int `map:",omitEmpty"`
+	Full     []int `map:",omitEmpty"`
+}
+
+func TestOmitEmpty(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		err    error
+		actual map[string]interface{}
+	)
+
+	s := &PossiblyEmptyValues{
+		Str:      "",
+		Slice:    []int{},
+		Map:      map[string]int{},
+		Kept:     []int{},
+		Untagged: "",
+		Full:     []int{1},
+	}
+
+	// Fields tagged omitEmpty are omitted when empty -- whether nil or not --
+	// regardless of the Config.
+	actual, err = maps.Marshal(s)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{
+		"Kept":     []int{},
+		"Untagged": "",
+		"Full":     []int{1},
+	}, actual)
+
+	// Config.OmitEmpty applies to untagged fields as well, but not to those
+	// tagged noOmitEmpty.
+	cfg := (&maps.Config{TagName: "map"}).With(maps.WithOmitEmpty(true))
+	actual, err = cfg.Marshal(s)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{
+		"Kept": []int{},
+		"Full": []int{1},
+	}, actual)
+
+	// noOmitEmpty does not prevent omission by OmitZero or OmitNil; an empty
+	// slice is zero-valued.
+	actual, err = cfg.With(maps.WithOmitZero(true)).Marshal(s)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{
+		"Full": []int{1},
+	}, actual)
+
+	s.Kept = nil
+	actual, err = cfg.With(maps.WithOmitNil(true)).Marshal(s)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{
+		"Full": []int{1},
+	}, actual)
+	actual, err = cfg.Marshal(s)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{
+		"Kept": []int(nil),
+		"Full": []int{1},
+	}, actual)
+}
+
 type AsValueParent struct {
 	Tagged     TaggedAsValueChild `map:",value"`
 	Interfaced MarshalerAsValueChild
@@ -470,3 +927,911 @@ func TestStructsAsValue(t *testing.T) {
 	require.NoError(err)
 	require.Equal(expected, actual)
 }
+
+type MarshalersAsValue struct {
+	Raw     null.Int64 `map:",value"`
+	Null    null.Int64 `map:",value"`
+	Encoded null.Int64
+}
+
+func TestMarshalersAsValue(t *testing.T) {
+	require := require.New(t)
+
+	s := &MarshalersAsValue{
+		Raw:     null.NewInt64(42),
+		Null:    null.NullInt64(),
+		Encoded: null.NewInt64(42),
+	}
+
+	// Fields tagged with the value option bypass MarshalMapValue, and are
+	// stored as the null.Int64 structs they are.
+	actual, err := maps.Marshal(s)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{
+		"Raw":     null.NewInt64(42),
+		"Null":    null.NullInt64(),
+		"Encoded": int64(42),
+	}, actual)
+	require.IsType(null.Int64{}, actual["Raw"])
+	require.IsType(null.Int64{}, actual["Null"])
+
+	// Nor are they subject to NullMapValue, which only applies to the
+	// results of MarshalMapValue.
+	cfg := (&maps.Config{TagName: "map"}).With(maps.WithNullMapValue(maps.NullMapValueOmit))
+	actual, err = cfg.Marshal(s)
+	require.NoError(err)
+	require.Equal(null.NullInt64(), actual["Null"])
+}
+
+type AllOptions struct {
+	Zero    int                 `map:",omitZero"`
+	Nil     *int                `map:",omitNil"`
+	Value   TaggedAsValueChild  `map:",value"`
+	Combo   *TaggedAsValueChild `map:"combo,omitZero,omitNil,value"`
+	Default int
+}
+
+func TestAllTagOptions(t *testing.T) {
+	require := require.New(t)
+
+	child := TaggedAsValueChild{3.14, true}
+
+	// Zero and nil fields are omitted, value fields are stored as-is.
+	actual, err := maps.Marshal(&AllOptions{Value: child})
+	require.NoError(err)
+	require.Equal(map[string]interface{}{
+		"Value":   child,
+		"Default": 0,
+	}, actual)
+
+	i := 42
+	actual, err = maps.Marshal(&AllOptions{1, &i, child, &child, 2})
+	require.NoError(err)
+	require.Equal(map[string]interface{}{
+		"Zero":    1,
+		"Nil":     &i,
+		"Value":   child,
+		"combo":   &child,
+		"Default": 2,
+	}, actual)
+}
+
+type Metadata struct {
+	CreatedAt int
+	UpdatedAt int `map:"updated_at"`
+}
+
+type Audit struct {
+	Author string
+}
+
+type Document struct {
+	Title    string
+	Metadata Metadata `map:",inline"`
+	Audit    *Audit   `map:"audit,inline"`
+	Nested   Metadata
+}
+
+type CollidingDocument struct {
+	CreatedAt int
+	Metadata  Metadata `map:",inline"`
+}
+
+func TestInline(t *testing.T) {
+	require := require.New(t)
+
+	s := &Document{
+		Title:    "Hello World",
+		Metadata: Metadata{1, 2},
+		Nested:   Metadata{3, 4},
+	}
+	actual, err := maps.Marshal(s)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{
+		"Title":      "Hello World",
+		"CreatedAt":  1,
+		"updated_at": 2,
+		"Nested": map[string]interface{}{
+			"CreatedAt":  3,
+			"updated_at": 4,
+		},
+	}, actual)
+
+	var decoded Document
+	err = maps.Unmarshal(actual, &decoded)
+	require.NoError(err)
+	require.Equal(*s, decoded)
+
+	// Inlined pointers are followed when non-nil.
+	s.Audit = &Audit{"Tom"}
+	actual, err = maps.Marshal(s)
+	require.NoError(err)
+	require.Equal("Tom", actual["Author"])
+	require.NotContains(actual, "audit")
+
+	// Inlined fields collide with, rather than being shadowed by, the fields
+	// of their parent.
+	_, err = maps.Marshal(&CollidingDocument{1, Metadata{2, 3}})
+	require.Error(err)
+	require.Contains(err.Error(), "CreatedAt")
+}
+
+type TypedCat struct {
+	Name  string
+	Lives int
+}
+
+type TypedDog struct {
+	Name   string
+	IsGood bool
+}
+
+func TestTypeField(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		err              error
+		actual, expected []map[string]interface{}
+	)
+
+	s := []interface{}{
+		TypedCat{"Tom", 9},
+		&TypedDog{"Rex", true},
+	}
+	expected = []map[string]interface{}{
+		{
+			"__type": "TypedCat",
+			"Name":   "Tom",
+			"Lives":  9,
+		},
+		{
+			"__type": "TypedDog",
+			"Name":   "Rex",
+			"IsGood": true,
+		},
+	}
+
+	cfg := &maps.Config{TagName: "map", TypeField: "__type"}
+	actual, err = cfg.MarshalSlice(s)
+	require.NoError(err)
+	require.Equal(expected, actual)
+
+	// The default config does not emit a type field.
+	actual, err = maps.MarshalSlice(s)
+	require.NoError(err)
+	require.NotContains(actual[0], "__type")
+	require.NotContains(actual[1], "__type")
+	// Anonymous struct types have no name.
+	actual, err = cfg.MarshalSlice([]interface{}{struct{ Name string }{"Anon"}})
+	require.NoError(err)
+	require.Equal(map[string]interface{}{"__type": "", "Name": "Anon"}, actual[0])
+
+	// The type field may not collide with the key of a field.
+	_, err = cfg.With(maps.WithTypeField("Name")).MarshalSlice(s)
+	require.Error(err)
+	require.Contains(err.Error(), "Name")
+}
+
+type PointerParent struct {
+	AnInt   int
+	AStruct *NestedStruct
+}
+
+type RecursiveStruct struct {
+	AnInt int
+	Next  *RecursiveStruct
+}
+
+func TestExpandNilStructPointers(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		err              error
+		actual, expected map[string]interface{}
+	)
+
+	cfg := maps.Config{TagName: "map"}
+	expanding := cfg.With(maps.WithExpandNilStructPointers(true))
+
+	// Non-nil pointers-to-structs are encoded as their pointed-to structs.
+	s := &PointerParent{42, &NestedStruct{5, 6.7}}
+	expected = map[string]interface{}{
+		"AnInt": 42,
+		"AStruct": map[string]interface{}{
+			"AnInt":  5,
+			"AFloat": 6.7,
+		},
+	}
+	actual, err = cfg.Marshal(s)
+	require.NoError(err)
+	require.Equal(expected, actual)
+	actual, err = expanding.Marshal(s)
+	require.NoError(err)
+	require.Equal(expected, actual)
+
+	// Nil pointers-to-structs are nil, unless expanded.
+	n := &PointerParent{42, nil}
+	actual, err = cfg.Marshal(n)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{
+		"AnInt":   42,
+		"AStruct": nil,
+	}, actual)
+	actual, err = expanding.Marshal(n)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{
+		"AnInt": 42,
+		"AStruct": map[string]interface{}{
+			"AnInt":  0,
+			"AFloat": 0.0,
+		},
+	}, actual)
+
+	// Recursive types are only expanded one level deep.
+	r := &RecursiveStruct{1, nil}
+	actual, err = expanding.Marshal(r)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{
+		"AnInt": 1,
+		"Next": map[string]interface{}{
+			"AnInt": 0,
+			"Next":  nil,
+		},
+	}, actual)
+}
+
+type TimePointerStruct struct {
+	TP *time.Time
+}
+
+func TestTimePointerField(t *testing.T) {
+	require := require.New(t)
+
+	// Pointers to structs that are not encoded as maps, such as time.Time, are
+	// stored as the pointer, with or without ExpandNilStructPointers.
+	now := time.Now()
+	s := TimePointerStruct{&now}
+	for _, cfg := range []*maps.Config{
+		{TagName: "map"},
+		{TagName: "map", ExpandNilStructPointers: true},
+	} {
+		actual, err := cfg.Marshal(s)
+		require.NoError(err)
+		require.Equal(map[string]interface{}{"TP": &now}, actual)
+
+		var decoded TimePointerStruct
+		require.NoError(cfg.Unmarshal(actual, &decoded))
+		require.True(now.Equal(*decoded.TP))
+
+		actual, err = cfg.Marshal(TimePointerStruct{})
+		require.NoError(err)
+		require.Equal(map[string]interface{}{"TP": (*time.Time)(nil)}, actual)
+	}
+}
+
+type FloatyStruct struct {
+	Pi       float64
+	SmallPi  float32
+	NullPi   null.Float64
+	NoPi     null.Float64
+	NotFloat int
+}
+
+func TestRoundFloats(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		err    error
+		actual map[string]interface{}
+	)
+
+	s := &FloatyStruct{
+		Pi:       3.14159,
+		SmallPi:  3.14159,
+		NullPi:   null.NewFloat64(3.14159),
+		NoPi:     null.NullFloat64(),
+		NotFloat: 42,
+	}
+
+	cfg := &maps.Config{TagName: "map"}
+	actual, err = cfg.With(maps.WithRoundFloats(2)).Marshal(s)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{
+		"Pi":       3.14,
+		"SmallPi":  float32(3.14),
+		"NullPi":   3.14,
+		"NoPi":     nil,
+		"NotFloat": 42,
+	}, actual)
+
+	actual, err = cfg.With(maps.WithRoundFloats(0)).Marshal(s)
+	require.NoError(err)
+	require.Equal(3.0, actual["Pi"])
+
+	// Rounding is off by default, and when the precision is negative.
+	actual, err = cfg.Marshal(s)
+	require.NoError(err)
+	require.Equal(3.14159, actual["Pi"])
+	actual, err = cfg.With(maps.WithRoundFloats(-1)).Marshal(s)
+	require.NoError(err)
+	require.Equal(3.14159, actual["Pi"])
+}
+
+type TimeyStruct struct {
+	Timestamp null.Time
+	NoTime    null.Time
+	NotTime   string
+}
+
+type PlainTimeyStruct struct {
+	T    time.Time
+	TP   *time.Time
+	NoTP *time.Time
+}
+
+func TestTimeFormat(t *testing.T) {
+	require := require.New(t)
+
+	ts := time.Date(2019, time.March, 14, 15, 9, 26, 0, time.UTC)
+	s := &TimeyStruct{
+		Timestamp: null.NewTime(ts),
+		NoTime:    null.NullTime(),
+		NotTime:   "2019-03-14",
+	}
+
+	cfg := &maps.Config{TagName: "map"}
+	actual, err := cfg.With(maps.WithTimeFormat(time.RFC3339)).Marshal(s)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{
+		"Timestamp": "2019-03-14T15:09:26Z",
+		"NoTime":    nil,
+		"NotTime":   "2019-03-14",
+	}, actual)
+
+	actual, err = cfg.With(maps.WithTimeFormat("2006-01-02")).Marshal(s)
+	require.NoError(err)
+	require.Equal("2019-03-14", actual["Timestamp"])
+
+	// Time values are left as-is by default.
+	actual, err = cfg.Marshal(s)
+	require.NoError(err)
+	require.Equal(ts, actual["Timestamp"])
+
+	// Plain time.Time and *time.Time fields are formatted as well.
+	p := &PlainTimeyStruct{T: ts, TP: &ts}
+	actual, err = cfg.With(maps.WithTimeFormat("2006-01-02")).Marshal(p)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{
+		"T":    "2019-03-14",
+		"TP":   "2019-03-14",
+		"NoTP": (*time.Time)(nil),
+	}, actual)
+
+	actual, err = cfg.Marshal(p)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{
+		"T":    ts,
+		"TP":   &ts,
+		"NoTP": (*time.Time)(nil),
+	}, actual)
+
+	var decoded PlainTimeyStruct
+	require.NoError(cfg.Unmarshal(actual, &decoded))
+	require.Equal(*p, decoded)
+}
+
+type DurationStruct struct {
+	Timeout  time.Duration
+	Interval null.Duration
+	Count    int64
+}
+
+func TestDurationsAsStrings(t *testing.T) {
+	require := require.New(t)
+
+	s := &DurationStruct{
+		Timeout:  time.Hour,
+		Interval: null.NewDuration(90 * time.Second),
+		Count:    42,
+	}
+
+	cfg := &maps.Config{TagName: "map"}
+	actual, err := cfg.With(maps.WithDurationsAsStrings(true)).Marshal(s)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{
+		"Timeout":  "1h0m0s",
+		"Interval": "1m30s",
+		"Count":    int64(42),
+	}, actual)
+
+	// Durations are stored as time.Durations by default.
+	actual, err = cfg.Marshal(s)
+	require.NoError(err)
+	require.Equal(time.Hour, actual["Timeout"])
+	require.Equal(int64(time.Hour), int64(actual["Timeout"].(time.Duration)))
+}
+
+type ComplexStruct struct {
+	C128 complex128
+	C64  complex64
+	Real float64
+}
+
+func TestComplexAsObject(t *testing.T) {
+	require := require.New(t)
+
+	s := &ComplexStruct{
+		C128: complex(1.5, -2),
+		C64:  complex(0.25, 4),
+		Real: 3,
+	}
+
+	cfg := &maps.Config{TagName: "map"}
+	actual, err := cfg.With(maps.WithComplexAsObject(true)).Marshal(s)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{
+		"C128": map[string]interface{}{"real": 1.5, "imag": -2.0},
+		"C64":  map[string]interface{}{"real": 0.25, "imag": 4.0},
+		"Real": 3.0,
+	}, actual)
+	_, err = json.Marshal(actual)
+	require.NoError(err)
+
+	// Complex numbers are stored natively by default.
+	actual, err = cfg.Marshal(s)
+	require.NoError(err)
+	require.Equal(complex(1.5, -2), actual["C128"])
+	require.Equal(complex64(complex(0.25, 4)), actual["C64"])
+}
+
+type CollectionsStruct struct {
+	Ints    []int
+	Map     map[string]int
+	Omitted []int `map:",omitNil"`
+	Full    []int
+}
+
+func TestNilCollectionsAsEmpty(t *testing.T) {
+	require := require.New(t)
+
+	s := &CollectionsStruct{Full: []int{1, 2}}
+
+	cfg := &maps.Config{TagName: "map"}
+	actual, err := cfg.With(maps.WithNilCollectionsAsEmpty(true)).Marshal(s)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{
+		"Ints": []int{},
+		"Map":  map[string]int{},
+		"Full": []int{1, 2},
+	}, actual)
+	require.NotNil(actual["Ints"])
+	require.NotNil(actual["Map"])
+
+	// Nil collections are stored as typed nils by default.
+	actual, err = cfg.Marshal(s)
+	require.NoError(err)
+	require.Equal([]int(nil), actual["Ints"])
+	require.Equal(map[string]int(nil), actual["Map"])
+}
+
+type NullsStruct struct {
+	Int       null.Int64
+	JSON      null.RawJSON
+	Point     null.SFPoint
+	ValidInt  null.Int64
+	ValidJSON null.RawJSON
+	Bytes     []byte
+	// Valid, but base64 encoded as "null" by MarshalMapValue.
+	NullishBytes null.ByteSlice
+}
+
+func TestNullMapValue(t *testing.T) {
+	require := require.New(t)
+
+	s := &NullsStruct{
+		Int:       null.NullInt64(),
+		JSON:      null.NullJSON(),
+		Point:     null.NullSFPoint(),
+		ValidInt:  null.NewInt64(42),
+		ValidJSON: null.NewJSONStr(`{"a":1}`),
+		// Not a MarshalMapValue result, so never treated as null.
+		Bytes:        []byte("null"),
+		NullishBytes: null.NewByteSlice([]byte{0x9e, 0xe9, 0x65}),
+	}
+	valid := map[string]interface{}{
+		"ValidInt":     int64(42),
+		"ValidJSON":    map[string]interface{}{"a": float64(1)},
+		"Bytes":        []byte("null"),
+		"NullishBytes": []byte("null"),
+	}
+	with := func(m map[string]interface{}) map[string]interface{} {
+		for k, v := range valid {
+			m[k] = v
+		}
+		return m
+	}
+
+	cfg := &maps.Config{TagName: "map"}
+	for _, tc := range []struct {
+		nmv      maps.NullMapValue
+		expected map[string]interface{}
+	}{
+		{maps.NullMapValueAsIs, with(map[string]interface{}{
+			"Int":   nil,
+			"JSON":  nil,
+			"Point": []byte("null"),
+		})},
+		{maps.NullMapValueNil, with(map[string]interface{}{
+			"Int":   nil,
+			"JSON":  nil,
+			"Point": nil,
+		})},
+		{maps.NullMapValueJSON, with(map[string]interface{}{
+			"Int":   []byte("null"),
+			"JSON":  []byte("null"),
+			"Point": []byte("null"),
+		})},
+		{maps.NullMapValueOmit, with(map[string]interface{}{})},
+	} {
+		actual, err := cfg.With(maps.WithNullMapValue(tc.nmv)).Marshal(s)
+		require.NoError(err)
+		require.Equal(tc.expected, actual)
+	}
+}
+
+// nullSentinel is a value no field of NullSentinelStruct can hold.
+type nullSentinel struct{}
+
+type NullSentinelStruct struct {
+	Null    null.Int64
+	Zero    null.Int64
+	NonZero null.Int64
+	Absent  null.Int64 `map:",omitNil"`
+	String  null.String
+	Float   null.Float64
+}
+
+func TestNullSentinel(t *testing.T) {
+	require := require.New(t)
+
+	s := &NullSentinelStruct{
+		Zero:    null.NewInt64(0),
+		NonZero: null.NewInt64(42),
+		Float:   null.NewFloat64(0),
+	}
+
+	cfg := &maps.Config{TagName: "map"}
+	actual, err := cfg.With(maps.WithNullSentinel(nullSentinel{})).Marshal(s)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{
+		"Null":    nullSentinel{},
+		"Zero":    int64(0),
+		"NonZero": int64(42),
+		"String":  nullSentinel{},
+		"Float":   float64(0),
+	}, actual)
+	_, ok := actual["Absent"]
+	require.False(ok)
+
+	// Without a sentinel, null and valid-zero fields are told apart only by
+	// nil.
+	actual, err = cfg.Marshal(s)
+	require.NoError(err)
+	require.Nil(actual["Null"])
+	require.Equal(int64(0), actual["Zero"])
+}
+
+type WideStruct struct {
+	A, B, C, D int
+	Empty      string `map:",omitEmpty"`
+	Nested     NestedStruct
+}
+
+func TestMaxFields(t *testing.T) {
+	require := require.New(t)
+
+	s := WideStruct{A: 1, B: 2, C: 3, D: 4}
+	cfg := &maps.Config{TagName: "map"}
+
+	_, err := cfg.With(maps.WithMaxFields(4)).Marshal(s)
+	require.Error(err)
+	require.Contains(err.Error(), "maximum of 4 fields")
+
+	// Omitted fields don't count, and nested structs are limited separately.
+	actual, err := cfg.With(maps.WithMaxFields(5)).Marshal(s)
+	require.NoError(err)
+	require.Len(actual, 5)
+
+	// The type field counts.
+	_, err = cfg.With(maps.WithMaxFields(5), maps.WithTypeField("type")).Marshal(s)
+	require.Error(err)
+
+	_, err = cfg.With(maps.WithMaxFields(1)).MarshalSlice([]NestedStruct{{}})
+	require.Error(err)
+}
+
+type NullFlagsStruct struct {
+	Int      null.Int64
+	ValidInt null.Int64
+	Str      sql.NullString
+	Bytes    null.ByteSlice
+	Date     null.Date
+	Plain    int
+}
+
+func TestExplicitNullFlags(t *testing.T) {
+	require := require.New(t)
+
+	s := NullFlagsStruct{
+		Int:      null.NullInt64(),
+		ValidInt: null.NewInt64(42),
+		Str:      sql.NullString{String: "foo", Valid: true},
+		Bytes:    null.NewRawByteSlice([]byte("hi")),
+		Date:     null.NewDate(2020, time.January, 2),
+		Plain:    7,
+	}
+	cfg := &maps.Config{TagName: "map"}
+
+	actual, err := cfg.With(maps.WithExplicitNullFlags(true)).Marshal(s)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{
+		"Int":      map[string]interface{}{"value": int64(0), "valid": false},
+		"ValidInt": map[string]interface{}{"value": int64(42), "valid": true},
+		"Str":      map[string]interface{}{"value": "foo", "valid": true},
+		"Bytes":    map[string]interface{}{"value": []byte("hi"), "valid": true},
+		"Date":     map[string]interface{}{"value": time.Date(2020, time.January, 2, 0, 0, 0, 0, time.UTC), "valid": true},
+		"Plain":    7,
+	}, actual)
+
+	// Without the flag, the values are collapsed as usual.
+	actual, err = cfg.Marshal(s)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{
+		"Int":      nil,
+		"ValidInt": int64(42),
+		"Str":      "foo",
+		"Bytes":    []byte("aGk="),
+		"Date":     "2020-01-02",
+		"Plain":    7,
+	}, actual)
+}
+
+type KeyedParent struct {
+	ParentID    int
+	HTTPStatus  int
+	TaggedField string `map:"CustomName"`
+	Child       NestedStruct
+	ChildPtr    *NestedStruct
+}
+
+func TestKeyCaseNested(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		err    error
+		actual map[string]interface{}
+	)
+
+	s := &KeyedParent{
+		ParentID:    1,
+		HTTPStatus:  200,
+		TaggedField: "tagged",
+		Child:       NestedStruct{2, 3.4},
+		ChildPtr:    &NestedStruct{5, 6.7},
+	}
+
+	cfg := (&maps.Config{TagName: "map"}).With(maps.WithKeyCase(maps.KeyCaseSnake))
+	actual, err = cfg.Marshal(s)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{
+		"parent_id":   1,
+		"http_status": 200,
+		"CustomName":  "tagged",
+		"child": map[string]interface{}{
+			"an_int":  2,
+			"a_float": 3.4,
+		},
+		"child_ptr": map[string]interface{}{
+			"an_int":  5,
+			"a_float": 6.7,
+		},
+	}, actual)
+
+	// Unmarshal reads the converted keys.
+	var decoded KeyedParent
+	err = cfg.Unmarshal(actual, &decoded)
+	require.NoError(err)
+	require.Equal(*s, decoded)
+
+	cfg = (&maps.Config{TagName: "map"}).With(maps.WithKeyCase(maps.KeyCaseCamel))
+	actual, err = cfg.Marshal(s)
+	require.NoError(err)
+	require.Contains(actual, "parentId")
+	require.Contains(actual["childPtr"], "aFloat")
+
+	// Keys are untouched by default.
+	actual, err = maps.Marshal(s)
+	require.NoError(err)
+	require.Contains(actual, "ParentID")
+	require.Contains(actual["Child"], "AnInt")
+}
+
+type KeyCaseStruct struct {
+	FieldThree  string
+	HTTPStatus  int
+	TaggedField string `map:"Tagged_Name"`
+	OptsOnly    bool   `map:",omitNil"`
+}
+
+func TestKeyCase(t *testing.T) {
+	require := require.New(t)
+
+	s := KeyCaseStruct{"three", 200, "tagged", true}
+	for _, tc := range []struct {
+		kc       maps.KeyCase
+		expected map[string]interface{}
+	}{
+		{maps.KeyCaseNone, map[string]interface{}{
+			"FieldThree":  "three",
+			"HTTPStatus":  200,
+			"Tagged_Name": "tagged",
+			"OptsOnly":    true,
+		}},
+		{maps.KeyCaseSnake, map[string]interface{}{
+			"field_three": "three",
+			"http_status": 200,
+			"Tagged_Name": "tagged",
+			"opts_only":   true,
+		}},
+		{maps.KeyCaseCamel, map[string]interface{}{
+			"fieldThree":  "three",
+			"httpStatus":  200,
+			"Tagged_Name": "tagged",
+			"optsOnly":    true,
+		}},
+		{maps.KeyCaseKebab, map[string]interface{}{
+			"field-three": "three",
+			"http-status": 200,
+			"Tagged_Name": "tagged",
+			"opts-only":   true,
+		}},
+	} {
+		// Each KeyCase is a distinct Config, and so a distinct encoder; the
+		// same type is encoded differently under each.
+		cfg := (&maps.Config{TagName: "map"}).With(maps.WithKeyCase(tc.kc))
+		actual, err := cfg.Marshal(s)
+		require.NoError(err)
+		require.Equal(tc.expected, actual)
+
+		var decoded KeyCaseStruct
+		err = cfg.Unmarshal(actual, &decoded)
+		require.NoError(err)
+		require.Equal(s, decoded)
+	}
+}
+
+type KeyCaseTaggedCollision struct {
+	FieldThree int
+	Other      int `map:"field_three"`
+}
+
+type KeyCaseAmbiguous struct {
+	FieldThree  int
+	Field_Three int
+}
+
+func TestKeyCaseCollisions(t *testing.T) {
+	require := require.New(t)
+
+	// Collisions are resolved on the converted keys; a tagged field dominates
+	// an untagged field converted to the same key, as it would one sharing
+	// its Go name.
+	cfg := (&maps.Config{TagName: "map"}).With(maps.WithKeyCase(maps.KeyCaseSnake))
+	actual, err := cfg.Marshal(KeyCaseTaggedCollision{1, 2})
+	require.NoError(err)
+	require.Equal(map[string]interface{}{"field_three": 2}, actual)
+
+	var decoded KeyCaseTaggedCollision
+	err = cfg.Unmarshal(actual, &decoded)
+	require.NoError(err)
+	require.Equal(KeyCaseTaggedCollision{0, 2}, decoded)
+
+	// Untagged fields converted to the same key are ambiguous.
+	_, err = cfg.Marshal(KeyCaseAmbiguous{1, 2})
+	require.Error(err)
+	_, err = (&maps.Config{TagName: "map"}).Marshal(KeyCaseAmbiguous{1, 2})
+	require.NoError(err)
+}
+
+type PrefixedStruct struct {
+	DBFieldOne   string
+	DBFieldTwo   int
+	Other        bool
+	DBTaggedName string `map:"DBTagged"`
+}
+
+func stripPrefix(prefix string) func(string) string {
+	return func(name string) string {
+		return strings.TrimPrefix(name, prefix)
+	}
+}
+
+func TestNameFunc(t *testing.T) {
+	require := require.New(t)
+
+	s := PrefixedStruct{"one", 2, true, "tagged"}
+	cfg := (&maps.Config{TagName: "map"}).With(maps.WithNameFunc(stripPrefix("DB")))
+	actual, err := cfg.Marshal(s)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{
+		"FieldOne": "one",
+		"FieldTwo": 2,
+		"Other":    true,
+		"DBTagged": "tagged",
+	}, actual)
+
+	var decoded PrefixedStruct
+	err = cfg.Unmarshal(actual, &decoded)
+	require.NoError(err)
+	require.Equal(s, decoded)
+
+	// NameFunc is applied before KeyCase.
+	actual, err = cfg.With(maps.WithKeyCase(maps.KeyCaseSnake)).Marshal(s)
+	require.NoError(err)
+	require.Contains(actual, "field_one")
+	require.Contains(actual, "DBTagged")
+
+	// Encoders built with a NameFunc are cached per Config, so Configs that
+	// differ only by NameFunc do not share keys.
+	actual, err = cfg.With(maps.WithNameFunc(stripPrefix("DBField"))).Marshal(s)
+	require.NoError(err)
+	require.Contains(actual, "One")
+	require.Contains(actual, "Two")
+	actual, err = (&maps.Config{TagName: "map"}).Marshal(s)
+	require.NoError(err)
+	require.Contains(actual, "DBFieldOne")
+	// Collisions are resolved on the mapped keys.
+	_, err = cfg.Marshal(struct {
+		DBOther string
+		Other   string
+	}{})
+	require.Error(err)
+}
+
+type ErroringMarshaler struct{}
+
+func (ErroringMarshaler) MarshalMapValue() (interface{}, error) {
+	return nil, errors.New("ErroringMarshaler: always fails")
+}
+
+type PanickingMarshaler struct{}
+
+func (PanickingMarshaler) MarshalMapValue() (interface{}, error) {
+	panic(42)
+}
+
+func TestPanicOnError(t *testing.T) {
+	require := require.New(t)
+
+	type Erroring struct{ Field ErroringMarshaler }
+	type Panicking struct{ Field PanickingMarshaler }
+
+	cfg := &maps.Config{TagName: "map"}
+	panicCfg := cfg.With(maps.WithPanicOnError(true))
+
+	// By default, errors raised while encoding are recovered and returned ...
+	_, err := cfg.Marshal(Erroring{})
+	require.Error(err)
+	require.Contains(err.Error(), "always fails")
+	_, err = cfg.MarshalSlice([]Erroring{{}})
+	require.Error(err)
+
+	// ... but with PanicOnError they propagate.
+	require.Panics(func() { panicCfg.Marshal(Erroring{}) })
+	require.Panics(func() { panicCfg.MarshalSlice([]Erroring{{}}) })
+
+	// Panics with non-error values always propagate.
+	require.Panics(func() { cfg.Marshal(Panicking{}) })
+	require.Panics(func() { cfg.MarshalSlice([]Panicking{{}}) })
+	require.Panics(func() { panicCfg.Marshal(Panicking{}) })
+}
@@ -0,0 +1,373 @@
+package maps
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fieldsLevelOne struct {
+	fieldsLevelTwoLeft
+	fieldsLevelTwoRight
+	Own string `map:"own,omitZero"`
+}
+
+type fieldsLevelTwoLeft struct {
+	AnInt   int
+	AString string
+	AFloat  float64
+}
+
+type fieldsLevelTwoRight struct {
+	AnInt int `map:"AnInt"`
+	*fieldsLevelThree
+}
+
+type fieldsLevelThree struct {
+	AString string
+	AFloat  float64 `map:"AFloat"`
+	Deep    bool    `map:"deep"`
+	Skipped int     `map:"-"`
+	hidden  int
+}
+
+type fieldsInline struct {
+	Title string
+	Meta  fieldsLevelTwoLeft `map:",inline"`
+	Other fieldsLevelTwoLeft
+}
+
+// bigStruct returns a struct type of n fields, alternating between tagged and
+// untagged fields, with an embedded struct every tenth field.
+func bigStruct(n int) reflect.Type {
+	sfs := make([]reflect.StructField, 0, n)
+	for i := 0; i < n; i++ {
+		sf := reflect.StructField{
+			Name: fmt.Sprintf("Field%d", i),
+			Type: reflect.TypeOf(0),
+		}
+		switch {
+		case i%10 == 9:
+			sf.Name = fmt.Sprintf("Embedded%d", i)
+			sf.Type = reflect.StructOf([]reflect.StructField{
+				{Name: fmt.Sprintf("Inner%d", i), Type: reflect.TypeOf("")},
+				{Name: fmt.Sprintf("Field%d", i-1), Type: reflect.TypeOf("")},
+			})
+			sf.Anonymous = true
+		case i%2 == 0:
+			sf.Tag = reflect.StructTag(fmt.Sprintf(`map:"field_%d,omitZero"`, i))
+		}
+		sfs = append(sfs, sf)
+	}
+	return reflect.StructOf(sfs)
+}
+
+// resolvedFields summarizes fields as "name:index" strings.
+func resolvedFields(fields []field) []string {
+	ret := make([]string, len(fields))
+	for i, f := range fields {
+		ret[i] = fmt.Sprintf("%s:%v", f.name, f.index)
+	}
+	return ret
+}
+
+// TestTypeFieldsResolution pins the fields typeFields resolves for embedded,
+// shadowed, and inlined structs, so optimizations to it can't change its output.
+func TestTypeFieldsResolution(t *testing.T) {
+	require := require.New(t)
+
+	require.Equal([]string{
+		"AString:[0 1]",
+		"AFloat:[0 2]",
+		"AnInt:[1 0]",
+		"deep:[1 1 2]",
+		"own:[2]",
+	}, resolvedFields(typeFields(reflect.TypeOf(fieldsLevelOne{}), defaultConfig)))
+
+	require.Equal([]string{
+		"Title:[0]",
+		"AnInt:[1 0]",
+		"AString:[1 1]",
+		"AFloat:[1 2]",
+		"Other:[2]",
+	}, resolvedFields(typeFields(reflect.TypeOf(fieldsInline{}), defaultConfig)))
+
+	fields := typeFields(bigStruct(200), defaultConfig)
+	require.Len(fields, 220)
+	require.Equal([]string{
+		"field_0:[0]",
+		"Field1:[1]",
+		"field_2:[2]",
+		"Field3:[3]",
+		"field_4:[4]",
+		"Field5:[5]",
+		"field_6:[6]",
+		"Field7:[7]",
+		"field_8:[8]",
+		"Inner9:[9 0]",
+		"Field8:[9 1]",
+		"field_10:[10]",
+	}, resolvedFields(fields)[:12])
+	for _, f := range fields {
+		require.Equal(f.name, string(f.nameBytes))
+		require.NotNil(f.equalFold)
+	}
+}
+
+// comparableFields returns a copy of fields with their fold functions, which
+// cannot be compared, removed; after checking that each field has one.
+func comparableFields(t *testing.T, fields []field) []field {
+	ret := make([]field, len(fields))
+	for i, f := range fields {
+		require.NotNil(t, f.equalFold)
+		f.equalFold = nil
+		ret[i] = f
+	}
+	return ret
+}
+
+// TestTypeFieldsReference compares the full output of typeFields with that of
+// referenceTypeFields, for each fixture under several Configs.
+func TestTypeFieldsReference(t *testing.T) {
+	require := require.New(t)
+
+	fixtures := []reflect.Type{
+		reflect.TypeOf(fieldsLevelOne{}),
+		reflect.TypeOf(fieldsLevelTwoRight{}),
+		reflect.TypeOf(fieldsInline{}),
+		bigStruct(10),
+		bigStruct(200),
+	}
+	cfgs := []*Config{
+		defaultConfig,
+		defaultConfig.With(WithKeyCase(KeyCaseCamel)),
+		defaultConfig.With(WithKeyCase(KeyCaseSnake)),
+		defaultConfig.With(WithNameFunc(strings.ToLower)),
+	}
+	for _, cfg := range cfgs {
+		for _, ft := range fixtures {
+			require.Equal(
+				comparableFields(t, referenceTypeFields(ft, cfg)),
+				comparableFields(t, typeFields(ft, cfg)))
+		}
+	}
+}
+
+// referenceTypeFields is typeFields as it was before its allocations were
+// reduced, updated only for the later resolution of fields by their converted
+// keys. It is kept to check that optimizations to typeFields don't change its
+// output.
+func referenceTypeFields(t reflect.Type, cfg *Config) []field {
+	// Anonymous fields to explore at the current level and the next.
+	current := []field{}
+	next := []field{{typ: t}}
+
+	// Count of queued names for current level and the next.
+	count := map[reflect.Type]int{}
+	nextCount := map[reflect.Type]int{}
+
+	// Types already visited at an earlier level.
+	visited := map[reflect.Type]bool{}
+
+	// Fields found.
+	var fields []field
+
+	for len(next) > 0 {
+		current, next = next, current[:0]
+		count, nextCount = nextCount, map[reflect.Type]int{}
+
+		for _, f := range current {
+			if visited[f.typ] {
+				continue
+			}
+			visited[f.typ] = true
+
+			// Scan f.typ for fields to include.
+			for i := 0; i < f.typ.NumField(); i++ {
+				sf := f.typ.Field(i)
+				isUnexported := sf.PkgPath != ""
+				isEmbedded := sf.Anonymous
+				if isEmbedded {
+					t := sf.Type
+					if t.Kind() == reflect.Ptr {
+						t = t.Elem()
+					}
+					if isUnexported && t.Kind() != reflect.Struct {
+						// Ignore embedded fields of unexported non-structs.
+						// Would these be the fields of unexported Complex
+						// numbers? I'm not sure when we would hit this.
+						continue
+					}
+					// Do not ignore embedded fields of unexported structs,
+					// because they may have exported fields.
+				} else if isUnexported {
+					continue
+				}
+
+				tag := sf.Tag.Get(cfg.TagName)
+				tagged := tag != ""
+				name, opts := parseTag(tag)
+				if name == "-" {
+					continue
+				}
+				// TODO: Consider adding a check to ensure `name` is a valid key
+				if name == "" {
+					name = cfg.fieldName(sf.Name)
+				}
+
+				index := make([]int, len(f.index)+1)
+				copy(index, f.index)
+				index[len(f.index)] = i
+
+				sft := sf.Type
+				// If we're looking at an unnamed pointer type, follow the
+				// pointer to the underlying type.
+				if sft.Name() == "" && sft.Kind() == reflect.Ptr {
+					sft = sft.Elem()
+				}
+
+				// Fields tagged with the inline option have their fields
+				// hoisted into the parent, as if they had been embedded.
+				inline := opts.Contains("inline") && sft.Kind() == reflect.Struct
+
+				// Record the found field and index sequence ...
+				if !inline && (tagged || !isEmbedded || sft.Kind() != reflect.Struct) {
+					fields = append(fields, fillField(field{
+						name:    name,
+						tagged:  tagged,
+						inlined: f.inlined,
+						index:   index,
+						typ:     sft,
+						options: opts,
+					}))
+					if count[f.typ] > 1 {
+						// If there were multiple instances, add a second, so
+						// that the annihilation code will see a duplicate.
+						// It only cares about the distinction between 1 or 2,
+						// so don't bother generating any more copies.
+						fields = append(fields, fields[len(fields)-1])
+					}
+					continue
+				}
+
+				// ... or record a new anonymous struct to be explored in the
+				// next round.
+				nextCount[sft]++
+				if nextCount[sft] == 1 {
+					next = append(next, fillField(field{
+						name:    sft.Name(),
+						inlined: f.inlined || inline,
+						index:   index,
+						typ:     sft,
+					}))
+				}
+			}
+		}
+	}
+
+	// Sort field first by name, then breaking ties with index sequence length,
+	// then breaking ties with "name came from map tag", then breaking ties with
+	// index sequence.
+	sort.Slice(fields, func(i, j int) bool {
+		l := fields[i]
+		r := fields[j]
+
+		if l.name != r.name {
+			return l.name < r.name
+		}
+		if len(l.index) != len(r.index) {
+			return len(l.index) < len(r.index)
+		}
+		if l.tagged != r.tagged {
+			return l.tagged
+		}
+		for k := 0; k < len(l.index); k++ {
+			if k >= len(r.index) {
+				return false
+			}
+			if l.index[k] != r.index[k] {
+				return l.index[k] < r.index[k]
+			}
+		}
+		return len(l.index) < len(r.index)
+	})
+
+	// Delete all fields that are hidden based on Go's rules for embedded
+	// fields, modified for the presence of map tags.
+	out := fields[:0]
+	for i, count := 0, 0; i < len(fields); i += count {
+		// Find the number of fields that share a name
+		for count = 1; i+count < len(fields); count++ {
+			if fields[i].name != fields[i+count].name {
+				break
+			}
+		}
+		// If there's only one field named `name`, our job is easy ...
+		if count == 1 {
+			out = append(out, fields[i])
+			continue
+		}
+		// ... otherwise, find the single field that dominates the other
+		// similarly named fields using Go's embedding rules, modified by the
+		// presence of map tags. If there are multiple top-level fields -- which
+		// is an error in Go -- we mirror the compile-time "ambiguous selector"
+		// error as closely as possible at runtime. With a panic.
+		contended := fields[i : i+count]
+		// Unlike embedded fields, inlined fields are not shadowed; they must
+		// not collide with any other field.
+		for _, f := range contended {
+			if f.inlined {
+				panic(fmt.Errorf("encmap: inlined field name '%s' collides with another field in %s", f.name, t.Name()))
+			}
+		}
+		taggedIndex := -1
+		for j, f := range contended {
+			// A shorter index length indicates a more dominant field. The
+			// `contended` slice is sorted in increasing index-length order. We
+			// can therefore drop longer (and less dominant) entries by simply
+			// truncating the slice.
+			if len(f.index) > len(contended[0].index) {
+				contended = contended[:j]
+				break
+			}
+			// Fields with map tags are given special precedence for the given
+			// index-length level, so we need to keep track of their presence.
+			if f.tagged {
+				// If there are multiple tagged fields at the same index level,
+				// we have a genuine conflict.
+				if taggedIndex >= 0 {
+					panic(fmt.Errorf("encmap: ambiguous tagged field name '%s' in %s", f.name, t.Name()))
+				}
+				taggedIndex = j
+			}
+		}
+		if taggedIndex >= 0 {
+			out = append(out, contended[taggedIndex])
+			continue
+		}
+		// All remaining contended fields have the same length. If there's more
+		// than one, we have a conflict.
+		if len(contended) > 1 {
+			panic(fmt.Errorf("encmap: ambiguous tagged field name '%s' in %s", contended[0].name, t.Name()))
+		}
+		out = append(out, contended[0])
+	}
+
+	fields = out
+	sort.Sort(byIndex(fields))
+
+	return fields
+}
+
+func BenchmarkTypeFields(b *testing.B) {
+	t := bigStruct(200)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		typeFields(t, defaultConfig)
+	}
+}
@@ -0,0 +1,93 @@
+package maps
+
+import (
+	"strings"
+	"unicode"
+)
+
+// KeyCase identifies a naming convention applied to the keys of struct fields
+// that have not been given an explicit name by a map tag. Explicitly named
+// fields are left untouched. Nested structs are encoded and decoded with the
+// same Config, so the keys of their fields are converted as well.
+//
+// Fields are resolved by their converted keys. A field tagged with a name
+// hides an untagged field converted to the same name, as it would one sharing
+// its Go name; untagged fields converted to the same name are ambiguous, and
+// cause an error.
+type KeyCase int
+
+const (
+	// KeyCaseNone leaves the Go names of untagged fields as-is.
+	KeyCaseNone KeyCase = iota
+	// KeyCaseSnake converts untagged names to snake_case; "FieldThree"
+	// becomes "field_three".
+	KeyCaseSnake
+	// KeyCaseCamel converts untagged names to camelCase; "FieldThree" becomes
+	// "fieldThree", and "HTTPStatus" becomes "httpStatus".
+	KeyCaseCamel
+	// KeyCaseKebab converts untagged names to kebab-case; "FieldThree"
+	// becomes "field-three".
+	KeyCaseKebab
+)
+
+// apply returns name converted to the case kc.
+func (kc KeyCase) apply(name string) string {
+	switch kc {
+	case KeyCaseSnake:
+		return strings.Join(splitWords(name), "_")
+	case KeyCaseCamel:
+		words := splitWords(name)
+		for i := 1; i < len(words); i++ {
+			r := []rune(words[i])
+			r[0] = unicode.ToUpper(r[0])
+			words[i] = string(r)
+		}
+		return strings.Join(words, "")
+	case KeyCaseKebab:
+		return strings.Join(splitWords(name), "-")
+	default:
+		return name
+	}
+}
+
+// fieldName returns the key cfg will use, in encoded and decoded maps, for a
+// struct field named name that has not been given an explicit name by a map
+// tag.
+func (cfg *Config) fieldName(name string) string {
+	if cfg.NameFunc != nil {
+		name = cfg.NameFunc(name)
+	}
+	return cfg.KeyCase.apply(name)
+}
+
+// splitWords splits name into lower-cased words at underscores, hyphens,
+// spaces, lower-to-upper case transitions, and the ends of runs of upper case
+// letters (treating "HTTPServer" as "HTTP" and "Server"). Digits are kept with
+// the preceding word.
+func splitWords(name string) []string {
+	var words []string
+	var cur []rune
+	flush := func() {
+		if len(cur) > 0 {
+			words = append(words, strings.ToLower(string(cur)))
+			cur = cur[:0]
+		}
+	}
+	runes := []rune(name)
+	for i, r := range runes {
+		switch {
+		case r == '_' || r == '-' || r == ' ':
+			flush()
+			continue
+		case unicode.IsUpper(r) && len(cur) > 0:
+			prev := runes[i-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if !unicode.IsUpper(prev) || nextIsLower {
+				flush()
+			}
+		}
+		cur = append(cur, r)
+	}
+	flush()
+	return words
+}
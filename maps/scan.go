@@ -0,0 +1,36 @@
+package maps
+
+import (
+	"reflect"
+)
+
+// Scanner is the decoding counterpart of Marshaler. It is implemented by types
+// that can populate themselves from the interface{} representation that would
+// be found in a map[string]interface{} -- a sub-map for struct-like types, or a
+// bare value otherwise.
+//
+// When Unmarshal encounters a field (or top-level target) that implements
+// Scanner, ScanMap will be called with the relevant value rather than
+// attempting a reflection-based assignment. If only a pointer to the field's
+// type implements Scanner, ScanMap will be called on the field's address when
+// that field is addressable.
+type Scanner interface {
+	ScanMap(src interface{}) error
+}
+
+var scannerType = reflect.TypeOf(new(Scanner)).Elem()
+
+// decodeScanner calls dst.ScanMap(src). If dst is a nil pointer, a new value
+// will be allocated for it first.
+func decodeScanner(src interface{}, dst reflect.Value) error {
+	if dst.Kind() == reflect.Ptr && dst.IsNil() {
+		dst.Set(reflect.New(dst.Type().Elem()))
+	}
+	return dst.Interface().(Scanner).ScanMap(src)
+}
+
+// decodeAddrScanner calls ScanMap(src) on the address of dst, for types where
+// only the pointer receiver implements Scanner.
+func decodeAddrScanner(src interface{}, dst reflect.Value) error {
+	return dst.Addr().Interface().(Scanner).ScanMap(src)
+}
@@ -7,6 +7,11 @@ import (
 type tagOptions map[string]string
 
 func parseTag(tag string) (string, tagOptions) {
+	// Most tags carry no options; skip the split and the options map for them.
+	// Reading from the resulting nil tagOptions is safe.
+	if strings.IndexByte(tag, ',') < 0 {
+		return tag, nil
+	}
 	strs := strings.Split(tag, ",")
 	name := strs[0]
 
@@ -16,7 +21,7 @@ func parseTag(tag string) (string, tagOptions) {
 		if idx < 0 {
 			opts.setOption(str, "")
 		} else {
-			opts.setOption(str[:idx], str[:idx])
+			opts.setOption(str[:idx], str[idx+1:])
 		}
 	}
 	return name, opts
@@ -0,0 +1,27 @@
+package maps
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTagOptionValues(t *testing.T) {
+	require := require.New(t)
+
+	name, opts := parseTag("name,omitZero,default=foo,Format=2006-01-02,empty=")
+	require.Equal("name", name)
+
+	// Options with a value return the right-hand side of the '='.
+	require.Equal("foo", opts.ValueOf("default"))
+	require.Equal("2006-01-02", opts.ValueOf("format"))
+	// Options without a value return their own name.
+	require.Equal("omitZero", opts.ValueOf("omitZero"))
+	require.Equal("empty", opts.ValueOf("empty"))
+	// Missing options return the empty string.
+	require.Equal("", opts.ValueOf("missing"))
+
+	val, ok := opts.getOption("empty")
+	require.True(ok)
+	require.Equal("", val)
+}
@@ -0,0 +1,69 @@
+package types
+
+import "sort"
+
+// BBoxIndex is an in-memory index of the bounding boxes of a set of
+// SFPolygons. It is intended to be used as a cheap prefilter for spatial joins;
+// Query returns the polygons that may contain a point, which can then be
+// checked exactly.
+//
+// A BBoxIndex does not retain the polygons it was built from, and will not
+// reflect later modifications to them.
+type BBoxIndex struct {
+	// boxes are sorted by increasing minX, s.t. Query can skip every box that
+	// begins after the queried point.
+	boxes []bbox
+}
+
+type bbox struct {
+	i                      int
+	minX, minY, maxX, maxY float64
+}
+
+// BuildBBoxIndex constructs and returns a new BBoxIndex over the bounding boxes
+// of polys. Nil polygons have no bounding box, and will never be returned by
+// Query.
+func BuildBBoxIndex(polys []SFPolygon) BBoxIndex {
+	boxes := make([]bbox, 0, len(polys))
+	for i, p := range polys {
+		if p.IsNil() {
+			continue
+		}
+		b := p.Bounds()
+		boxes = append(boxes, bbox{
+			i:    i,
+			minX: b.Min(0),
+			minY: b.Min(1),
+			maxX: b.Max(0),
+			maxY: b.Max(1),
+		})
+	}
+	sort.SliceStable(boxes, func(i, j int) bool {
+		return boxes[i].minX < boxes[j].minX
+	})
+	return BBoxIndex{boxes}
+}
+
+// Query returns the indices, in increasing order, of the polygons given to
+// BuildBBoxIndex whose bounding boxes contain point. Points on the edge of a
+// bounding box are considered to be contained by it. Being returned by Query
+// does not imply that a polygon contains point. A nil point is contained by no
+// bounding box.
+func (idx BBoxIndex) Query(point SFPoint) []int {
+	if point.IsNil() {
+		return []int{}
+	}
+	x, y := point.Lng(), point.Lat()
+	// Every box from n onward begins after x.
+	n := sort.Search(len(idx.boxes), func(i int) bool {
+		return idx.boxes[i].minX > x
+	})
+	ret := []int{}
+	for _, b := range idx.boxes[:n] {
+		if x <= b.maxX && b.minY <= y && y <= b.maxY {
+			ret = append(ret, b.i)
+		}
+	}
+	sort.Ints(ret)
+	return ret
+}
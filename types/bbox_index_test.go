@@ -0,0 +1,44 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/pyrrho/encoding/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBBoxIndexQuery(t *testing.T) {
+	require := require.New(t)
+
+	polys := []types.SFPolygon{
+		// A square, from (0, 0) to (10, 10).
+		types.NewSFPolygonXY([][2]float64{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}}),
+		// A triangle overlapping the square, bounded by (5, 5) to (20, 15).
+		types.NewSFPolygonXY([][2]float64{{5, 5}, {20, 5}, {5, 15}, {5, 5}}),
+		// A nil polygon, which has no bounds.
+		{},
+		// A square far away, from (-50, -50) to (-40, -40).
+		types.NewSFPolygonXY([][2]float64{{-50, -50}, {-40, -50}, {-40, -40}, {-50, -40}, {-50, -50}}),
+	}
+	idx := types.BuildBBoxIndex(polys)
+
+	require.Equal([]int{0}, idx.Query(types.NewSFPointXY(1, 1)))
+	require.Equal([]int{0, 1}, idx.Query(types.NewSFPointXY(7, 7)))
+	require.Equal([]int{3}, idx.Query(types.NewSFPointXY(-45, -45)))
+	require.Equal([]int{}, idx.Query(types.NewSFPointXY(100, 100)))
+	require.Equal([]int{}, idx.Query(types.NewSFPointXY(-45, 0)))
+
+	// Points on the edges of a bounding box are within it.
+	require.Equal([]int{0}, idx.Query(types.NewSFPointXY(0, 0)))
+	require.Equal([]int{0, 1}, idx.Query(types.NewSFPointXY(10, 10)))
+
+	// The query is only a prefilter; (18, 14) is within the triangle's
+	// bounding box, but not within the triangle.
+	require.Equal([]int{1}, idx.Query(types.NewSFPointXY(18, 14)))
+
+	// Nil points are within no bounding box.
+	require.Equal([]int{}, idx.Query(types.SFPoint{}))
+
+	empty := types.BuildBBoxIndex(nil)
+	require.Equal([]int{}, empty.Query(types.NewSFPointXY(0, 0)))
+}
@@ -0,0 +1,152 @@
+package types
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// Color is an 8-bit-per-channel RGBA color. Color implements all of the
+// pyrrho/encoding/types interfaces detailed in the package comments. Database,
+// JSON, and map interactions all use the CSS hex notation; "#rrggbb" for fully
+// opaque colors, and "#rrggbbaa" otherwise. When parsing, the shorthand "#rgb"
+// notation is also accepted, and hex digits may be of either case.
+//
+// The zero value of Color is fully transparent black ("#00000000").
+type Color struct {
+	R, G, B, A uint8
+}
+
+// Constructors
+
+// NewColor constructs and returns a new Color from the given red, green, blue,
+// and alpha channels.
+func NewColor(r, g, b, a uint8) Color {
+	return Color{
+		R: r,
+		G: g,
+		B: b,
+		A: a,
+	}
+}
+
+// ParseColor parses s as a "#rgb", "#rrggbb", or "#rrggbbaa" hex color. Colors
+// parsed from the three and six digit forms are fully opaque.
+func ParseColor(s string) (Color, error) {
+	if len(s) == 0 || s[0] != '#' {
+		return Color{}, fmt.Errorf("types.Color: invalid color %q; expected a leading '#'", s)
+	}
+	hex := s[1:]
+	switch len(hex) {
+	case 3:
+		hex = string([]byte{
+			hex[0], hex[0],
+			hex[1], hex[1],
+			hex[2], hex[2],
+			'f', 'f',
+		})
+	case 6:
+		hex += "ff"
+	case 8:
+	default:
+		return Color{}, fmt.Errorf("types.Color: invalid color %q; expected 3, 6, or 8 hex digits", s)
+	}
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return Color{}, fmt.Errorf("types.Color: invalid color %q; malformed hex digits", s)
+	}
+	return Color{
+		R: uint8(v >> 24),
+		G: uint8(v >> 16),
+		B: uint8(v >> 8),
+		A: uint8(v),
+	}, nil
+}
+
+// Getters
+
+// String returns c in CSS hex notation; "#rrggbb" if c is fully opaque, and
+// "#rrggbbaa" otherwise.
+func (c Color) String() string {
+	if c.A == 0xff {
+		return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+	}
+	return fmt.Sprintf("#%02x%02x%02x%02x", c.R, c.G, c.B, c.A)
+}
+
+// Interfaces
+
+// IsNil implements the pyrrho/encoding IsNiler interface. It will return true
+// if c is the zero value, fully transparent black.
+func (c Color) IsNil() bool {
+	return c == Color{}
+}
+
+// IsZero implements the pyrrho/encoding IsZeroer interface. It will return true
+// if c is the zero value, fully transparent black.
+func (c Color) IsZero() bool {
+	return c == Color{}
+}
+
+// Value implements the database/sql/driver Valuer interface. It will return the
+// value of c as a driver.Value; specifically the hex string returned by
+// c.String.
+func (c Color) Value() (driver.Value, error) {
+	return c.String(), nil
+}
+
+// Scan implements the database/sql Scanner interface. It expects to receive a
+// string or []byte holding a hex color, and will assign that value to c.
+func (c *Color) Scan(src interface{}) error {
+	if c == nil {
+		return fmt.Errorf("types.Color: Scan called on nil pointer")
+	}
+	var s string
+	switch x := src.(type) {
+	case string:
+		s = x
+	case []byte:
+		s = string(x)
+	default:
+		return fmt.Errorf("types.Color: cannot scan type %T (%v)", src, src)
+	}
+	parsed, err := ParseColor(s)
+	if err != nil {
+		return err
+	}
+	*c = parsed
+	return nil
+}
+
+// MarshalJSON implements the encoding/json Marshaler interface. It will return
+// c encoded as a quoted hex string.
+func (c Color) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.String())
+}
+
+// UnmarshalJSON implements the encoding/json Unmarshaler interface. It expects
+// to receive a quoted hex string, and will assign that value to c.
+//
+// If the decode fails, the value of c will be unchanged.
+func (c *Color) UnmarshalJSON(data []byte) error {
+	if c == nil {
+		return fmt.Errorf("types.Color: UnmarshalJSON called on nil pointer")
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	parsed, err := ParseColor(s)
+	if err != nil {
+		return err
+	}
+	*c = parsed
+	return nil
+}
+
+// MarshalMapValue implements the pyrrho/encoding/maps Marshaler interface. It
+// will return the hex string returned by c.String.
+func (c Color) MarshalMapValue() (interface{}, error) {
+	return c.String(), nil
+}
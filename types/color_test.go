@@ -0,0 +1,152 @@
+package types_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pyrrho/encoding/maps"
+	"github.com/pyrrho/encoding/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestColorCtors(t *testing.T) {
+	require := require.New(t)
+
+	c := types.NewColor(0x12, 0x34, 0x56, 0x78)
+	require.Equal(types.Color{R: 0x12, G: 0x34, B: 0x56, A: 0x78}, c)
+
+	p, err := types.ParseColor("#12345678")
+	require.NoError(err)
+	require.Equal(c, p)
+
+	p, err = types.ParseColor("#123456")
+	require.NoError(err)
+	require.Equal(types.NewColor(0x12, 0x34, 0x56, 0xff), p)
+
+	p, err = types.ParseColor("#abc")
+	require.NoError(err)
+	require.Equal(types.NewColor(0xaa, 0xbb, 0xcc, 0xff), p)
+
+	// Hex digits are case-insensitive.
+	p, err = types.ParseColor("#ABCDEF")
+	require.NoError(err)
+	require.Equal(types.NewColor(0xab, 0xcd, 0xef, 0xff), p)
+
+	for _, bad := range []string{"", "#", "123456", "#12", "#1234", "#12345", "#1234567", "#123456789", "#ggg", "#+12345", "#0x1234"} {
+		_, err = types.ParseColor(bad)
+		require.Error(err, bad)
+	}
+}
+
+func TestColorString(t *testing.T) {
+	require := require.New(t)
+
+	require.Equal("#123456", types.NewColor(0x12, 0x34, 0x56, 0xff).String())
+	require.Equal("#12345678", types.NewColor(0x12, 0x34, 0x56, 0x78).String())
+	require.Equal("#aabbcc", types.NewColor(0xaa, 0xbb, 0xcc, 0xff).String())
+	require.Equal("#00000000", types.Color{}.String())
+
+	// Formatting a parsed color round-trips to the long form.
+	for _, s := range []string{"#000000", "#ffffff", "#0a0b0c0d", "#fedcba98"} {
+		c, err := types.ParseColor(s)
+		require.NoError(err)
+		require.Equal(s, c.String())
+	}
+}
+
+func TestColorIsNilIsZero(t *testing.T) {
+	require := require.New(t)
+
+	require.True(types.Color{}.IsNil())
+	require.True(types.Color{}.IsZero())
+
+	// Opaque black is not transparent black.
+	black := types.NewColor(0, 0, 0, 0xff)
+	require.False(black.IsNil())
+	require.False(black.IsZero())
+
+	// Nor is a transparent non-black.
+	transparent := types.NewColor(0xff, 0xff, 0xff, 0)
+	require.False(transparent.IsNil())
+	require.False(transparent.IsZero())
+}
+
+func TestColorSQLValue(t *testing.T) {
+	require := require.New(t)
+
+	val, err := types.NewColor(0x12, 0x34, 0x56, 0xff).Value()
+	require.NoError(err)
+	require.Equal("#123456", val)
+
+	val, err = types.NewColor(0x12, 0x34, 0x56, 0x78).Value()
+	require.NoError(err)
+	require.Equal("#12345678", val)
+}
+
+func TestColorSQLScan(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	var s types.Color
+	err = s.Scan("#123")
+	require.NoError(err)
+	require.Equal(types.NewColor(0x11, 0x22, 0x33, 0xff), s)
+
+	var b types.Color
+	err = b.Scan([]byte("#12345678"))
+	require.NoError(err)
+	require.Equal(types.NewColor(0x12, 0x34, 0x56, 0x78), b)
+
+	bad := types.NewColor(1, 2, 3, 4)
+	err = bad.Scan("red")
+	require.Error(err)
+	require.Equal(types.NewColor(1, 2, 3, 4), bad)
+
+	var wrong types.Color
+	err = wrong.Scan(int64(0x123456))
+	require.Error(err)
+}
+
+func TestColorMarshalJSON(t *testing.T) {
+	require := require.New(t)
+
+	data, err := json.Marshal(types.NewColor(0x12, 0x34, 0x56, 0xff))
+	require.NoError(err)
+	require.EqualValues(`"#123456"`, data)
+
+	c := types.NewColor(0x12, 0x34, 0x56, 0x78)
+	data, err = json.Marshal(&c)
+	require.NoError(err)
+	require.EqualValues(`"#12345678"`, data)
+}
+
+func TestColorUnmarshalJSON(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	var c types.Color
+	err = json.Unmarshal([]byte(`"#abc"`), &c)
+	require.NoError(err)
+	require.Equal(types.NewColor(0xaa, 0xbb, 0xcc, 0xff), c)
+
+	err = json.Unmarshal([]byte(`"#aabbcc80"`), &c)
+	require.NoError(err)
+	require.Equal(types.NewColor(0xaa, 0xbb, 0xcc, 0x80), c)
+
+	// Failed decodes leave the value unchanged.
+	err = json.Unmarshal([]byte(`"#nope"`), &c)
+	require.Error(err)
+	require.Equal(types.NewColor(0xaa, 0xbb, 0xcc, 0x80), c)
+
+	err = json.Unmarshal([]byte(`123456`), &c)
+	require.Error(err)
+}
+
+func TestColorMarshalMapValue(t *testing.T) {
+	require := require.New(t)
+	type Wrapper struct{ Color types.Color }
+
+	data, err := maps.Marshal(Wrapper{types.NewColor(0x12, 0x34, 0x56, 0xff)})
+	require.NoError(err)
+	require.Equal(map[string]interface{}{"Color": "#123456"}, data)
+}
@@ -0,0 +1,199 @@
+package types
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/twpayne/go-geom"
+	"github.com/twpayne/go-geom/encoding/ewkb"
+	"github.com/twpayne/go-geom/encoding/geojson"
+	"github.com/twpayne/go-geom/encoding/wkb"
+)
+
+// MarshalNilGeometryAsNull controls how the MarshalJSON methods of the
+// geospatial types (SFPoint, SFPolygon, SFMultiPolygon, SFGeometryCollection)
+// handle uninitialized values. By default marshaling a nil geometry is an
+// error, as there is no meaningful GeoJSON representation of it. If this is set
+// to true, nil geometries will instead be marshaled as the JSON 'null' keyword,
+// mirroring the behavior of the pyrrho/encoding/types/null wrappers.
+//
+// This value is read at marshal-time, and should be set once during program
+// initialization.
+var MarshalNilGeometryAsNull = false
+
+// GeoJSONPrecision controls the number of decimal places each coordinate is
+// rounded to when the geospatial types are encoded as GeoJSON, by MarshalJSON
+// or by Value in GeometryValueGeoJSON mode. Rounding is applied to every
+// coordinate, including those of interior rings and collection members, and
+// trailing zeros are trimmed. By default this is negative, and coordinates are
+// encoded with full float64 precision.
+//
+// This value is read at marshal-time, and should be set once during program
+// initialization.
+var GeoJSONPrecision = -1
+
+// GeometryValueMode enumerates the column encodings the geospatial types can
+// produce from their Value methods. Different spatial stacks expect different
+// encodings; see GeometryValueOutput.
+type GeometryValueMode int
+
+const (
+	// GeometryValueWKB encodes geometries as a plain WKB (Well Known Binary)
+	// []byte. This is the default. Geometries with a non-zero SRID will be
+	// encoded as EWKB, as plain WKB cannot carry one.
+	GeometryValueWKB GeometryValueMode = iota
+	// GeometryValueEWKB encodes geometries as a PostGIS-style EWKB (Extended
+	// Well Known Binary) []byte, which carries the SRID of the geometry.
+	GeometryValueEWKB
+	// GeometryValueGeoJSON encodes geometries as GeoJSON text, returned as a
+	// string, for databases that store geospatial data in text or JSON
+	// columns. GeoJSON has no notion of an SRID, so it will be dropped.
+	GeometryValueGeoJSON
+)
+
+// GeometryValueOutput controls the encoding returned by the Value methods of the
+// geospatial types (SFPoint, SFPolygon, SFMultiPolygon, SFGeometryCollection).
+//
+// The Scan methods of those types will accept either WKB or EWKB regardless of
+// this setting, as the two are self-describing. GeoJSON text -- as either a
+// string or a []byte -- will only be accepted when this is set to
+// GeometryValueGeoJSON.
+//
+// This value is read at Value- and Scan-time, and should be set once during
+// program initialization.
+var GeometryValueOutput = GeometryValueWKB
+
+// ewkbFlags are the high bits of an EWKB geometry type, flagging the presence
+// of Z, M, and SRID components. They are never set in a plain WKB geometry type.
+const ewkbFlags = 0x80000000 | 0x40000000 | 0x20000000
+
+// geometryValue encodes g in the format selected by GeometryValueOutput. Plain
+// WKB has no room for an SRID, so geometries with a non-zero SRID will be
+// encoded as EWKB in GeometryValueWKB mode.
+func geometryValue(g geom.T) (driver.Value, error) {
+	switch {
+	case GeometryValueOutput == GeometryValueEWKB,
+		GeometryValueOutput == GeometryValueWKB && g.SRID() != 0:
+		return ewkb.Marshal(g, ewkb.NDR)
+	case GeometryValueOutput == GeometryValueGeoJSON:
+		return geoJSONValue(g)
+	default:
+		return wkb.Marshal(g, wkb.NDR)
+	}
+}
+
+// geoJSONValue encodes g as GeoJSON text, returned as a string.
+func geoJSONValue(g geom.T) (driver.Value, error) {
+	b, err := marshalGeoJSON(g)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// marshalGeoJSON encodes g as GeoJSON, rounding coordinates as directed by
+// GeoJSONPrecision.
+func marshalGeoJSON(g geom.T) ([]byte, error) {
+	if GeoJSONPrecision < 0 {
+		return geojson.Marshal(g)
+	}
+	return geojson.Marshal(g, geojson.EncodeGeometryWithMaxDecimalDigits(GeoJSONPrecision))
+}
+
+// scanGeometry decodes the geometry described by src, which may be a WKB or
+// EWKB encoded []byte, or -- if GeometryValueOutput is GeometryValueGeoJSON --
+// GeoJSON text. name is used to prefix any errors.
+func scanGeometry(name string, src interface{}) (geom.T, error) {
+	if err := checkScanNull(name, src); err != nil {
+		return nil, err
+	}
+	var b []byte
+	switch x := src.(type) {
+	case []byte:
+		b = x
+	case string:
+		if GeometryValueOutput != GeometryValueGeoJSON {
+			return nil, fmt.Errorf("%s: cannot scan type %T (%v)", name, src, src)
+		}
+		b = []byte(x)
+	default:
+		return nil, fmt.Errorf("%s: cannot scan type %T (%v)", name, src, src)
+	}
+
+	if GeometryValueOutput == GeometryValueGeoJSON {
+		if t := bytes.TrimLeft(b, " \t\r\n"); len(t) > 0 && t[0] == '{' {
+			var g geom.T
+			if err := geojson.Unmarshal(t, &g); err != nil {
+				return nil, err
+			}
+			return g, nil
+		}
+	}
+	if isEWKB(b) {
+		return ewkb.Unmarshal(b)
+	}
+	return wkb.Unmarshal(b)
+}
+
+// scanGeoJSON decodes the geometry described by src, which must be GeoJSON
+// text as either a string or a []byte, regardless of GeometryValueOutput. name
+// is used to prefix any errors.
+func scanGeoJSON(name string, src interface{}) (geom.T, error) {
+	if err := checkScanNull(name, src); err != nil {
+		return nil, err
+	}
+	var b []byte
+	switch x := src.(type) {
+	case []byte:
+		b = x
+	case string:
+		b = []byte(x)
+	default:
+		return nil, fmt.Errorf("%s: cannot scan type %T (%v)", name, src, src)
+	}
+	var g geom.T
+	if err := geojson.Unmarshal(b, &g); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// checkScanNull returns an error if src is nil, or an empty []byte or string;
+// the forms SQL NULL takes. The geospatial types in this package cannot be
+// null, and so cannot scan them. name is used to prefix the error.
+func checkScanNull(name string, src interface{}) error {
+	switch x := src.(type) {
+	case nil:
+	case []byte:
+		if len(x) > 0 {
+			return nil
+		}
+	case string:
+		if len(x) > 0 {
+			return nil
+		}
+	default:
+		return nil
+	}
+	return fmt.Errorf("%s: cannot scan NULL or empty data; use the pyrrho/encoding/types/null equivalent for nullable columns", name)
+}
+
+// isEWKB reports whether b looks like an EWKB, rather than plain WKB, encoded
+// geometry.
+func isEWKB(b []byte) bool {
+	if len(b) < 5 {
+		return false
+	}
+	var t uint32
+	switch b[0] {
+	case 0x00:
+		t = binary.BigEndian.Uint32(b[1:5])
+	case 0x01:
+		t = binary.LittleEndian.Uint32(b[1:5])
+	default:
+		return false
+	}
+	return t&ewkbFlags != 0
+}
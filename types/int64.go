@@ -0,0 +1,107 @@
+package types
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// Int64 is a non-null int64 that implements all of the pyrrho/encoding/types
+// interfaces detailed in the package comments. It is intended for required
+// (NOT NULL) integer columns that should still be handled by pyrrho/encoding.
+//
+// Unlike null.Int64, an Int64 cannot be null; there is no distinction between
+// a nil and a zero Int64. Both IsNil and IsZero will return true only if the
+// Int64 holds the Go zero value, 0. For columns that may be NULL, please use
+// the pyrrho/encoding/types/null package, specifically the null.Int64 type.
+type Int64 int64
+
+// NewInt64 constructs and returns a new Int64 initialized with the value of the
+// given i.
+func NewInt64(i int64) Int64 {
+	return Int64(i)
+}
+
+// Set modifies the value stored in i.
+func (i *Int64) Set(v int64) {
+	*i = Int64(v)
+}
+
+// IsNil implements the pyrrho/encoding IsNiler interface. It will return true
+// if i is 0.
+func (i Int64) IsNil() bool {
+	return i == 0
+}
+
+// IsZero implements the pyrrho/encoding IsZeroer interface. It will return true
+// if i is 0.
+func (i Int64) IsZero() bool {
+	return i == 0
+}
+
+// Value implements the database/sql/driver Valuer interface. It will return the
+// value of i as an int64 driver.Value.
+func (i Int64) Value() (driver.Value, error) {
+	return int64(i), nil
+}
+
+// Scan implements the database/sql Scanner interface. It expects to receive an
+// int64, or a string or []byte holding a base 10 integer, from an SQL database,
+// and will assign that value to i. SQL NULL values cannot be scanned into an
+// Int64.
+func (i *Int64) Scan(src interface{}) error {
+	if i == nil {
+		return fmt.Errorf("types.Int64: Scan called on nil pointer")
+	}
+	var s string
+	switch x := src.(type) {
+	case int64:
+		*i = Int64(x)
+		return nil
+	case string:
+		s = x
+	case []byte:
+		s = string(x)
+	case nil:
+		return fmt.Errorf("types.Int64: cannot scan NULL; use null.Int64 for nullable columns")
+	default:
+		return fmt.Errorf("types.Int64: cannot scan type %T (%v)", src, src)
+	}
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return fmt.Errorf("types.Int64: cannot scan %q: %v", s, err)
+	}
+	*i = Int64(v)
+	return nil
+}
+
+// MarshalJSON implements the encoding/json Marshaler interface. It will encode
+// i into its JSON representation.
+func (i Int64) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.FormatInt(int64(i), 10)), nil
+}
+
+// UnmarshalJSON implements the encoding/json Unmarshaler interface. It will
+// decode a given []byte into i, so long as the provided []byte is a valid JSON
+// representation of an int. As with a plain int64, the 'null' keyword is a
+// no-op.
+//
+// If the decode fails, the value of i will be unchanged.
+func (i *Int64) UnmarshalJSON(data []byte) error {
+	if i == nil {
+		return fmt.Errorf("types.Int64: UnmarshalJSON called on nil pointer")
+	}
+	tmp := int64(*i)
+	if err := json.Unmarshal(data, &tmp); err != nil {
+		return err
+	}
+	*i = Int64(tmp)
+	return nil
+}
+
+// MarshalMapValue implements the pyrrho/encoding/maps Marshaler interface. It
+// will return the value of i as an int64 for use in a map[string]interface{}.
+func (i Int64) MarshalMapValue() (interface{}, error) {
+	return int64(i), nil
+}
@@ -0,0 +1,169 @@
+package types_test
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/pyrrho/encoding/maps"
+	"github.com/pyrrho/encoding/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInt64Ctors(t *testing.T) {
+	require := require.New(t)
+
+	i := types.NewInt64(12345)
+	require.Equal(types.Int64(12345), i)
+
+	var zero types.Int64
+	require.Equal(types.Int64(0), zero)
+}
+
+func TestInt64Set(t *testing.T) {
+	require := require.New(t)
+
+	var i types.Int64
+	i.Set(12345)
+	require.Equal(types.Int64(12345), i)
+
+	i.Set(0)
+	require.Equal(types.Int64(0), i)
+}
+
+func TestInt64IsNil(t *testing.T) {
+	require := require.New(t)
+
+	// Only the Go zero value is considered nil ...
+	var zero types.Int64
+	require.True(zero.IsNil())
+
+	require.False(types.NewInt64(12345).IsNil())
+	require.False(types.NewInt64(-1).IsNil())
+}
+
+func TestInt64IsZero(t *testing.T) {
+	require := require.New(t)
+
+	// ... and IsZero matches.
+	var zero types.Int64
+	require.True(zero.IsZero())
+
+	require.False(types.NewInt64(12345).IsZero())
+	require.False(types.NewInt64(-1).IsZero())
+}
+
+func TestInt64SQLValue(t *testing.T) {
+	require := require.New(t)
+	var val driver.Value
+	var err error
+
+	val, err = types.NewInt64(12345).Value()
+	require.NoError(err)
+	require.Equal(int64(12345), val)
+
+	val, err = types.Int64(0).Value()
+	require.NoError(err)
+	require.Equal(int64(0), val)
+}
+
+func TestInt64SQLScan(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	var i types.Int64
+	err = i.Scan(int64(12345))
+	require.NoError(err)
+	require.Equal(types.Int64(12345), i)
+
+	var s types.Int64
+	err = s.Scan("-12345")
+	require.NoError(err)
+	require.Equal(types.Int64(-12345), s)
+
+	var b types.Int64
+	err = b.Scan([]byte("9223372036854775807"))
+	require.NoError(err)
+	require.Equal(types.Int64(math.MaxInt64), b)
+
+	// NULLs are not accepted; the value is unchanged.
+	nul := types.NewInt64(12345)
+	err = nul.Scan(nil)
+	require.Error(err)
+	require.Contains(err.Error(), "null.Int64")
+	require.Equal(types.Int64(12345), nul)
+
+	var overflow types.Int64
+	err = overflow.Scan("9223372036854775808")
+	require.Error(err)
+
+	var wrong types.Int64
+	err = wrong.Scan("hello world")
+	require.Error(err)
+
+	var f types.Int64
+	err = f.Scan(1.5)
+	require.Error(err)
+}
+
+func TestInt64MarshalJSON(t *testing.T) {
+	require := require.New(t)
+	var data []byte
+	var err error
+
+	i := types.NewInt64(12345)
+	data, err = json.Marshal(i)
+	require.NoError(err)
+	require.EqualValues("12345", data)
+	data, err = json.Marshal(&i)
+	require.NoError(err)
+	require.EqualValues("12345", data)
+
+	// Zero values are encoded as 0, never null.
+	var zero types.Int64
+	data, err = json.Marshal(zero)
+	require.NoError(err)
+	require.EqualValues("0", data)
+}
+
+func TestInt64UnmarshalJSON(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	var i types.Int64
+	err = json.Unmarshal([]byte("12345"), &i)
+	require.NoError(err)
+	require.Equal(types.Int64(12345), i)
+
+	// 'null' is a no-op, as it would be for an int64.
+	nul := types.NewInt64(12345)
+	err = json.Unmarshal([]byte("null"), &nul)
+	require.NoError(err)
+	require.Equal(types.Int64(12345), nul)
+
+	// Failed decodes leave the value unchanged.
+	bad := types.NewInt64(12345)
+	err = json.Unmarshal([]byte("1.5"), &bad)
+	require.Error(err)
+	require.Equal(types.Int64(12345), bad)
+
+	err = json.Unmarshal([]byte(`"12345"`), &bad)
+	require.Error(err)
+	require.Equal(types.Int64(12345), bad)
+}
+
+func TestInt64MarshalMapValue(t *testing.T) {
+	require := require.New(t)
+	type Wrapper struct{ Int64 types.Int64 }
+	var data map[string]interface{}
+	var err error
+
+	data, err = maps.Marshal(Wrapper{types.NewInt64(12345)})
+	require.NoError(err)
+	require.Equal(map[string]interface{}{"Int64": int64(12345)}, data)
+
+	data, err = maps.Marshal(Wrapper{})
+	require.NoError(err)
+	require.Equal(map[string]interface{}{"Int64": int64(0)}, data)
+}
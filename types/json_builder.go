@@ -0,0 +1,210 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// JSONBuilder constructs a single JSON object or array, one token at a time,
+// without the need to define intermediate types or format strings. The zero
+// value is an empty JSONBuilder ready to use. Each method returns the builder,
+// s.t. calls may be chained;
+//
+//	j, err := new(types.JSONBuilder).
+//		Object().
+//			Field("id", 42).
+//			Key("tags").Array().
+//				Elem("a").
+//				Elem("b").
+//			EndArray().
+//		EndObject().
+//		JSON()
+//
+// Values passed to Field and Elem are encoded with json.Marshal, so RawJSON
+// values -- and any other json.Marshalers -- are embedded as-is.
+//
+// The first error encountered, whether from encoding a value or from calls that
+// would produce invalid JSON, is retained and returned by JSON. All calls made
+// after an error are ignored.
+type JSONBuilder struct {
+	buf   []byte
+	stack []jsonBuilderFrame
+	done  bool
+	err   error
+}
+
+// jsonBuilderFrame tracks an open object or array.
+type jsonBuilderFrame struct {
+	delim byte // '{' or '['
+	n     int  // number of members or elements written
+	keyed bool // a key has been written, and is waiting for its value
+}
+
+// Object begins a new JSON object. Inside of an object, Object must follow a
+// call to Key.
+func (b *JSONBuilder) Object() *JSONBuilder {
+	if b.beginValue() {
+		b.buf = append(b.buf, '{')
+		b.stack = append(b.stack, jsonBuilderFrame{delim: '{'})
+	}
+	return b
+}
+
+// EndObject closes the innermost open object.
+func (b *JSONBuilder) EndObject() *JSONBuilder {
+	b.end('{', '}')
+	return b
+}
+
+// Array begins a new JSON array. Inside of an object, Array must follow a call
+// to Key.
+func (b *JSONBuilder) Array() *JSONBuilder {
+	if b.beginValue() {
+		b.buf = append(b.buf, '[')
+		b.stack = append(b.stack, jsonBuilderFrame{delim: '['})
+	}
+	return b
+}
+
+// EndArray closes the innermost open array.
+func (b *JSONBuilder) EndArray() *JSONBuilder {
+	b.end('[', ']')
+	return b
+}
+
+// Key writes key as the name of the next member of the innermost open object.
+// The member's value must be given by a following call to Object or Array.
+func (b *JSONBuilder) Key(key string) *JSONBuilder {
+	if b.err != nil {
+		return b
+	}
+	top := b.top()
+	if top == nil || top.delim != '{' {
+		b.err = fmt.Errorf("types.JSONBuilder: cannot write key %q outside of an object", key)
+		return b
+	}
+	if top.keyed {
+		b.err = fmt.Errorf("types.JSONBuilder: cannot write key %q before the value of the previous key", key)
+		return b
+	}
+	k, err := json.Marshal(key)
+	if err != nil {
+		b.err = err
+		return b
+	}
+	if top.n > 0 {
+		b.buf = append(b.buf, ',')
+	}
+	top.n++
+	top.keyed = true
+	b.buf = append(b.buf, k...)
+	b.buf = append(b.buf, ':')
+	return b
+}
+
+// Field writes a member named key, with the encoded value, to the innermost
+// open object.
+func (b *JSONBuilder) Field(key string, value interface{}) *JSONBuilder {
+	return b.Key(key).value(value)
+}
+
+// Elem writes the encoded value as the next element of the innermost open
+// array.
+func (b *JSONBuilder) Elem(value interface{}) *JSONBuilder {
+	if b.err != nil {
+		return b
+	}
+	if top := b.top(); top == nil || top.delim != '[' {
+		b.err = errors.New("types.JSONBuilder: cannot write an element outside of an array")
+		return b
+	}
+	return b.value(value)
+}
+
+// JSON returns the built document. An error will be returned if any previous
+// call failed, if no document has been started, or if any object or array has
+// been left open. The returned RawJSON is a copy, and may be retained.
+func (b *JSONBuilder) JSON() (RawJSON, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	if !b.done {
+		if len(b.stack) > 0 {
+			return nil, fmt.Errorf("types.JSONBuilder: %d unclosed objects or arrays", len(b.stack))
+		}
+		return nil, errors.New("types.JSONBuilder: no object or array has been written")
+	}
+	return NewJSON(b.buf), nil
+}
+
+func (b *JSONBuilder) top() *jsonBuilderFrame {
+	if len(b.stack) == 0 {
+		return nil
+	}
+	return &b.stack[len(b.stack)-1]
+}
+
+// value encodes v and writes it in the current position.
+func (b *JSONBuilder) value(v interface{}) *JSONBuilder {
+	if !b.beginValue() {
+		return b
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		b.err = err
+		return b
+	}
+	b.buf = append(b.buf, data...)
+	return b
+}
+
+// beginValue checks that a value may be written in the current position, and
+// writes the separator that precedes it, if any. If a value may not be written,
+// the builder's error is set and false is returned.
+func (b *JSONBuilder) beginValue() bool {
+	if b.err != nil {
+		return false
+	}
+	top := b.top()
+	switch {
+	case top == nil && b.done:
+		b.err = errors.New("types.JSONBuilder: cannot write past the end of the document")
+	case top == nil:
+		return true
+	case top.delim == '{' && !top.keyed:
+		b.err = errors.New("types.JSONBuilder: cannot write a value in an object without a key")
+	case top.delim == '{':
+		top.keyed = false
+		return true
+	default:
+		if top.n > 0 {
+			b.buf = append(b.buf, ',')
+		}
+		top.n++
+		return true
+	}
+	return false
+}
+
+// end closes the innermost open object or array, which must have been opened
+// with open.
+func (b *JSONBuilder) end(open, close byte) {
+	if b.err != nil {
+		return
+	}
+	top := b.top()
+	if top == nil || top.delim != open {
+		b.err = fmt.Errorf("types.JSONBuilder: cannot write '%c' without a matching '%c'", close, open)
+		return
+	}
+	if top.keyed {
+		b.err = errors.New("types.JSONBuilder: cannot close an object before the value of its last key")
+		return
+	}
+	b.stack = b.stack[:len(b.stack)-1]
+	b.buf = append(b.buf, close)
+	if len(b.stack) == 0 {
+		b.done = true
+	}
+}
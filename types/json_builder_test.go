@@ -0,0 +1,75 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/pyrrho/encoding/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONBuilderNested(t *testing.T) {
+	require := require.New(t)
+
+	j, err := new(types.JSONBuilder).
+		Object().
+		Field("id", 42).
+		Field("name", "Phobos").
+		Key("tags").Array().
+		Elem("moon").
+		Elem(nil).
+		Array().Elem(1.5).EndArray().
+		Object().Field("empty", map[string]int{}).EndObject().
+		EndArray().
+		Key("orbit").Object().
+		Field("parent", "Mars").
+		Field("raw", types.NewJSONStr(`{"a":[true]}`)).
+		EndObject().
+		EndObject().
+		JSON()
+	require.NoError(err)
+	require.Equal(
+		`{"id":42,"name":"Phobos","tags":["moon",null,[1.5],{"empty":{}}],"orbit":{"parent":"Mars","raw":{"a":[true]}}}`,
+		string(j))
+	require.True(j.Valid())
+
+	j, err = new(types.JSONBuilder).Array().EndArray().JSON()
+	require.NoError(err)
+	require.Equal(`[]`, string(j))
+}
+
+func TestJSONBuilderErrors(t *testing.T) {
+	require := require.New(t)
+
+	// Nothing written.
+	_, err := new(types.JSONBuilder).JSON()
+	require.Error(err)
+	// Left open.
+	_, err = new(types.JSONBuilder).Object().Key("a").Array().JSON()
+	require.Error(err)
+	// Fields outside of objects, and elements outside of arrays.
+	_, err = new(types.JSONBuilder).Field("a", 1).JSON()
+	require.Error(err)
+	_, err = new(types.JSONBuilder).Array().Field("a", 1).EndArray().JSON()
+	require.Error(err)
+	_, err = new(types.JSONBuilder).Object().Elem(1).EndObject().JSON()
+	require.Error(err)
+	// Nested values in objects without keys.
+	_, err = new(types.JSONBuilder).Object().Object().EndObject().EndObject().JSON()
+	require.Error(err)
+	// Keys without values.
+	_, err = new(types.JSONBuilder).Object().Key("a").EndObject().JSON()
+	require.Error(err)
+	_, err = new(types.JSONBuilder).Object().Key("a").Key("b").JSON()
+	require.Error(err)
+	// Mismatched delimiters.
+	_, err = new(types.JSONBuilder).Object().EndArray().JSON()
+	require.Error(err)
+	_, err = new(types.JSONBuilder).EndObject().JSON()
+	require.Error(err)
+	// A second document.
+	_, err = new(types.JSONBuilder).Array().EndArray().Array().EndArray().JSON()
+	require.Error(err)
+	// Unencodable values.
+	_, err = new(types.JSONBuilder).Array().Elem(make(chan int)).EndArray().JSON()
+	require.Error(err)
+}
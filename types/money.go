@@ -0,0 +1,270 @@
+package types
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Money is an amount of a single currency. The amount is stored as an integer
+// number of the currency's minor units (eg. cents for USD), avoiding the
+// rounding errors of floating point representations. The currency is stored as
+// an ISO 4217 alphabetic code (eg. "USD").
+//
+// Money implements all of the pyrrho/encoding/types interfaces detailed in the
+// package comments. Database interactions (Value and Scan) use a single text
+// column holding the minor-unit amount and currency code separated by a space;
+// $12.34 is stored as "1234 USD". JSON interactions (MarshalJSON and
+// UnmarshalJSON) use an object holding the amount as a decimal string in major
+// units, and the currency code; $12.34 is encoded as
+// {"amount":"12.34","currency":"USD"}. Decimal strings are used rather than
+// JSON numbers to avoid float conversions in JSON decoders.
+//
+// Arithmetic is only defined between Money values of the same currency; mixing
+// currencies will result in an error.
+type Money struct {
+	Amount   int64
+	Currency string
+}
+
+// minorUnitExponents lists the ISO 4217 currencies with a number of minor
+// units other than two.
+var minorUnitExponents = map[string]int{
+	"BHD": 3, "BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "IQD": 3, "ISK": 0,
+	"JOD": 3, "JPY": 0, "KMF": 0, "KRW": 0, "KWD": 3, "LYD": 3, "OMR": 3,
+	"PYG": 0, "RWF": 0, "TND": 3, "UGX": 0, "UYI": 0, "VND": 0, "VUV": 0,
+	"XAF": 0, "XOF": 0, "XPF": 0,
+}
+
+// minorUnitExponent returns the number of decimal digits used by the minor
+// unit of the given currency.
+func minorUnitExponent(currency string) int {
+	if e, ok := minorUnitExponents[currency]; ok {
+		return e
+	}
+	return 2
+}
+
+// Constructors
+
+// NewMoney constructs and returns a new Money of the given amount of minor
+// units in the given currency.
+func NewMoney(amount int64, currency string) Money {
+	return Money{
+		Amount:   amount,
+		Currency: currency,
+	}
+}
+
+// ParseMoney parses the decimal string amount -- in major units, eg. "12.34"
+// -- and returns a new Money of that amount in the given currency. An error
+// will be returned if the currency is not a three letter code, if amount is not
+// a decimal number, or if amount has more fractional digits than the
+// currency's minor unit allows.
+func ParseMoney(amount string, currency string) (Money, error) {
+	if err := validateCurrency(currency); err != nil {
+		return Money{}, err
+	}
+	a, err := parseMinorUnits(amount, minorUnitExponent(currency))
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{
+		Amount:   a,
+		Currency: currency,
+	}, nil
+}
+
+func validateCurrency(currency string) error {
+	if len(currency) != 3 {
+		return fmt.Errorf("types.Money: invalid currency code %q", currency)
+	}
+	for _, r := range currency {
+		if r < 'A' || r > 'Z' {
+			return fmt.Errorf("types.Money: invalid currency code %q", currency)
+		}
+	}
+	return nil
+}
+
+func parseMinorUnits(s string, exp int) (int64, error) {
+	whole, frac := s, ""
+	if idx := strings.IndexByte(s, '.'); idx >= 0 {
+		whole, frac = s[:idx], s[idx+1:]
+		if len(frac) == 0 {
+			return 0, fmt.Errorf("types.Money: invalid amount %q", s)
+		}
+	}
+	if len(frac) > exp {
+		return 0, fmt.Errorf("types.Money: amount %q has more than %d fractional digits", s, exp)
+	}
+	for _, r := range frac {
+		if r < '0' || r > '9' {
+			return 0, fmt.Errorf("types.Money: invalid amount %q", s)
+		}
+	}
+	frac += strings.Repeat("0", exp-len(frac))
+	if whole == "" || whole == "-" || whole == "+" {
+		return 0, fmt.Errorf("types.Money: invalid amount %q", s)
+	}
+	a, err := strconv.ParseInt(whole+frac, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("types.Money: invalid amount %q: %v", s, err)
+	}
+	return a, nil
+}
+
+// Getters
+
+// Major returns the amount of m as a decimal string in major units, eg. "12.34"
+// for 1234 minor units of USD.
+func (m Money) Major() string {
+	exp := minorUnitExponent(m.Currency)
+	s := strconv.FormatInt(m.Amount, 10)
+	if exp == 0 {
+		return s
+	}
+	neg := ""
+	if m.Amount < 0 {
+		neg, s = "-", s[1:]
+	}
+	if len(s) <= exp {
+		s = strings.Repeat("0", exp-len(s)+1) + s
+	}
+	return neg + s[:len(s)-exp] + "." + s[len(s)-exp:]
+}
+
+// String returns m formatted as its major unit amount followed by its currency
+// code, eg. "12.34 USD".
+func (m Money) String() string {
+	return m.Major() + " " + m.Currency
+}
+
+// Arithmetic
+
+// Add returns the sum of m and o. An error will be returned if m and o are of
+// different currencies, or if the sum would overflow.
+func (m Money) Add(o Money) (Money, error) {
+	if m.Currency != o.Currency {
+		return Money{}, fmt.Errorf("types.Money: cannot add %s to %s; mismatched currencies", o.Currency, m.Currency)
+	}
+	if (o.Amount > 0 && m.Amount > math.MaxInt64-o.Amount) ||
+		(o.Amount < 0 && m.Amount < math.MinInt64-o.Amount) {
+		return Money{}, fmt.Errorf("types.Money: overflow adding %v to %v", o, m)
+	}
+	return Money{m.Amount + o.Amount, m.Currency}, nil
+}
+
+// Sub returns the difference of m and o. An error will be returned if m and o
+// are of different currencies, or if the difference would overflow.
+func (m Money) Sub(o Money) (Money, error) {
+	if m.Currency != o.Currency {
+		return Money{}, fmt.Errorf("types.Money: cannot subtract %s from %s; mismatched currencies", o.Currency, m.Currency)
+	}
+	if (o.Amount < 0 && m.Amount > math.MaxInt64+o.Amount) ||
+		(o.Amount > 0 && m.Amount < math.MinInt64+o.Amount) {
+		return Money{}, fmt.Errorf("types.Money: overflow subtracting %v from %v", o, m)
+	}
+	return Money{m.Amount - o.Amount, m.Currency}, nil
+}
+
+// Interfaces
+
+// IsNil implements the pyrrho/encoding IsNiler interface. It will return true
+// if m has no currency, as is the case for a zero-initialized Money.
+func (m Money) IsNil() bool {
+	return m.Currency == ""
+}
+
+// IsZero implements the pyrrho/encoding IsZeroer interface. It will return true
+// if the amount of m is zero, regardless of currency.
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+// Value implements the database/sql/driver Valuer interface. It will return the
+// value of m as a driver.Value; specifically a string holding the minor-unit
+// amount and the currency code, eg. "1234 USD".
+func (m Money) Value() (driver.Value, error) {
+	if err := validateCurrency(m.Currency); err != nil {
+		return nil, err
+	}
+	return strconv.FormatInt(m.Amount, 10) + " " + m.Currency, nil
+}
+
+// Scan implements the database/sql Scanner interface. It expects to receive a
+// string or []byte holding a minor-unit amount and a currency code separated by
+// a space, eg. "1234 USD", and will assign that value to m.
+func (m *Money) Scan(src interface{}) error {
+	if m == nil {
+		return fmt.Errorf("types.Money: Scan called on nil pointer")
+	}
+	var s string
+	switch x := src.(type) {
+	case string:
+		s = x
+	case []byte:
+		s = string(x)
+	default:
+		return fmt.Errorf("types.Money: cannot scan type %T (%v)", src, src)
+	}
+	parts := strings.Split(s, " ")
+	if len(parts) != 2 {
+		return fmt.Errorf("types.Money: cannot scan %q; expected an amount and currency", s)
+	}
+	if err := validateCurrency(parts[1]); err != nil {
+		return err
+	}
+	a, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("types.Money: cannot scan %q: %v", s, err)
+	}
+	m.Amount = a
+	m.Currency = parts[1]
+	return nil
+}
+
+type moneyJSON struct {
+	Amount   string `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// MarshalJSON implements the encoding/json Marshaler interface. It will return
+// m encoded as an object holding the major unit amount as a decimal string and
+// the currency code, eg. {"amount":"12.34","currency":"USD"}.
+func (m Money) MarshalJSON() ([]byte, error) {
+	if err := validateCurrency(m.Currency); err != nil {
+		return nil, err
+	}
+	return json.Marshal(moneyJSON{m.Major(), m.Currency})
+}
+
+// UnmarshalJSON implements the encoding/json Unmarshaler interface. It expects
+// to receive an object of the form {"amount":"12.34","currency":"USD"}, and
+// will assign that value to m.
+//
+// If the decode fails, the value of m will be unchanged.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	if m == nil {
+		return fmt.Errorf("types.Money: UnmarshalJSON called on nil pointer")
+	}
+	var tmp moneyJSON
+	if err := json.Unmarshal(data, &tmp); err != nil {
+		return err
+	}
+	parsed, err := ParseMoney(tmp.Amount, tmp.Currency)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// MarshalMapValue implements the pyrrho/encoding/maps Marshaler interface. It
+// will return m wrapped in an interface{} for use in a map[string]interface{}.
+func (m Money) MarshalMapValue() (interface{}, error) {
+	return m, nil
+}
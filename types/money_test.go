@@ -0,0 +1,183 @@
+package types_test
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/pyrrho/encoding/maps"
+	"github.com/pyrrho/encoding/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMoneyCtors(t *testing.T) {
+	require := require.New(t)
+
+	m := types.NewMoney(1234, "USD")
+	require.Equal(int64(1234), m.Amount)
+	require.Equal("USD", m.Currency)
+
+	p, err := types.ParseMoney("12.34", "USD")
+	require.NoError(err)
+	require.Equal(m, p)
+
+	p, err = types.ParseMoney("12.3", "USD")
+	require.NoError(err)
+	require.Equal(types.NewMoney(1230, "USD"), p)
+
+	p, err = types.ParseMoney("-0.05", "USD")
+	require.NoError(err)
+	require.Equal(types.NewMoney(-5, "USD"), p)
+
+	p, err = types.ParseMoney("1234", "JPY")
+	require.NoError(err)
+	require.Equal(types.NewMoney(1234, "JPY"), p)
+
+	p, err = types.ParseMoney("1.234", "KWD")
+	require.NoError(err)
+	require.Equal(types.NewMoney(1234, "KWD"), p)
+
+	// Too much precision for the currency.
+	_, err = types.ParseMoney("12.345", "USD")
+	require.Error(err)
+	_, err = types.ParseMoney("12.3", "JPY")
+	require.Error(err)
+
+	// Malformed amounts and currencies.
+	for _, bad := range []string{"", ".", "12.", ".34", "-", "1.2.3", "1,234", "abc", "1.x"} {
+		_, err = types.ParseMoney(bad, "USD")
+		require.Error(err, bad)
+	}
+	_, err = types.ParseMoney("12.34", "usd")
+	require.Error(err)
+	_, err = types.ParseMoney("12.34", "DOLLARS")
+	require.Error(err)
+}
+
+func TestMoneyFormatting(t *testing.T) {
+	require := require.New(t)
+
+	require.Equal("12.34 USD", types.NewMoney(1234, "USD").String())
+	require.Equal("0.05 USD", types.NewMoney(5, "USD").String())
+	require.Equal("-0.05 USD", types.NewMoney(-5, "USD").String())
+	require.Equal("-12.00 EUR", types.NewMoney(-1200, "EUR").String())
+	require.Equal("0.00 USD", types.NewMoney(0, "USD").String())
+	require.Equal("1234 JPY", types.NewMoney(1234, "JPY").String())
+	require.Equal("0.001 BHD", types.NewMoney(1, "BHD").String())
+}
+
+func TestMoneyArithmetic(t *testing.T) {
+	require := require.New(t)
+
+	sum, err := types.NewMoney(1234, "USD").Add(types.NewMoney(66, "USD"))
+	require.NoError(err)
+	require.Equal(types.NewMoney(1300, "USD"), sum)
+
+	diff, err := types.NewMoney(1234, "USD").Sub(types.NewMoney(1300, "USD"))
+	require.NoError(err)
+	require.Equal(types.NewMoney(-66, "USD"), diff)
+
+	_, err = types.NewMoney(1234, "USD").Add(types.NewMoney(1234, "EUR"))
+	require.Error(err)
+	require.Contains(err.Error(), "mismatched currencies")
+	_, err = types.NewMoney(1234, "USD").Sub(types.NewMoney(1234, "EUR"))
+	require.Error(err)
+	require.Contains(err.Error(), "mismatched currencies")
+
+	_, err = types.NewMoney(math.MaxInt64, "USD").Add(types.NewMoney(1, "USD"))
+	require.Error(err)
+	_, err = types.NewMoney(math.MinInt64, "USD").Sub(types.NewMoney(1, "USD"))
+	require.Error(err)
+}
+
+func TestMoneyIsNilIsZero(t *testing.T) {
+	require := require.New(t)
+
+	require.True(types.Money{}.IsNil())
+	require.True(types.Money{}.IsZero())
+	require.False(types.NewMoney(0, "USD").IsNil())
+	require.True(types.NewMoney(0, "USD").IsZero())
+	require.False(types.NewMoney(1234, "USD").IsZero())
+}
+
+func TestMoneySQLValue(t *testing.T) {
+	require := require.New(t)
+	var val driver.Value
+	var err error
+
+	val, err = types.NewMoney(1234, "USD").Value()
+	require.NoError(err)
+	require.Equal("1234 USD", val)
+
+	val, err = types.NewMoney(-5, "JPY").Value()
+	require.NoError(err)
+	require.Equal("-5 JPY", val)
+
+	_, err = types.Money{}.Value()
+	require.Error(err)
+}
+
+func TestMoneySQLScan(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	var m types.Money
+	err = m.Scan("1234 USD")
+	require.NoError(err)
+	require.Equal(types.NewMoney(1234, "USD"), m)
+
+	err = m.Scan([]byte("-5 JPY"))
+	require.NoError(err)
+	require.Equal(types.NewMoney(-5, "JPY"), m)
+
+	for _, bad := range []interface{}{nil, 1234, "1234", "12.34 USD", "1234 usd", "1234  USD"} {
+		err = m.Scan(bad)
+		require.Error(err)
+	}
+}
+
+func TestMoneyMarshalJSON(t *testing.T) {
+	require := require.New(t)
+	var data []byte
+	var err error
+
+	m := types.NewMoney(1234, "USD")
+	data, err = json.Marshal(m)
+	require.NoError(err)
+	require.EqualValues(`{"amount":"12.34","currency":"USD"}`, data)
+	data, err = json.Marshal(&m)
+	require.NoError(err)
+	require.EqualValues(`{"amount":"12.34","currency":"USD"}`, data)
+
+	_, err = json.Marshal(types.Money{})
+	require.Error(err)
+}
+
+func TestMoneyUnmarshalJSON(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	var m types.Money
+	err = json.Unmarshal([]byte(`{"amount":"12.34","currency":"USD"}`), &m)
+	require.NoError(err)
+	require.Equal(types.NewMoney(1234, "USD"), m)
+
+	// Failed decodes leave m unchanged.
+	err = json.Unmarshal([]byte(`{"amount":12.34,"currency":"USD"}`), &m)
+	require.Error(err)
+	err = json.Unmarshal([]byte(`{"amount":"12.345","currency":"USD"}`), &m)
+	require.Error(err)
+	err = json.Unmarshal([]byte(`{"amount":"12.34"}`), &m)
+	require.Error(err)
+	require.Equal(types.NewMoney(1234, "USD"), m)
+}
+
+func TestMoneyMarshalMapValue(t *testing.T) {
+	require := require.New(t)
+	type Wrapper struct{ Price types.Money }
+
+	data, err := maps.Marshal(Wrapper{types.NewMoney(1234, "USD")})
+	require.NoError(err)
+	require.Equal(map[string]interface{}{"Price": types.NewMoney(1234, "USD")}, data)
+}
@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"strconv"
 )
 
 // Bool is a wrapper around the database/sql NullBool type that implements all
@@ -74,6 +75,30 @@ func (b Bool) IsZero() bool {
 	return !b.Valid || !b.Bool
 }
 
+// Scan implements the database/sql Scanner interface. It defers to
+// sql.NullBool's Scan, but additionally accepts the single byte []byte some
+// databases return for BIT columns; 0x00 scans as false, and any other byte as
+// true. Single bytes that sql.NullBool can parse -- eg. '0', '1', 't', or 'f'
+// -- are scanned as sql.NullBool would.
+//
+// If the scan fails, the value of b will be unchanged.
+func (b *Bool) Scan(src interface{}) error {
+	if b == nil {
+		return fmt.Errorf("null.Bool: Scan called on nil pointer")
+	}
+	var tmp sql.NullBool
+	err := tmp.Scan(src)
+	if err != nil {
+		bit, ok := src.([]byte)
+		if !ok || len(bit) != 1 {
+			return err
+		}
+		tmp = sql.NullBool{Bool: bit[0] != 0x00, Valid: true}
+	}
+	b.NullBool = tmp
+	return nil
+}
+
 // MarshalJSON implements the encoding/json Marshaler interface. It will encode
 // b into its JSON representation if valid, or 'null' otherwise.
 func (b Bool) MarshalJSON() ([]byte, error) {
@@ -119,6 +144,36 @@ func (b *Bool) UnmarshalJSON(data []byte) error {
 	}
 }
 
+// MarshalText implements the encoding TextMarshaler interface. It will encode
+// b as "true" or "false" if valid, or an empty []byte otherwise.
+func (b Bool) MarshalText() ([]byte, error) {
+	if !b.Valid {
+		return []byte{}, nil
+	}
+	return []byte(strconv.FormatBool(b.Bool)), nil
+}
+
+// UnmarshalText implements the encoding TextUnmarshaler interface. It will
+// decode any of the values accepted by strconv.ParseBool -- "1", "t", "true",
+// "0", "f", "false", etc. -- into b. Empty text will decode into a null Bool.
+//
+// If the decode fails, the value of b will be unchanged.
+func (b *Bool) UnmarshalText(text []byte) error {
+	if b == nil {
+		return fmt.Errorf("null.Bool: UnmarshalText called on nil pointer")
+	}
+	if len(text) == 0 {
+		b.Null()
+		return nil
+	}
+	v, err := strconv.ParseBool(string(text))
+	if err != nil {
+		return err
+	}
+	b.Set(v)
+	return nil
+}
+
 // MarshalMapValue implements the pyrrho/encoding/maps Marshaler interface. It
 // will encode b into its interface{} representation for use in a
 // map[string]interface{} if valid, or return nil otherwise.
@@ -135,6 +135,34 @@ func TestBoolSQLScan(t *testing.T) {
 	var wrong null.Bool
 	err = wrong.Scan(int64(42))
 	require.Error(err)
+
+	// BIT columns may be returned as a single byte.
+	var bitFalse null.Bool
+	err = bitFalse.Scan([]byte{0x00})
+	require.NoError(err)
+	require.Equal(null.NewBool(false), bitFalse)
+
+	var bitTrue null.Bool
+	err = bitTrue.Scan([]byte{0x01})
+	require.NoError(err)
+	require.Equal(null.NewBool(true), bitTrue)
+
+	// ... but single byte text is still parsed as text.
+	var text null.Bool
+	err = text.Scan([]byte("0"))
+	require.NoError(err)
+	require.Equal(null.NewBool(false), text)
+	err = text.Scan([]byte("t"))
+	require.NoError(err)
+	require.Equal(null.NewBool(true), text)
+
+	// Failed scans leave the Bool unchanged.
+	err = text.Scan([]byte{0x00, 0x01})
+	require.Error(err)
+	require.Equal(null.NewBool(true), text)
+	err = text.Scan("x")
+	require.Error(err)
+	require.Equal(null.NewBool(true), text)
 }
 
 func TestBoolMarshalJSON(t *testing.T) {
@@ -235,6 +263,49 @@ func TestBoolUnmarshalJSON(t *testing.T) {
 	}
 }
 
+func TestBoolMarshalText(t *testing.T) {
+	require := require.New(t)
+
+	data, err := null.NewBool(true).MarshalText()
+	require.NoError(err)
+	require.EqualValues("true", data)
+
+	data, err = null.NewBool(false).MarshalText()
+	require.NoError(err)
+	require.EqualValues("false", data)
+
+	// Null values encode to empty text, distinct from the text of false.
+	data, err = null.Bool{}.MarshalText()
+	require.NoError(err)
+	require.NotNil(data)
+	require.Len(data, 0)
+}
+
+func TestBoolUnmarshalText(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	var b null.Bool
+	err = b.UnmarshalText([]byte("true"))
+	require.NoError(err)
+	require.Equal(null.NewBool(true), b)
+	err = b.UnmarshalText([]byte("0"))
+	require.NoError(err)
+	require.Equal(null.NewBool(false), b)
+
+	// Empty text decodes to null.
+	err = b.UnmarshalText([]byte(""))
+	require.NoError(err)
+	require.False(b.Valid)
+
+	b = null.NewBool(true)
+	err = b.UnmarshalText([]byte("yes"))
+	require.Error(err)
+	err = b.UnmarshalText([]byte("null"))
+	require.Error(err)
+	require.Equal(null.NewBool(true), b)
+}
+
 func TestBoolMarshalMapValue(t *testing.T) {
 	require := require.New(t)
 	type Wrapper struct{ Bool null.Bool }
@@ -0,0 +1,530 @@
+//go:build bson
+// +build bson
+
+package null
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+// This file implements the go.mongodb.org/mongo-driver/bson ValueMarshaler and
+// ValueUnmarshaler interfaces for the numeric, string, bool, time, and byte
+// slice null types. Null values are stored as BSON null, and valid values are
+// stored as their native BSON type.
+//
+// These methods depend on the mongo driver, and are only built with the bson
+// build tag. They are tested against go.mongodb.org/mongo-driver v1.17.6, the
+// version pinned by .github/workflows/test.yml;
+//
+//	go get go.mongodb.org/mongo-driver@v1.17.6
+//	go test -tags bson ./types/null
+
+// MarshalBSONValue implements the mongo-driver/bson ValueMarshaler interface.
+// It will encode i as a BSON int64 if valid, or null otherwise.
+func (i Int) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	if !i.Valid {
+		return bsontype.Null, nil, nil
+	}
+	return bsontype.Int64, bsoncore.AppendInt64(nil, int64(i.Int)), nil
+}
+
+// UnmarshalBSONValue implements the mongo-driver/bson ValueUnmarshaler
+// interface. It will decode a BSON int32 or int64 into i, or BSON null into a
+// null Int.
+//
+// If the decode fails, the value of i will be unchanged.
+func (i *Int) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	v, null, err := readBSONInt("null.Int", t, data, math.MinInt, math.MaxInt)
+	if err != nil {
+		return err
+	}
+	if null {
+		i.Null()
+		return nil
+	}
+	i.Set(int(v))
+	return nil
+}
+
+// MarshalBSONValue implements the mongo-driver/bson ValueMarshaler interface.
+// It will encode i as a BSON int32 if valid, or null otherwise.
+func (i Int8) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	if !i.Valid {
+		return bsontype.Null, nil, nil
+	}
+	return bsontype.Int32, bsoncore.AppendInt32(nil, int32(i.Int8)), nil
+}
+
+// UnmarshalBSONValue implements the mongo-driver/bson ValueUnmarshaler
+// interface. It will decode a BSON int32 or int64 into i, or BSON null into a
+// null Int8.
+//
+// If the decode fails, the value of i will be unchanged.
+func (i *Int8) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	v, null, err := readBSONInt("null.Int8", t, data, math.MinInt8, math.MaxInt8)
+	if err != nil {
+		return err
+	}
+	if null {
+		i.Null()
+		return nil
+	}
+	i.Set(int8(v))
+	return nil
+}
+
+// MarshalBSONValue implements the mongo-driver/bson ValueMarshaler interface.
+// It will encode i as a BSON int32 if valid, or null otherwise.
+func (i Int16) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	if !i.Valid {
+		return bsontype.Null, nil, nil
+	}
+	return bsontype.Int32, bsoncore.AppendInt32(nil, int32(i.Int16)), nil
+}
+
+// UnmarshalBSONValue implements the mongo-driver/bson ValueUnmarshaler
+// interface. It will decode a BSON int32 or int64 into i, or BSON null into a
+// null Int16.
+//
+// If the decode fails, the value of i will be unchanged.
+func (i *Int16) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	v, null, err := readBSONInt("null.Int16", t, data, math.MinInt16, math.MaxInt16)
+	if err != nil {
+		return err
+	}
+	if null {
+		i.Null()
+		return nil
+	}
+	i.Set(int16(v))
+	return nil
+}
+
+// MarshalBSONValue implements the mongo-driver/bson ValueMarshaler interface.
+// It will encode i as a BSON int32 if valid, or null otherwise.
+func (i Int32) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	if !i.Valid {
+		return bsontype.Null, nil, nil
+	}
+	return bsontype.Int32, bsoncore.AppendInt32(nil, i.Int32), nil
+}
+
+// UnmarshalBSONValue implements the mongo-driver/bson ValueUnmarshaler
+// interface. It will decode a BSON int32 or int64 into i, or BSON null into a
+// null Int32.
+//
+// If the decode fails, the value of i will be unchanged.
+func (i *Int32) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	v, null, err := readBSONInt("null.Int32", t, data, math.MinInt32, math.MaxInt32)
+	if err != nil {
+		return err
+	}
+	if null {
+		i.Null()
+		return nil
+	}
+	i.Set(int32(v))
+	return nil
+}
+
+// MarshalBSONValue implements the mongo-driver/bson ValueMarshaler interface.
+// It will encode i as a BSON int64 if valid, or null otherwise.
+func (i Int64) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	if !i.Valid {
+		return bsontype.Null, nil, nil
+	}
+	return bsontype.Int64, bsoncore.AppendInt64(nil, i.Int64), nil
+}
+
+// UnmarshalBSONValue implements the mongo-driver/bson ValueUnmarshaler
+// interface. It will decode a BSON int32 or int64 into i, or BSON null into a
+// null Int64.
+//
+// If the decode fails, the value of i will be unchanged.
+func (i *Int64) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	v, null, err := readBSONInt("null.Int64", t, data, math.MinInt64, math.MaxInt64)
+	if err != nil {
+		return err
+	}
+	if null {
+		i.Null()
+		return nil
+	}
+	i.Set(v)
+	return nil
+}
+
+// MarshalBSONValue implements the mongo-driver/bson ValueMarshaler interface.
+// It will encode i as a BSON int64 if valid, or null otherwise. BSON has no
+// unsigned integers, so values greater than math.MaxInt64 cannot be encoded,
+// and will result in an error.
+func (i Uint) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	if !i.Valid {
+		return bsontype.Null, nil, nil
+	}
+	return marshalBSONUint("null.Uint", uint64(i.Uint))
+}
+
+// UnmarshalBSONValue implements the mongo-driver/bson ValueUnmarshaler
+// interface. It will decode a non-negative BSON int32 or int64 into i, or BSON
+// null into a null Uint.
+//
+// If the decode fails, the value of i will be unchanged.
+func (i *Uint) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	v, null, err := readBSONInt("null.Uint", t, data, 0, math.MaxInt64)
+	if err != nil {
+		return err
+	}
+	if null {
+		i.Null()
+		return nil
+	}
+	i.Set(uint(v))
+	return nil
+}
+
+// MarshalBSONValue implements the mongo-driver/bson ValueMarshaler interface.
+// It will encode i as a BSON int32 if valid, or null otherwise.
+func (i Uint8) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	if !i.Valid {
+		return bsontype.Null, nil, nil
+	}
+	return bsontype.Int32, bsoncore.AppendInt32(nil, int32(i.Uint8)), nil
+}
+
+// UnmarshalBSONValue implements the mongo-driver/bson ValueUnmarshaler
+// interface. It will decode a BSON int32 or int64 into i, or BSON null into a
+// null Uint8.
+//
+// If the decode fails, the value of i will be unchanged.
+func (i *Uint8) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	v, null, err := readBSONInt("null.Uint8", t, data, 0, math.MaxUint8)
+	if err != nil {
+		return err
+	}
+	if null {
+		i.Null()
+		return nil
+	}
+	i.Set(uint8(v))
+	return nil
+}
+
+// MarshalBSONValue implements the mongo-driver/bson ValueMarshaler interface.
+// It will encode i as a BSON int32 if valid, or null otherwise.
+func (i Uint16) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	if !i.Valid {
+		return bsontype.Null, nil, nil
+	}
+	return bsontype.Int32, bsoncore.AppendInt32(nil, int32(i.Uint16)), nil
+}
+
+// UnmarshalBSONValue implements the mongo-driver/bson ValueUnmarshaler
+// interface. It will decode a BSON int32 or int64 into i, or BSON null into a
+// null Uint16.
+//
+// If the decode fails, the value of i will be unchanged.
+func (i *Uint16) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	v, null, err := readBSONInt("null.Uint16", t, data, 0, math.MaxUint16)
+	if err != nil {
+		return err
+	}
+	if null {
+		i.Null()
+		return nil
+	}
+	i.Set(uint16(v))
+	return nil
+}
+
+// MarshalBSONValue implements the mongo-driver/bson ValueMarshaler interface.
+// It will encode i as a BSON int64 if valid, or null otherwise; an int32 cannot
+// hold every uint32.
+func (i Uint32) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	if !i.Valid {
+		return bsontype.Null, nil, nil
+	}
+	return bsontype.Int64, bsoncore.AppendInt64(nil, int64(i.Uint32)), nil
+}
+
+// UnmarshalBSONValue implements the mongo-driver/bson ValueUnmarshaler
+// interface. It will decode a BSON int32 or int64 into i, or BSON null into a
+// null Uint32.
+//
+// If the decode fails, the value of i will be unchanged.
+func (i *Uint32) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	v, null, err := readBSONInt("null.Uint32", t, data, 0, math.MaxUint32)
+	if err != nil {
+		return err
+	}
+	if null {
+		i.Null()
+		return nil
+	}
+	i.Set(uint32(v))
+	return nil
+}
+
+// MarshalBSONValue implements the mongo-driver/bson ValueMarshaler interface.
+// It will encode i as a BSON int64 if valid, or null otherwise. BSON has no
+// unsigned integers, so values greater than math.MaxInt64 cannot be encoded,
+// and will result in an error.
+func (i Uint64) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	if !i.Valid {
+		return bsontype.Null, nil, nil
+	}
+	return marshalBSONUint("null.Uint64", i.Uint64)
+}
+
+// UnmarshalBSONValue implements the mongo-driver/bson ValueUnmarshaler
+// interface. It will decode a non-negative BSON int32 or int64 into i, or BSON
+// null into a null Uint64.
+//
+// If the decode fails, the value of i will be unchanged.
+func (i *Uint64) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	v, null, err := readBSONInt("null.Uint64", t, data, 0, math.MaxInt64)
+	if err != nil {
+		return err
+	}
+	if null {
+		i.Null()
+		return nil
+	}
+	i.Set(uint64(v))
+	return nil
+}
+
+// MarshalBSONValue implements the mongo-driver/bson ValueMarshaler interface.
+// It will encode f as a BSON double if valid, or null otherwise.
+func (f Float32) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	if !f.Valid {
+		return bsontype.Null, nil, nil
+	}
+	return bsontype.Double, bsoncore.AppendDouble(nil, float64(f.Float32)), nil
+}
+
+// UnmarshalBSONValue implements the mongo-driver/bson ValueUnmarshaler
+// interface. It will decode a BSON double, int32, or int64 into f, or BSON null
+// into a null Float32.
+//
+// If the decode fails, the value of f will be unchanged.
+func (f *Float32) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	v, null, err := readBSONFloat("null.Float32", t, data)
+	if err != nil {
+		return err
+	}
+	if null {
+		f.Null()
+		return nil
+	}
+	f.Set(float32(v))
+	return nil
+}
+
+// MarshalBSONValue implements the mongo-driver/bson ValueMarshaler interface.
+// It will encode f as a BSON double if valid, or null otherwise.
+func (f Float64) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	if !f.Valid {
+		return bsontype.Null, nil, nil
+	}
+	return bsontype.Double, bsoncore.AppendDouble(nil, f.Float64), nil
+}
+
+// UnmarshalBSONValue implements the mongo-driver/bson ValueUnmarshaler
+// interface. It will decode a BSON double, int32, or int64 into f, or BSON null
+// into a null Float64.
+//
+// If the decode fails, the value of f will be unchanged.
+func (f *Float64) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	v, null, err := readBSONFloat("null.Float64", t, data)
+	if err != nil {
+		return err
+	}
+	if null {
+		f.Null()
+		return nil
+	}
+	f.Set(v)
+	return nil
+}
+
+// MarshalBSONValue implements the mongo-driver/bson ValueMarshaler interface.
+// It will encode s as a BSON string if valid, or null otherwise.
+func (s String) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	if !s.Valid {
+		return bsontype.Null, nil, nil
+	}
+	return bsontype.String, bsoncore.AppendString(nil, s.String), nil
+}
+
+// UnmarshalBSONValue implements the mongo-driver/bson ValueUnmarshaler
+// interface. It will decode a BSON string into s, or BSON null into a null
+// String.
+//
+// If the decode fails, the value of s will be unchanged.
+func (s *String) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	switch t {
+	case bsontype.Null:
+		s.Null()
+		return nil
+	case bsontype.String:
+		v, _, ok := bsoncore.ReadString(data)
+		if !ok {
+			return fmt.Errorf("null.String: malformed BSON string")
+		}
+		s.Set(v)
+		return nil
+	default:
+		return fmt.Errorf("null.String: cannot unmarshal BSON type %s", t)
+	}
+}
+
+// MarshalBSONValue implements the mongo-driver/bson ValueMarshaler interface.
+// It will encode b as a BSON boolean if valid, or null otherwise.
+func (b Bool) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	if !b.Valid {
+		return bsontype.Null, nil, nil
+	}
+	return bsontype.Boolean, bsoncore.AppendBoolean(nil, b.Bool), nil
+}
+
+// UnmarshalBSONValue implements the mongo-driver/bson ValueUnmarshaler
+// interface. It will decode a BSON boolean into b, or BSON null into a null
+// Bool.
+//
+// If the decode fails, the value of b will be unchanged.
+func (b *Bool) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	switch t {
+	case bsontype.Null:
+		b.Null()
+		return nil
+	case bsontype.Boolean:
+		v, _, ok := bsoncore.ReadBoolean(data)
+		if !ok {
+			return fmt.Errorf("null.Bool: malformed BSON boolean")
+		}
+		b.Set(v)
+		return nil
+	default:
+		return fmt.Errorf("null.Bool: cannot unmarshal BSON type %s", t)
+	}
+}
+
+// MarshalBSONValue implements the mongo-driver/bson ValueMarshaler interface.
+// It will encode t as a BSON datetime if valid, or null otherwise. BSON
+// datetimes hold milliseconds since the Unix epoch, so t will be truncated to
+// millisecond precision, and its location will be lost.
+func (t Time) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	if !t.Valid {
+		return bsontype.Null, nil, nil
+	}
+	ms := t.Time.Unix()*1e3 + int64(t.Time.Nanosecond())/1e6
+	return bsontype.DateTime, bsoncore.AppendDateTime(nil, ms), nil
+}
+
+// UnmarshalBSONValue implements the mongo-driver/bson ValueUnmarshaler
+// interface. It will decode a BSON datetime into t, as a UTC time, or BSON null
+// into a null Time.
+//
+// If the decode fails, the value of t will be unchanged.
+func (t *Time) UnmarshalBSONValue(typ bsontype.Type, data []byte) error {
+	switch typ {
+	case bsontype.Null:
+		t.Null()
+		return nil
+	case bsontype.DateTime:
+		ms, _, ok := bsoncore.ReadDateTime(data)
+		if !ok {
+			return fmt.Errorf("null.Time: malformed BSON datetime")
+		}
+		t.Set(time.Unix(ms/1e3, ms%1e3*1e6).UTC())
+		return nil
+	default:
+		return fmt.Errorf("null.Time: cannot unmarshal BSON type %s", typ)
+	}
+}
+
+// MarshalBSONValue implements the mongo-driver/bson ValueMarshaler interface.
+// It will encode b as generic BSON binary data if valid, or null otherwise.
+func (b ByteSlice) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	if !b.Valid {
+		return bsontype.Null, nil, nil
+	}
+	return bsontype.Binary, bsoncore.AppendBinary(nil, 0x00, b.ByteSlice), nil
+}
+
+// UnmarshalBSONValue implements the mongo-driver/bson ValueUnmarshaler
+// interface. It will decode BSON binary data of any subtype into b, or BSON
+// null into a null ByteSlice.
+//
+// If the decode fails, the value of b will be unchanged.
+func (b *ByteSlice) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	switch t {
+	case bsontype.Null:
+		b.Null()
+		return nil
+	case bsontype.Binary:
+		_, v, _, ok := bsoncore.ReadBinary(data)
+		if !ok {
+			return fmt.Errorf("null.ByteSlice: malformed BSON binary")
+		}
+		b.Set(append([]byte{}, v...))
+		return nil
+	default:
+		return fmt.Errorf("null.ByteSlice: cannot unmarshal BSON type %s", t)
+	}
+}
+
+// marshalBSONUint encodes v as a BSON int64, or returns an error if v is too
+// large to be held by one. name is used to prefix any errors.
+func marshalBSONUint(name string, v uint64) (bsontype.Type, []byte, error) {
+	if v > math.MaxInt64 {
+		return 0, nil, fmt.Errorf("%s: %d overflows a BSON int64", name, v)
+	}
+	return bsontype.Int64, bsoncore.AppendInt64(nil, int64(v)), nil
+}
+
+// readBSONInt decodes the BSON int32 or int64 in data, and ensures it falls
+// within [min, max]. If t is BSON null, null will be true. name is used to
+// prefix any errors.
+func readBSONInt(name string, t bsontype.Type, data []byte, min, max int64) (v int64, null bool, err error) {
+	var ok bool
+	switch t {
+	case bsontype.Null:
+		return 0, true, nil
+	case bsontype.Int32:
+		var i32 int32
+		i32, _, ok = bsoncore.ReadInt32(data)
+		v = int64(i32)
+	case bsontype.Int64:
+		v, _, ok = bsoncore.ReadInt64(data)
+	default:
+		return 0, false, fmt.Errorf("%s: cannot unmarshal BSON type %s", name, t)
+	}
+	if !ok {
+		return 0, false, fmt.Errorf("%s: malformed BSON %s", name, t)
+	}
+	if v < min || v > max {
+		return 0, false, fmt.Errorf("%s: %d is out of range", name, v)
+	}
+	return v, false, nil
+}
+
+// readBSONFloat decodes the BSON double, int32, or int64 in data. If t is BSON
+// null, null will be true. name is used to prefix any errors.
+func readBSONFloat(name string, t bsontype.Type, data []byte) (v float64, null bool, err error) {
+	if t == bsontype.Double {
+		v, _, ok := bsoncore.ReadDouble(data)
+		if !ok {
+			return 0, false, fmt.Errorf("%s: malformed BSON %s", name, t)
+		}
+		return v, false, nil
+	}
+	i, null, err := readBSONInt(name, t, data, math.MinInt64, math.MaxInt64)
+	return float64(i), null, err
+}
@@ -0,0 +1,104 @@
+//go:build bson
+// +build bson
+
+package null_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/pyrrho/encoding/types/null"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type BSONDoc struct {
+	Int       null.Int       `bson:"int"`
+	Int8      null.Int8      `bson:"int8"`
+	Int64     null.Int64     `bson:"int64"`
+	Uint32    null.Uint32    `bson:"uint32"`
+	Uint64    null.Uint64    `bson:"uint64"`
+	Float32   null.Float32   `bson:"float32"`
+	Float64   null.Float64   `bson:"float64"`
+	String    null.String    `bson:"string"`
+	Bool      null.Bool      `bson:"bool"`
+	Time      null.Time      `bson:"time"`
+	ByteSlice null.ByteSlice `bson:"byte_slice"`
+}
+
+func TestBSONRoundTrip(t *testing.T) {
+	require := require.New(t)
+
+	valid := BSONDoc{
+		Int:       null.NewInt(-1),
+		Int8:      null.NewInt8(math.MinInt8),
+		Int64:     null.NewInt64(math.MaxInt64),
+		Uint32:    null.NewUint32(math.MaxUint32),
+		Uint64:    null.NewUint64(42),
+		Float32:   null.NewFloat32(1.5),
+		Float64:   null.NewFloat64(-0.25),
+		String:    null.NewString(""),
+		Bool:      null.NewBool(false),
+		Time:      null.NewTime(time.Date(2017, 4, 1, 12, 30, 0, 7e6, time.UTC)),
+		ByteSlice: null.NewByteSlice([]byte("hello")),
+	}
+	data, err := bson.Marshal(valid)
+	require.NoError(err)
+
+	// Valid values are stored as native BSON types.
+	var raw bson.M
+	require.NoError(bson.Unmarshal(data, &raw))
+	require.Equal(int64(-1), raw["int"])
+	require.Equal(int32(math.MinInt8), raw["int8"])
+	require.Equal(int64(math.MaxInt64), raw["int64"])
+	require.Equal(int64(math.MaxUint32), raw["uint32"])
+	require.Equal(int64(42), raw["uint64"])
+	require.Equal(1.5, raw["float32"])
+	require.Equal(-0.25, raw["float64"])
+	require.Equal("", raw["string"])
+	require.Equal(false, raw["bool"])
+	require.Equal(primitive.NewDateTimeFromTime(valid.Time.Time), raw["time"])
+	require.Equal(primitive.Binary{Data: []byte("hello")}, raw["byte_slice"])
+
+	var out BSONDoc
+	require.NoError(bson.Unmarshal(data, &out))
+	require.Equal(valid, out)
+
+	// Null values are stored as BSON null ...
+	data, err = bson.Marshal(BSONDoc{})
+	require.NoError(err)
+	raw = nil
+	require.NoError(bson.Unmarshal(data, &raw))
+	require.Len(raw, 11)
+	for k, v := range raw {
+		require.Nil(v, k)
+	}
+
+	// ... and unmarshal over valid values.
+	out = valid
+	require.NoError(bson.Unmarshal(data, &out))
+	require.Equal(BSONDoc{}, out)
+}
+
+func TestBSONErrors(t *testing.T) {
+	require := require.New(t)
+
+	_, err := bson.Marshal(BSONDoc{Uint64: null.NewUint64(math.MaxUint64)})
+	require.Error(err)
+
+	data, err := bson.Marshal(bson.M{"int8": 128})
+	require.NoError(err)
+	var out BSONDoc
+	require.Error(bson.Unmarshal(data, &out))
+
+	data, err = bson.Marshal(bson.M{"uint64": -1})
+	require.NoError(err)
+	require.Error(bson.Unmarshal(data, &out))
+
+	data, err = bson.Marshal(bson.M{"string": 42})
+	require.NoError(err)
+	require.Error(bson.Unmarshal(data, &out))
+	require.False(out.String.Valid)
+}
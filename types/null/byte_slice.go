@@ -1,12 +1,52 @@
 package null
 
 import (
+	"bytes"
 	"database/sql/driver"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"strings"
 )
 
+// ByteSliceMode enumerates the encodings ByteSlice can use for its contents;
+// see ByteSlice.Mode and ByteSliceEncoding.
+type ByteSliceMode int
+
+const (
+	// ByteSliceDefault selects the mode held by ByteSliceEncoding. It is the
+	// mode of a zero-initialized ByteSlice.
+	ByteSliceDefault ByteSliceMode = iota
+	// ByteSliceBase64 encodes contents as standard, padded base64. This
+	// matches encoding/json's treatment of []byte.
+	ByteSliceBase64
+	// ByteSliceHex encodes contents as lower-case hexadecimal; eg. "deadbeef".
+	ByteSliceHex
+	// ByteSlicePostgresHex encodes contents as lower-case hexadecimal with a
+	// `\x` prefix, the bytea hex format used by PostgreSQL; eg. `\xdeadbeef`.
+	ByteSlicePostgresHex
+	// ByteSliceRaw passes contents through Value and Scan verbatim, without
+	// encoding or decoding them. This is the mode to use for binary columns --
+	// eg. PostgreSQL's bytea, as handled by lib/pq and pgx -- whose drivers
+	// exchange raw bytes; base64 decoding such bytes in Scan would corrupt
+	// them. All other representations (MarshalJSON, UnmarshalJSON,
+	// MarshalMapValue, and String) use base64, as they do in ByteSliceBase64.
+	ByteSliceRaw
+)
+
+// ByteSliceEncoding is the mode used by each ByteSlice whose Mode is
+// ByteSliceDefault. It is ByteSliceBase64 unless set otherwise; setting it to
+// ByteSliceDefault is equivalent to ByteSliceBase64.
+//
+// As the right encoding usually depends on the column or API a value is bound
+// for, prefer setting the Mode of individual values -- eg. with NewRawByteSlice
+// -- over changing this default.
+//
+// This value is read at marshal- and scan-time, and should be set once during
+// program initialization.
+var ByteSliceEncoding = ByteSliceBase64
+
 // ByteSlice is a nullable wrapper around the []byte type. It implements all of
 // the pyrrho/encoding/types interfaces detailed in the package comments. This
 // type makes a distinction between nil and valid-but-empty []bytes. It is valid
@@ -17,10 +57,23 @@ import (
 // To maintain consistency with the encoding/json package -- and to ensure we
 // never attempt to marshal non-ASCII characters -- this type will emit base64
 // encoded strings from MarshalJSON, Value, and MarshalMapValue (when non-null),
-// and expect to receive base64 encoded strings in UnmarshalJSON and Scan.
+// and expect to receive base64 encoded strings in UnmarshalJSON and Scan. Hex
+// may be used instead, or, for database interactions, the raw bytes; see Mode.
 type ByteSlice struct {
 	ByteSlice []byte
 	Valid     bool
+	// Mode selects the encoding used by b's methods. The zero value,
+	// ByteSliceDefault, defers to ByteSliceEncoding. Mode is kept by Set,
+	// SetStr, Null, Scan, and the Unmarshal methods, so that a ByteSlice may
+	// be prepared to receive data in a given mode; eg.
+	//
+	//	data := null.NewRawByteSlice(nil)
+	//	err := row.Scan(&data)
+	//
+	// Mode is carried by GobEncode, but not by the text encodings. NB. Adding
+	// Mode broke unkeyed literals, eg. null.ByteSlice{b, true}; use keyed
+	// fields or the constructors instead.
+	Mode ByteSliceMode
 }
 
 // Constructors
@@ -91,6 +144,101 @@ func NewByteSliceFromBase64Str(s string) (ByteSlice, error) {
 
 }
 
+// NewByteSliceMode constructs and returns a new ByteSlice based on the given
+// []byte b, as NewByteSlice does, that will be encoded in mode m. If b is nil,
+// the new ByteSlice will be null, but will still hold m.
+func NewByteSliceMode(b []byte, m ByteSliceMode) ByteSlice {
+	ret := NewByteSlice(b)
+	ret.Mode = m
+	return ret
+}
+
+// NewRawByteSlice is NewByteSliceMode(b, ByteSliceRaw); Value and Scan will
+// pass the contents of the new ByteSlice through verbatim.
+func NewRawByteSlice(b []byte) ByteSlice {
+	return NewByteSliceMode(b, ByteSliceRaw)
+}
+
+// NewByteSliceHex is NewByteSliceMode(b, ByteSliceHex); the contents of the new
+// ByteSlice will be hex encoded.
+func NewByteSliceHex(b []byte) ByteSlice {
+	return NewByteSliceMode(b, ByteSliceHex)
+}
+
+// NewByteSliceFromHex constructs and returns a new, valid ByteSlice object
+// based on the given hex encoded string s, which may carry a `\x` prefix. This
+// is independent of both Mode and ByteSliceEncoding; the new ByteSlice has the
+// default Mode.
+func NewByteSliceFromHex(s string) (ByteSlice, error) {
+	tmp, err := hex.DecodeString(strings.TrimPrefix(s, `\x`))
+	if err != nil {
+		return ByteSlice{}, err
+	}
+	return ByteSlice{
+		ByteSlice: tmp,
+		Valid:     true,
+	}, nil
+}
+
+// mode returns the mode b will be encoded in; b.Mode, or ByteSliceEncoding if
+// b.Mode is ByteSliceDefault.
+func (b ByteSlice) mode() ByteSliceMode {
+	if b.Mode != ByteSliceDefault {
+		return b.Mode
+	}
+	if ByteSliceEncoding != ByteSliceDefault {
+		return ByteSliceEncoding
+	}
+	return ByteSliceBase64
+}
+
+// isHex reports whether m is either hex mode.
+func (m ByteSliceMode) isHex() bool {
+	return m == ByteSliceHex || m == ByteSlicePostgresHex
+}
+
+// encodeByteSlice encodes v in mode m. The raw mode is handled by Value, and is
+// treated as base64 here.
+func encodeByteSlice(v []byte, m ByteSliceMode) []byte {
+	switch m {
+	case ByteSliceHex:
+		enc := make([]byte, hex.EncodedLen(len(v)))
+		hex.Encode(enc, v)
+		return enc
+	case ByteSlicePostgresHex:
+		enc := make([]byte, 2+hex.EncodedLen(len(v)))
+		copy(enc, `\x`)
+		hex.Encode(enc[2:], v)
+		return enc
+	}
+	// TODO: For all base64.StdEncoding.Encode calls, consider performing an
+	// optimization similar to the one implemented in encoding/json/encode.go's
+	// encodeByteSlice function.
+	enc := make([]byte, base64.StdEncoding.EncodedLen(len(v)))
+	base64.StdEncoding.Encode(enc, v)
+	return enc
+}
+
+// decodeByteSlice decodes src from mode m. The raw mode is handled by Scan, and
+// is treated as base64 here.
+func decodeByteSlice(src []byte, m ByteSliceMode) ([]byte, error) {
+	if !m.isHex() {
+		tmp := make([]byte, base64.StdEncoding.DecodedLen(len(src)))
+		n, err := base64.StdEncoding.Decode(tmp, src)
+		if err != nil {
+			return nil, err
+		}
+		return tmp[:n], nil
+	}
+	src = bytes.TrimPrefix(src, []byte(`\x`))
+	tmp := make([]byte, hex.DecodedLen(len(src)))
+	n, err := hex.Decode(tmp, src)
+	if err != nil {
+		return nil, err
+	}
+	return tmp[:n], nil
+}
+
 // Getters and Setters
 
 // ValueOrZero returns the value of b if it is valid; otherwise,it returns an
@@ -134,6 +282,19 @@ func (b *ByteSlice) Null() {
 	b.Valid = false
 }
 
+// ToJSON returns a RawJSON holding a copy of the contents of b, or a null
+// RawJSON if b is null. An error will be returned if b is valid but its
+// contents are not valid JSON.
+func (b ByteSlice) ToJSON() (RawJSON, error) {
+	if !b.Valid {
+		return NullJSON(), nil
+	}
+	if !json.Valid(b.ByteSlice) {
+		return RawJSON{}, fmt.Errorf("null.ByteSlice: cannot convert to RawJSON; contents are not valid JSON")
+	}
+	return NewJSON(b.ByteSlice), nil
+}
+
 // Interfaces
 
 // IsNil implements the pyrrho/encoding IsNiler interface. It will return true
@@ -149,26 +310,31 @@ func (b ByteSlice) IsZero() bool {
 }
 
 // Value implements the database/sql/driver Valuer interface. It will base64
-// encode valid values prior to returning them.
+// encode valid values -- or hex encode them, or return them verbatim; see Mode
+// -- prior to returning them.
 func (b ByteSlice) Value() (driver.Value, error) {
 	if !b.Valid {
 		return nil, nil
 	}
-	// TODO: For all base64.StdEncoding.Encode calls, consider performing an
-	// optimization similar to the one implemented in encoding/json/encode.go's
-	// encodeByteSlice function.
-	enc := make([]byte, base64.StdEncoding.EncodedLen(len(b.ByteSlice)))
-	base64.StdEncoding.Encode(enc, b.ByteSlice)
-	return enc, nil
+	m := b.mode()
+	if m == ByteSliceRaw {
+		return b.ByteSlice, nil
+	}
+	return encodeByteSlice(b.ByteSlice, m), nil
 }
 
 // Scan implements the database/sql Scanner interface. It will receive a value
 // from an SQL database and assign it to b, so long as the provided data can be
-// is a []byte, a string, or nil. Valid data is expected to be base64 encoded.
+// is a []byte, a string, or nil. Valid data is expected to be base64 encoded,
+// or hex encoded, or is stored verbatim; see Mode. Raw data is copied, as the
+// database/sql package requires of Scanners.
+//
+// If the scan fails, the value of b will be unchanged.
 func (b *ByteSlice) Scan(src interface{}) error {
 	if b == nil {
 		return fmt.Errorf("null.ByteSlice: Scan called on nil pointer")
 	}
+	m := b.mode()
 	switch val := src.(type) {
 	case nil:
 		b.ByteSlice = nil
@@ -180,16 +346,25 @@ func (b *ByteSlice) Scan(src interface{}) error {
 			b.Valid = false
 			return nil
 		}
-		tmp := make([]byte, base64.StdEncoding.DecodedLen(len(val)))
-		n, err := base64.StdEncoding.Decode(tmp, val)
+		if m == ByteSliceRaw {
+			b.ByteSlice = append([]byte{}, val...)
+			b.Valid = true
+			return nil
+		}
+		tmp, err := decodeByteSlice(val, m)
 		if err != nil {
 			return err
 		}
-		b.ByteSlice = tmp[:n]
+		b.ByteSlice = tmp
 		b.Valid = true
 		return nil
 	case string:
-		tmp, err := base64.StdEncoding.DecodeString(val)
+		if m == ByteSliceRaw {
+			b.ByteSlice = []byte(val)
+			b.Valid = true
+			return nil
+		}
+		tmp, err := decodeByteSlice([]byte(val), m)
 		if err != nil {
 			return err
 		}
@@ -203,19 +378,23 @@ func (b *ByteSlice) Scan(src interface{}) error {
 }
 
 // MarshalJSON implements the encoding/json Marshaler interface. It will encode
-// b into its base64 representation if valid, or 'null' otherwise.
+// b into its base64 representation -- or its hex representation; see Mode -- if
+// valid, or 'null' otherwise.
 func (b ByteSlice) MarshalJSON() ([]byte, error) {
 	if !b.Valid {
 		return []byte("null"), nil
 	}
+	if m := b.mode(); m.isHex() {
+		return json.Marshal(string(encodeByteSlice(b.ByteSlice, m)))
+	}
 	// Because we're passing a []byte into json.Marshal, the json package will
 	// handle any base64 decoding that needs to happen.
 	return json.Marshal(b.ByteSlice)
 }
 
 // UnmarshalJSON implements the encoding/json Unmarshaler interface. It will
-// decode a given []byte into b, so long as the provided []byte is
-// a valid base64 encoded string or a null.
+// decode a given []byte into b, so long as the provided []byte is a valid
+// base64 encoded string -- or hex encoded string; see Mode -- or a null.
 //
 // An empty string will result in a valid-but-empty ByteSlice. The keyword
 // 'null' will result in a null ByteSlice. The string '"null"' is considered
@@ -243,6 +422,15 @@ func (b *ByteSlice) UnmarshalJSON(data []byte) error {
 			b.Valid = true
 			return nil
 		}
+		if m := b.mode(); m.isHex() {
+			tmp, err := decodeByteSlice([]byte(val), m)
+			if err != nil {
+				return err
+			}
+			b.ByteSlice = tmp
+			b.Valid = true
+			return nil
+		}
 		// Call json.Unmarshal again, this time with a []byte as the dest. This
 		// lets encoding/json package take care of the base64 decoding.
 		var tmp []byte
@@ -260,16 +448,12 @@ func (b *ByteSlice) UnmarshalJSON(data []byte) error {
 }
 
 // MarshalMapValue implements the pyrrho/encoding/maps Marshaler interface. It
-// will encode b into its base64 encoded interface{} representation for use in a
-// map[string]interface{} if valid, or return nil otherwise.
+// will encode b into its base64 encoded -- or hex encoded; see Mode --
+// interface{} representation for use in a map[string]interface{} if valid, or
+// return nil otherwise.
 func (b ByteSlice) MarshalMapValue() (interface{}, error) {
 	if !b.Valid {
 		return nil, nil
 	}
-	// TODO: For all base64.StdEncoding.Encode calls, consider performing an
-	// optimization similar to the one implemented in encoding/json/encode.go's
-	// encodeByteSlice function.
-	enc := make([]byte, base64.StdEncoding.EncodedLen(len(b.ByteSlice)))
-	base64.StdEncoding.Encode(enc, b.ByteSlice)
-	return enc, nil
+	return encodeByteSlice(b.ByteSlice, b.mode()), nil
 }
@@ -137,6 +137,33 @@ func TestByteSliceNull(t *testing.T) {
 	require.False(bs.Valid)
 }
 
+func TestByteSliceToJSON(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	b := null.NewByteSliceStr(`{"foo":"bar"}`)
+	j, err := b.ToJSON()
+	require.NoError(err)
+	require.True(j.Valid)
+	require.EqualValues(`{"foo":"bar"}`, j.JSON)
+
+	// The conversion copies.
+	b.ByteSlice[0] = '['
+	require.EqualValues(`{"foo":"bar"}`, j.JSON)
+
+	// Contents that aren't JSON can't be converted ...
+	_, err = null.NewByteSliceStr(":->").ToJSON()
+	require.Error(err)
+	_, err = null.NewByteSlice([]byte{}).ToJSON()
+	require.Error(err)
+
+	// ... but nulls can.
+	nul, err := null.NullByteSlice().ToJSON()
+	require.NoError(err)
+	require.False(nul.Valid)
+	require.Equal(null.NullJSON(), nul)
+}
+
 func TestByteSliceIsNil(t *testing.T) {
 	require := require.New(t)
 
@@ -321,3 +348,192 @@ func TestByteSliceMarshalMapValue(t *testing.T) {
 	require.NoError(err)
 	require.Equal(map[string]interface{}{"Slice": nil}, data)
 }
+
+func TestByteSliceFromHex(t *testing.T) {
+	require := require.New(t)
+
+	b, err := null.NewByteSliceFromHex("444149434f4e2056")
+	require.NoError(err)
+	require.True(b.Valid)
+	require.Equal([]byte("DAICON V"), b.ByteSlice)
+
+	b2, err := null.NewByteSliceFromHex(`\x444149434f4e2056`)
+	require.NoError(err)
+	require.Equal([]byte("DAICON V"), b2.ByteSlice)
+
+	_, err = null.NewByteSliceFromHex("not hex")
+	require.Error(err)
+}
+
+func TestByteSliceHexEncoding(t *testing.T) {
+	require := require.New(t)
+	defer func() { null.ByteSliceEncoding = null.ByteSliceBase64 }()
+
+	b := null.NewByteSliceStr("DAICON V")
+
+	null.ByteSliceEncoding = null.ByteSliceHex
+	val, err := b.Value()
+	require.NoError(err)
+	require.Equal([]byte("444149434f4e2056"), val)
+	mv, err := b.MarshalMapValue()
+	require.NoError(err)
+	require.Equal([]byte("444149434f4e2056"), mv)
+	require.Equal("444149434f4e2056", b.String())
+	data, err := json.Marshal(b)
+	require.NoError(err)
+	require.Equal(`"444149434f4e2056"`, string(data))
+
+	var scanned null.ByteSlice
+	require.NoError(scanned.Scan(val))
+	require.Equal(b, scanned)
+	var unmarshaled null.ByteSlice
+	require.NoError(json.Unmarshal(data, &unmarshaled))
+	require.Equal(b, unmarshaled)
+
+	// Either hex mode accepts input with or without the `\x` prefix.
+	var prefixed null.ByteSlice
+	require.NoError(prefixed.Scan(`\x444149434f4e2056`))
+	require.Equal(b, prefixed)
+
+	// Base64 input is rejected in hex mode, and leaves the target unchanged.
+	bad := null.NewByteSliceStr("unchanged")
+	require.Error(bad.Scan(base64ed("DAICON V")))
+	require.Equal(null.NewByteSliceStr("unchanged"), bad)
+
+	null.ByteSliceEncoding = null.ByteSlicePostgresHex
+	val, err = b.Value()
+	require.NoError(err)
+	require.Equal([]byte(`\x444149434f4e2056`), val)
+	data, err = json.Marshal(b)
+	require.NoError(err)
+	require.Equal(`"\\x444149434f4e2056"`, string(data))
+
+	scanned = null.ByteSlice{}
+	require.NoError(scanned.Scan(val))
+	require.Equal(b, scanned)
+	unmarshaled = null.ByteSlice{}
+	require.NoError(json.Unmarshal(data, &unmarshaled))
+	require.Equal(b, unmarshaled)
+
+	// Switching back to base64 restores the default behavior.
+	null.ByteSliceEncoding = null.ByteSliceBase64
+	val, err = b.Value()
+	require.NoError(err)
+	require.Equal(base64ed("DAICON V"), val)
+	data, err = json.Marshal(b)
+	require.NoError(err)
+	require.Equal(base64ed(`"DAICON V"`), data)
+
+	scanned = null.ByteSlice{}
+	require.NoError(scanned.Scan(val))
+	require.Equal(b, scanned)
+}
+
+func TestByteSliceRawEncoding(t *testing.T) {
+	require := require.New(t)
+	defer func() { null.ByteSliceEncoding = null.ByteSliceBase64 }()
+	null.ByteSliceEncoding = null.ByteSliceRaw
+
+	// Scan stores the bytes it receives verbatim. These bytes happen to be
+	// valid base64, and would be decoded in ByteSliceBase64 mode.
+	raw := []byte("REFJQ09OIFY=")
+	var b null.ByteSlice
+	require.NoError(b.Scan(raw))
+	require.True(b.Valid)
+	require.Equal([]byte("REFJQ09OIFY="), b.ByteSlice)
+
+	// The scanned bytes are a copy; drivers may reuse their buffers.
+	raw[0] = 'X'
+	require.Equal([]byte("REFJQ09OIFY="), b.ByteSlice)
+
+	// Bytes that are not valid base64 are accepted as well.
+	var bin null.ByteSlice
+	require.NoError(bin.Scan([]byte{0x00, 0xff, 0x10}))
+	require.Equal([]byte{0x00, 0xff, 0x10}, bin.ByteSlice)
+
+	var s null.ByteSlice
+	require.NoError(s.Scan("DAICON V"))
+	require.Equal([]byte("DAICON V"), s.ByteSlice)
+
+	var nul null.ByteSlice
+	require.NoError(nul.Scan(nil))
+	require.False(nul.Valid)
+
+	// Value returns the bytes verbatim.
+	val, err := null.NewByteSliceStr("DAICON V").Value()
+	require.NoError(err)
+	require.Equal([]byte("DAICON V"), val)
+	val, err = null.NullByteSlice().Value()
+	require.NoError(err)
+	require.Nil(val)
+
+	// JSON and map values still use base64.
+	data, err := json.Marshal(null.NewByteSliceStr("DAICON V"))
+	require.NoError(err)
+	require.Equal(base64ed(`"DAICON V"`), data)
+	var unmarshaled null.ByteSlice
+	require.NoError(json.Unmarshal(data, &unmarshaled))
+	require.Equal([]byte("DAICON V"), unmarshaled.ByteSlice)
+	mv, err := null.NewByteSliceStr("DAICON V").MarshalMapValue()
+	require.NoError(err)
+	require.Equal(base64ed("DAICON V"), mv)
+}
+
+func TestByteSliceMode(t *testing.T) {
+	require := require.New(t)
+	defer func() { null.ByteSliceEncoding = null.ByteSliceBase64 }()
+
+	// Values of different modes may be used side by side; eg. a bytea column
+	// and a base64 text column.
+	raw := null.NewRawByteSlice([]byte("DAICON V"))
+	b64 := null.NewByteSliceStr("DAICON V")
+	hexed := null.NewByteSliceHex([]byte("DAICON V"))
+	require.Equal(null.ByteSliceRaw, raw.Mode)
+	require.Equal(null.ByteSliceDefault, b64.Mode)
+	require.Equal(null.ByteSliceHex, hexed.Mode)
+
+	val, err := raw.Value()
+	require.NoError(err)
+	require.Equal([]byte("DAICON V"), val)
+	val, err = b64.Value()
+	require.NoError(err)
+	require.Equal(base64ed("DAICON V"), val)
+	val, err = hexed.Value()
+	require.NoError(err)
+	require.Equal([]byte("444149434f4e2056"), val)
+	data, err := json.Marshal(hexed)
+	require.NoError(err)
+	require.Equal(`"444149434f4e2056"`, string(data))
+
+	// A ByteSlice may be prepared to receive data in a given mode. The mode is
+	// kept by Scan, Set, and Null.
+	rawDst := null.NewRawByteSlice(nil)
+	require.False(rawDst.Valid)
+	require.NoError(rawDst.Scan([]byte("REFJQ09OIFY=")))
+	require.Equal([]byte("REFJQ09OIFY="), rawDst.ByteSlice)
+	require.Equal(null.ByteSliceRaw, rawDst.Mode)
+	rawDst.Set([]byte("x"))
+	rawDst.Null()
+	require.Equal(null.ByteSliceRaw, rawDst.Mode)
+
+	b64Dst := null.NullByteSlice()
+	require.NoError(b64Dst.Scan([]byte("REFJQ09OIFY=")))
+	require.Equal([]byte("DAICON V"), b64Dst.ByteSlice)
+
+	hexDst := null.ByteSlice{Mode: null.ByteSlicePostgresHex}
+	require.NoError(json.Unmarshal([]byte(`"\\x444149434f4e2056"`), &hexDst))
+	require.Equal([]byte("DAICON V"), hexDst.ByteSlice)
+
+	// A value's mode takes precedence over ByteSliceEncoding, which is only
+	// used by values of the default mode.
+	null.ByteSliceEncoding = null.ByteSliceHex
+	val, err = raw.Value()
+	require.NoError(err)
+	require.Equal([]byte("DAICON V"), val)
+	val, err = b64.Value()
+	require.NoError(err)
+	require.Equal([]byte("444149434f4e2056"), val)
+	val, err = null.NewByteSliceMode([]byte("DAICON V"), null.ByteSliceBase64).Value()
+	require.NoError(err)
+	require.Equal(base64ed("DAICON V"), val)
+}
@@ -0,0 +1,208 @@
+package null
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// dateLayout is the layout, as understood by time.Parse and time.Time.Format,
+// of a Date's text representation.
+const dateLayout = "2006-01-02"
+
+// Date is a nullable calendar date -- a year, month, and day, without a time
+// of day or time zone -- implementing all of the pyrrho/encoding/types
+// interfaces detailed in the package comments. Database interactions (Value
+// and Scan) use a time.Time at midnight UTC. JSON interactions (MarshalJSON
+// and UnmarshalJSON) use a "YYYY-MM-DD" string, eg. "2017-04-01".
+//
+// If the Date is valid and holds the date of the zero time instant,
+// 0001-01-01, it will be considered non-null, and of zero value.
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+	Valid bool
+}
+
+// Constructors
+
+// NullDate constructs and returns a new null Date.
+func NullDate() Date {
+	return Date{
+		Year:  0,
+		Month: 0,
+		Day:   0,
+		Valid: false,
+	}
+}
+
+// NewDate constructs and returns a new, valid Date initialized with the given
+// y, m, and d. Out-of-range values are normalized as they would be by
+// time.Date; eg. October 32 becomes November 1.
+func NewDate(y int, m time.Month, d int) Date {
+	return newDateFromTime(time.Date(y, m, d, 0, 0, 0, 0, time.UTC))
+}
+
+// NewDateStr parses the given string, s, as a "YYYY-MM-DD" date and returns a
+// new, valid Date initialized with the result. If s is the empty string, the
+// new Date will be null.
+func NewDateStr(s string) (Date, error) {
+	if len(s) == 0 {
+		return NullDate(), nil
+	}
+	tmp, err := time.Parse(dateLayout, s)
+	if err != nil {
+		return Date{}, err
+	}
+	return newDateFromTime(tmp), nil
+}
+
+// newDateFromTime returns a new, valid Date holding the date of t in t's
+// location.
+func newDateFromTime(t time.Time) Date {
+	y, m, d := t.Date()
+	return Date{
+		Year:  y,
+		Month: m,
+		Day:   d,
+		Valid: true,
+	}
+}
+
+// Getters and Setters
+
+// ValueOrZero returns the value of d as a time.Time at midnight UTC if it is
+// valid; otherwise it returns the zero value for a time.Time.
+func (d Date) ValueOrZero() time.Time {
+	if !d.Valid {
+		return time.Time{}
+	}
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, time.UTC)
+}
+
+// Set modifies the value stored in d, and guarantees it is valid. The given
+// values are normalized as they are by NewDate.
+func (d *Date) Set(y int, m time.Month, day int) {
+	*d = NewDate(y, m, day)
+}
+
+// Null marks d as null with no meaningful value.
+func (d *Date) Null() {
+	*d = NullDate()
+}
+
+// Interfaces
+
+// IsNil implements the pyrrho/encoding IsNiler interface. It will return true
+// if d is null.
+func (d Date) IsNil() bool {
+	return !d.Valid
+}
+
+// IsZero implements the pyrrho/encoding IsZeroer interface. It will return true
+// if d is null or if its value is the date of the zero time instant,
+// 0001-01-01.
+func (d Date) IsZero() bool {
+	return !d.Valid || d.Year == 1 && d.Month == time.January && d.Day == 1
+}
+
+// Value implements the database/sql/driver Valuer interface. It will return the
+// value of d as a time.Time at midnight UTC if valid, or nil otherwise.
+func (d Date) Value() (driver.Value, error) {
+	if !d.Valid {
+		return nil, nil
+	}
+	return d.ValueOrZero(), nil
+}
+
+// Scan implements the database/sql Scanner interface. It will receive a value
+// from an SQL database and assign it to d, so long as the provided data is a
+// time.Time, a "YYYY-MM-DD" string or []byte, or nil. The date of a time.Time
+// is taken in its own location. All other types will result in an error.
+func (d *Date) Scan(src interface{}) error {
+	if d == nil {
+		return fmt.Errorf("null.Date: Scan called on nil pointer")
+	}
+	var s string
+	switch val := src.(type) {
+	case time.Time:
+		*d = newDateFromTime(val)
+		return nil
+	case string:
+		s = val
+	case []byte:
+		s = string(val)
+	case nil:
+		d.Null()
+		return nil
+	default:
+		return fmt.Errorf("null.Date: cannot scan type %T (%v)", val, src)
+	}
+	tmp, err := time.Parse(dateLayout, s)
+	if err != nil {
+		return fmt.Errorf("null.Date: cannot scan %q: %v", s, err)
+	}
+	*d = newDateFromTime(tmp)
+	return nil
+}
+
+// MarshalJSON implements the encoding/json Marshaler interface. It will encode
+// d into a quoted "YYYY-MM-DD" string if valid, or 'null' otherwise.
+func (d Date) MarshalJSON() ([]byte, error) {
+	if !d.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON implements the encoding/json Unmarshaler interface. It will
+// decode a given []byte into d so long as the provided []byte is a JSON
+// "YYYY-MM-DD" string. Empty strings and the 'null' keyword will both decode
+// into a null Date.
+//
+// If the decode fails, the value of d will be unchanged.
+func (d *Date) UnmarshalJSON(data []byte) error {
+	if d == nil {
+		return fmt.Errorf("null.Date: UnmarshalJSON called on nil pointer")
+	}
+	var j interface{}
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+	switch val := j.(type) {
+	case string:
+		tmp, err := NewDateStr(val)
+		if err != nil {
+			return err
+		}
+		*d = tmp
+		return nil
+	case nil:
+		d.Null()
+		return nil
+	default:
+		return fmt.Errorf("null.Date: cannot unmarshal JSON of type %T (%v)",
+			val, data)
+	}
+}
+
+// MarshalMapValue implements the pyrrho/encoding/maps Marshaler interface. It
+// will encode d into its "YYYY-MM-DD" string representation for use in a
+// map[string]interface{} if valid, or return nil otherwise.
+func (d Date) MarshalMapValue() (interface{}, error) {
+	if d.Valid {
+		return d.String(), nil
+	}
+	return nil, nil
+}
+
+// String returns the text form of d, formatted as "YYYY-MM-DD", if valid, or
+// the null token otherwise.
+func (d Date) String() string {
+	if !d.Valid {
+		return nullToken
+	}
+	return d.ValueOrZero().Format(dateLayout)
+}
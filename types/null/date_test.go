@@ -0,0 +1,251 @@
+package null_test
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/pyrrho/encoding/maps"
+	"github.com/pyrrho/encoding/types/null"
+	"github.com/pyrrho/encoding/types/typestest"
+	"github.com/stretchr/testify/require"
+)
+
+var dateValue = null.NewDate(2017, time.April, 1)
+
+func TestDateCtors(t *testing.T) {
+	require := require.New(t)
+
+	// null.NullDate() returns a new null null.Date.
+	// This is equivalent to null.Date{}.
+	nul := null.NullDate()
+	require.False(nul.Valid)
+	require.Equal(null.Date{}, nul)
+
+	// null.NewDate constructs a new, valid null.Date ...
+	d := null.NewDate(2017, time.April, 1)
+	require.True(d.Valid)
+	require.Equal(2017, d.Year)
+	require.Equal(time.April, d.Month)
+	require.Equal(1, d.Day)
+
+	// ... normalizing out-of-range values.
+	require.Equal(null.NewDate(2017, time.May, 1), null.NewDate(2017, time.April, 31))
+
+	// null.NewDateStr parses "YYYY-MM-DD" strings ...
+	s, err := null.NewDateStr("2017-04-01")
+	require.NoError(err)
+	require.Equal(dateValue, s)
+
+	// ... treating empty strings as null ...
+	s, err = null.NewDateStr("")
+	require.NoError(err)
+	require.False(s.Valid)
+
+	// ... and returning parse errors.
+	for _, bad := range []string{
+		"2017-04-31",
+		"2017-4-1",
+		"04/01/2017",
+		"2017-04-01T00:00:00Z",
+	} {
+		_, err = null.NewDateStr(bad)
+		require.Error(err, bad)
+	}
+}
+
+func TestDateValueOrZero(t *testing.T) {
+	require := require.New(t)
+
+	require.Equal(time.Date(2017, time.April, 1, 0, 0, 0, 0, time.UTC),
+		dateValue.ValueOrZero())
+
+	nul := null.Date{}
+	require.Equal(time.Time{}, nul.ValueOrZero())
+}
+
+func TestDateSet(t *testing.T) {
+	require := require.New(t)
+
+	d := null.Date{}
+	d.Set(2017, time.April, 1)
+	require.Equal(dateValue, d)
+}
+
+func TestDateNull(t *testing.T) {
+	require := require.New(t)
+
+	d := dateValue
+	d.Null()
+	require.False(d.Valid)
+	require.Equal(null.Date{}, d)
+}
+
+func TestDateIsNil(t *testing.T) {
+	require := require.New(t)
+
+	require.False(dateValue.IsNil())
+	require.False(null.NewDate(1, time.January, 1).IsNil())
+	require.True(null.Date{}.IsNil())
+}
+
+func TestDateIsZero(t *testing.T) {
+	require := require.New(t)
+
+	require.False(dateValue.IsZero())
+	require.True(null.NewDate(1, time.January, 1).IsZero())
+	require.True(null.Date{}.IsZero())
+}
+
+func TestDateSQLValue(t *testing.T) {
+	require := require.New(t)
+	var val driver.Value
+	var err error
+
+	val, err = dateValue.Value()
+	require.NoError(err)
+	require.Equal(time.Date(2017, time.April, 1, 0, 0, 0, 0, time.UTC), val)
+
+	nul := null.Date{}
+	val, err = nul.Value()
+	require.NoError(err)
+	require.Equal(nil, val)
+}
+
+func TestDateSQLScan(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	// time.Times keep the date of their own location.
+	var ti null.Date
+	loc := time.FixedZone("UTC-8", -8*60*60)
+	err = ti.Scan(time.Date(2017, time.April, 1, 20, 0, 0, 0, loc))
+	require.NoError(err)
+	require.Equal(dateValue, ti)
+
+	var s null.Date
+	err = s.Scan("2017-04-01")
+	require.NoError(err)
+	require.Equal(dateValue, s)
+
+	var b null.Date
+	err = b.Scan([]byte("2017-04-01"))
+	require.NoError(err)
+	require.Equal(dateValue, b)
+
+	nul := dateValue
+	err = nul.Scan(nil)
+	require.NoError(err)
+	require.False(nul.Valid)
+
+	invalid := dateValue
+	err = invalid.Scan("2017-13-01")
+	require.Error(err)
+	require.Equal(dateValue, invalid)
+
+	var wrong null.Date
+	err = wrong.Scan(int64(20170401))
+	require.Error(err)
+}
+
+func TestDateMarshalJSON(t *testing.T) {
+	require := require.New(t)
+	var data []byte
+	var err error
+
+	data, err = json.Marshal(dateValue)
+	require.NoError(err)
+	require.EqualValues(`"2017-04-01"`, data)
+	data, err = json.Marshal(&dateValue)
+	require.NoError(err)
+	require.EqualValues(`"2017-04-01"`, data)
+
+	zero := null.NewDate(1, time.January, 1)
+	data, err = json.Marshal(zero)
+	require.NoError(err)
+	require.EqualValues(`"0001-01-01"`, data)
+
+	nul := null.Date{}
+	data, err = json.Marshal(nul)
+	require.NoError(err)
+	require.EqualValues("null", data)
+	data, err = json.Marshal(&nul)
+	require.NoError(err)
+	require.EqualValues("null", data)
+}
+
+func TestDateUnmarshalJSON(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	var d null.Date
+	err = json.Unmarshal([]byte(`"2017-04-01"`), &d)
+	require.NoError(err)
+	require.Equal(dateValue, d)
+
+	nul := dateValue
+	err = json.Unmarshal([]byte("null"), &nul)
+	require.NoError(err)
+	require.False(nul.Valid)
+
+	empty := dateValue
+	err = json.Unmarshal([]byte(`""`), &empty)
+	require.NoError(err)
+	require.False(empty.Valid)
+
+	// Failed decodes leave the value unchanged.
+	invalid := dateValue
+	err = json.Unmarshal([]byte(`"2017-02-30"`), &invalid)
+	require.Error(err)
+	require.Equal(dateValue, invalid)
+
+	var number null.Date
+	err = json.Unmarshal([]byte("20170401"), &number)
+	require.Error(err)
+
+	// MarshalJSON round-trips through UnmarshalJSON.
+	for _, in := range []null.Date{
+		dateValue,
+		null.NewDate(1, time.January, 1),
+		null.NullDate(),
+	} {
+		data, err := json.Marshal(in)
+		require.NoError(err)
+		var out null.Date
+		err = json.Unmarshal(data, &out)
+		require.NoError(err)
+		require.Equal(in, out)
+	}
+}
+
+func TestDateMarshalMapValue(t *testing.T) {
+	require := require.New(t)
+	type Wrapper struct{ Date null.Date }
+	var wrapper Wrapper
+	var data map[string]interface{}
+	var err error
+
+	wrapper = Wrapper{dateValue}
+	data, err = maps.Marshal(wrapper)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{"Date": "2017-04-01"}, data)
+
+	// Null Dates should be encoded as "nil"
+	wrapper = Wrapper{null.Date{}}
+	data, err = maps.Marshal(wrapper)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{"Date": nil}, data)
+}
+
+func TestDateString(t *testing.T) {
+	require := require.New(t)
+
+	require.Equal("2017-04-01", dateValue.String())
+	require.Equal("", null.Date{}.String())
+}
+
+func TestDateConformance(t *testing.T) {
+	typestest.AssertInterfaceConformance(t, dateValue)
+	typestest.AssertInterfaceConformance(t, null.Date{})
+}
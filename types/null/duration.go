@@ -0,0 +1,190 @@
+package null
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Duration is a nullable wrapper around the time.Duration type implementing all
+// of the pyrrho/encoding/types interfaces detailed in the package comments.
+// Database interactions (Value and Scan) use an integer number of nanoseconds.
+// JSON interactions (MarshalJSON and UnmarshalJSON) use the string form
+// produced by time.Duration.String and accepted by time.ParseDuration, eg.
+// "1h30m0s".
+//
+// If the Duration is valid and contains 0, it will be considered non-null, and
+// of zero value.
+type Duration struct {
+	Duration time.Duration
+	Valid    bool
+}
+
+// Constructors
+
+// NullDuration constructs and returns a new null Duration.
+func NullDuration() Duration {
+	return Duration{
+		Duration: 0,
+		Valid:    false,
+	}
+}
+
+// NewDuration constructs and returns a new, valid Duration initialized with the
+// value of the given d.
+func NewDuration(d time.Duration) Duration {
+	return Duration{
+		Duration: d,
+		Valid:    true,
+	}
+}
+
+// NewDurationStr parses the given string, s, with time.ParseDuration and
+// returns a new, valid Duration initialized with the result. If s is the empty
+// string, the new Duration will be null.
+func NewDurationStr(s string) (Duration, error) {
+	if len(s) == 0 {
+		return NullDuration(), nil
+	}
+	tmp, err := time.ParseDuration(s)
+	if err != nil {
+		return Duration{}, err
+	}
+	return Duration{
+		Duration: tmp,
+		Valid:    true,
+	}, nil
+}
+
+// Getters and Setters
+
+// ValueOrZero returns the value of d if it is valid; otherwise it returns the
+// zero value for a time.Duration (0).
+func (d Duration) ValueOrZero() time.Duration {
+	if !d.Valid {
+		return 0
+	}
+	return d.Duration
+}
+
+// Set modifies the value stored in d, and guarantees it is valid.
+func (d *Duration) Set(v time.Duration) {
+	d.Duration = v
+	d.Valid = true
+}
+
+// Null marks d as null with no meaningful value.
+func (d *Duration) Null() {
+	d.Duration = 0
+	d.Valid = false
+}
+
+// Interfaces
+
+// IsNil implements the pyrrho/encoding IsNiler interface. It will return true
+// if d is null.
+func (d Duration) IsNil() bool {
+	return !d.Valid
+}
+
+// IsZero implements the pyrrho/encoding IsZeroer interface. It will return true
+// if d is null or if its value is 0.
+func (d Duration) IsZero() bool {
+	return !d.Valid || d.Duration == 0
+}
+
+// Value implements the database/sql/driver Valuer interface. It will return the
+// value of d as an int64 number of nanoseconds if valid, or nil otherwise.
+func (d Duration) Value() (driver.Value, error) {
+	if !d.Valid {
+		return nil, nil
+	}
+	return int64(d.Duration), nil
+}
+
+// Scan implements the database/sql Scanner interface. It will receive a value
+// from an SQL database and assign it to d, so long as the provided data is an
+// int64 number of nanoseconds, a string or []byte accepted by
+// time.ParseDuration, or nil. All other types will result in an error.
+func (d *Duration) Scan(src interface{}) error {
+	if d == nil {
+		return fmt.Errorf("null.Duration: Scan called on nil pointer")
+	}
+	var s string
+	switch val := src.(type) {
+	case int64:
+		d.Duration = time.Duration(val)
+		d.Valid = true
+		return nil
+	case string:
+		s = val
+	case []byte:
+		s = string(val)
+	case nil:
+		d.Duration = 0
+		d.Valid = false
+		return nil
+	default:
+		return fmt.Errorf("null.Duration: cannot scan type %T (%v)", val, src)
+	}
+	tmp, err := time.ParseDuration(s)
+	if err != nil {
+		return fmt.Errorf("null.Duration: cannot scan %q: %v", s, err)
+	}
+	d.Duration = tmp
+	d.Valid = true
+	return nil
+}
+
+// MarshalJSON implements the encoding/json Marshaler interface. It will encode
+// d into a quoted time.Duration string, eg. "1h30m0s", if valid, or 'null'
+// otherwise.
+func (d Duration) MarshalJSON() ([]byte, error) {
+	if !d.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(d.Duration.String())
+}
+
+// UnmarshalJSON implements the encoding/json Unmarshaler interface. It will
+// decode a given []byte into d so long as the provided []byte is a JSON string
+// accepted by time.ParseDuration. Empty strings and the 'null' keyword will
+// both decode into a null Duration.
+//
+// If the decode fails, the value of d will be unchanged.
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	if d == nil {
+		return fmt.Errorf("null.Duration: UnmarshalJSON called on nil pointer")
+	}
+	var j interface{}
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+	switch val := j.(type) {
+	case string:
+		tmp, err := NewDurationStr(val)
+		if err != nil {
+			return err
+		}
+		*d = tmp
+		return nil
+	case nil:
+		d.Duration = 0
+		d.Valid = false
+		return nil
+	default:
+		return fmt.Errorf("null.Duration: cannot unmarshal JSON of type %T (%v)",
+			val, data)
+	}
+}
+
+// MarshalMapValue implements the pyrrho/encoding/maps Marshaler interface. It
+// will encode d into its interface{} representation for use in a
+// map[string]interface{} if valid, or return nil otherwise.
+func (d Duration) MarshalMapValue() (interface{}, error) {
+	if d.Valid {
+		return d.Duration, nil
+	}
+	return nil, nil
+}
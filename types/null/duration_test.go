@@ -0,0 +1,263 @@
+package null_test
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/pyrrho/encoding/maps"
+	"github.com/pyrrho/encoding/types/null"
+	"github.com/stretchr/testify/require"
+)
+
+var durationValue = time.Hour + 30*time.Minute
+
+func TestDurationCtors(t *testing.T) {
+	require := require.New(t)
+
+	// null.NullDuration() returns a new null null.Duration.
+	// This is equivalent to null.Duration{}.
+	nul := null.NullDuration()
+	require.False(nul.Valid)
+
+	empty := null.Duration{}
+	require.False(empty.Valid)
+
+	// null.NewDuration constructs a new, valid null.Duration.
+	d := null.NewDuration(durationValue)
+	require.True(d.Valid)
+	require.Equal(durationValue, d.Duration)
+
+	z := null.NewDuration(0)
+	require.True(z.Valid)
+	require.Equal(time.Duration(0), z.Duration)
+
+	// null.NewDurationStr parses with time.ParseDuration ...
+	s, err := null.NewDurationStr("1h30m")
+	require.NoError(err)
+	require.True(s.Valid)
+	require.Equal(durationValue, s.Duration)
+
+	// ... treating empty strings as null ...
+	s, err = null.NewDurationStr("")
+	require.NoError(err)
+	require.False(s.Valid)
+
+	// ... and returning parse errors.
+	_, err = null.NewDurationStr("1 fortnight")
+	require.Error(err)
+}
+
+func TestDurationValueOrZero(t *testing.T) {
+	require := require.New(t)
+
+	d := null.NewDuration(durationValue)
+	require.Equal(durationValue, d.ValueOrZero())
+
+	nul := null.Duration{}
+	require.Equal(time.Duration(0), nul.ValueOrZero())
+}
+
+func TestDurationSet(t *testing.T) {
+	require := require.New(t)
+
+	d := null.Duration{}
+	require.False(d.Valid)
+
+	d.Set(durationValue)
+	require.True(d.Valid)
+	require.Equal(durationValue, d.Duration)
+
+	d.Set(0)
+	require.True(d.Valid)
+	require.Equal(time.Duration(0), d.Duration)
+}
+
+func TestDurationNull(t *testing.T) {
+	require := require.New(t)
+
+	d := null.NewDuration(durationValue)
+
+	d.Null()
+	require.False(d.Valid)
+	require.Equal(time.Duration(0), d.Duration)
+}
+
+func TestDurationIsNil(t *testing.T) {
+	require := require.New(t)
+
+	d := null.NewDuration(durationValue)
+	require.False(d.IsNil())
+
+	z := null.NewDuration(0)
+	require.False(z.IsNil())
+
+	nul := null.Duration{}
+	require.True(nul.IsNil())
+}
+
+func TestDurationIsZero(t *testing.T) {
+	require := require.New(t)
+
+	d := null.NewDuration(durationValue)
+	require.False(d.IsZero())
+
+	z := null.NewDuration(0)
+	require.True(z.IsZero())
+
+	nul := null.Duration{}
+	require.True(nul.IsZero())
+}
+
+func TestDurationSQLValue(t *testing.T) {
+	require := require.New(t)
+	var val driver.Value
+	var err error
+
+	d := null.NewDuration(durationValue)
+	val, err = d.Value()
+	require.NoError(err)
+	require.Equal(int64(durationValue), val)
+
+	zero := null.NewDuration(0)
+	val, err = zero.Value()
+	require.NoError(err)
+	require.Equal(int64(0), val)
+
+	nul := null.Duration{}
+	val, err = nul.Value()
+	require.NoError(err)
+	require.Equal(nil, val)
+}
+
+func TestDurationSQLScan(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	var i null.Duration
+	err = i.Scan(int64(durationValue))
+	require.NoError(err)
+	require.True(i.Valid)
+	require.Equal(durationValue, i.Duration)
+
+	var s null.Duration
+	err = s.Scan("1h30m")
+	require.NoError(err)
+	require.True(s.Valid)
+	require.Equal(durationValue, s.Duration)
+
+	var b null.Duration
+	err = b.Scan([]byte("-1.5s"))
+	require.NoError(err)
+	require.True(b.Valid)
+	require.Equal(-1500*time.Millisecond, b.Duration)
+
+	nul := null.NewDuration(durationValue)
+	err = nul.Scan(nil)
+	require.NoError(err)
+	require.False(nul.Valid)
+
+	var invalid null.Duration
+	err = invalid.Scan("1 fortnight")
+	require.Error(err)
+	require.False(invalid.Valid)
+
+	var wrong null.Duration
+	err = wrong.Scan(1.5)
+	require.Error(err)
+}
+
+func TestDurationMarshalJSON(t *testing.T) {
+	require := require.New(t)
+	var data []byte
+	var err error
+
+	d := null.NewDuration(durationValue)
+	data, err = json.Marshal(d)
+	require.NoError(err)
+	require.EqualValues(`"1h30m0s"`, data)
+	data, err = json.Marshal(&d)
+	require.NoError(err)
+	require.EqualValues(`"1h30m0s"`, data)
+
+	zero := null.NewDuration(0)
+	data, err = json.Marshal(zero)
+	require.NoError(err)
+	require.EqualValues(`"0s"`, data)
+
+	nul := null.Duration{}
+	data, err = json.Marshal(nul)
+	require.NoError(err)
+	require.EqualValues("null", data)
+	data, err = json.Marshal(&nul)
+	require.NoError(err)
+	require.EqualValues("null", data)
+}
+
+func TestDurationUnmarshalJSON(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	var d null.Duration
+	err = json.Unmarshal([]byte(`"1h30m"`), &d)
+	require.NoError(err)
+	require.True(d.Valid)
+	require.Equal(durationValue, d.Duration)
+
+	var nul null.Duration
+	err = json.Unmarshal([]byte("null"), &nul)
+	require.NoError(err)
+	require.False(nul.Valid)
+
+	var empty null.Duration
+	err = json.Unmarshal([]byte(`""`), &empty)
+	require.NoError(err)
+	require.False(empty.Valid)
+
+	// Failed decodes leave the value unchanged.
+	invalid := null.NewDuration(durationValue)
+	err = json.Unmarshal([]byte(`"1 fortnight"`), &invalid)
+	require.Error(err)
+	require.True(invalid.Valid)
+	require.Equal(durationValue, invalid.Duration)
+
+	var number null.Duration
+	// Durations are strings, not nanosecond counts.
+	err = json.Unmarshal([]byte("5400000000000"), &number)
+	require.Error(err)
+
+	// MarshalJSON round-trips through UnmarshalJSON.
+	for _, in := range []null.Duration{
+		null.NewDuration(durationValue),
+		null.NewDuration(-time.Nanosecond),
+		null.NewDuration(0),
+		null.NullDuration(),
+	} {
+		data, err := json.Marshal(in)
+		require.NoError(err)
+		var out null.Duration
+		err = json.Unmarshal(data, &out)
+		require.NoError(err)
+		require.Equal(in, out)
+	}
+}
+
+func TestDurationMarshalMapValue(t *testing.T) {
+	require := require.New(t)
+	type Wrapper struct{ Duration null.Duration }
+	var wrapper Wrapper
+	var data map[string]interface{}
+	var err error
+
+	wrapper = Wrapper{null.NewDuration(durationValue)}
+	data, err = maps.Marshal(wrapper)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{"Duration": durationValue}, data)
+
+	// Null Durations should be encoded as "nil"
+	wrapper = Wrapper{null.Duration{}}
+	data, err = maps.Marshal(wrapper)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{"Duration": nil}, data)
+}
@@ -0,0 +1,214 @@
+package null
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+)
+
+// Float32 is a nullable wrapper around the float32 type that implements all of
+// the pyrrho/encoding/types interfaces detailed in the package comments.
+//
+// If the Float32 is valid and contains 0, it will be considered non-nil, and of
+// zero value.
+type Float32 struct {
+	Float32 float32
+	Valid   bool
+}
+
+// Constructors
+
+// NullFloat32 constructs and returns a new null Float32.
+func NullFloat32() Float32 {
+	return Float32{
+		Float32: 0.0,
+		Valid:   false,
+	}
+}
+
+// NewFloat32 constructs and returns a new, valid Float32 initialized with the
+// value of the given f.
+func NewFloat32(f float32) Float32 {
+	return Float32{
+		Float32: f,
+		Valid:   true,
+	}
+}
+
+// Getters and Setters
+
+// ValueOrZero returns the value of f if it is valid; otherwise it returns the
+// zero value for a float32 (0.0).
+func (f Float32) ValueOrZero() float32 {
+	if !f.Valid {
+		return 0.0
+	}
+	return f.Float32
+}
+
+// Set modifies the value stored in f, and guarantees it is valid.
+func (f *Float32) Set(v float32) {
+	f.Float32 = v
+	f.Valid = true
+}
+
+// Null marks f as null with no meaningful value.
+func (f *Float32) Null() {
+	f.Float32 = 0.0
+	f.Valid = false
+}
+
+// Interface
+
+// IsNil implements the pyrrho/encoding IsNiler interface. It will return true
+// if f is null.
+func (f Float32) IsNil() bool {
+	return !f.Valid
+}
+
+// IsZero implements the pyrrho/encoding IsZeroer interface. It will return true
+// if f is null or if its value is 0.
+func (f Float32) IsZero() bool {
+	return !f.Valid || f.Float32 == 0.0
+}
+
+// Value implements the database/sql/driver Valuer interface. Nil is a valid
+// type to be stored in a driver.Value, but float32 isn't, so if this Float32 is
+// valid it will promote its float32 to a float64.
+func (f Float32) Value() (driver.Value, error) {
+	if !f.Valid {
+		return nil, nil
+	}
+	return float64(f.Float32), nil
+}
+
+// Scan implements the database/sql Scanner interface. It will receive a value
+// from an SQL database and assign it to f, so long as the provided data is of
+// type nil, float32, string, []byte, or a float64 or integer that can be
+// represented exactly as a float32. Strings and []bytes are parsed as decimal
+// text, and rounded to the nearest float32. All other types will result in an
+// error.
+func (f *Float32) Scan(src interface{}) error {
+	if f == nil {
+		return fmt.Errorf("null.Float32: Scan called on nil pointer")
+	}
+	switch val := src.(type) {
+	case nil:
+		f.Float32 = 0
+		f.Valid = false
+		return nil
+	case float32:
+		f.Float32 = val
+		f.Valid = true
+		return nil
+	case float64:
+		if float64(float32(val)) != val && !math.IsNaN(val) {
+			return fmt.Errorf("null.Float32: failed to scan type %T (%v): loss of precision", src, src)
+		}
+		f.Float32 = float32(val)
+		f.Valid = true
+		return nil
+	case int, int8, int16, int32, int64:
+		vi := reflect.ValueOf(src).Int()
+		if int64(float32(vi)) != vi {
+			return fmt.Errorf("null.Float32: failed to scan type %T (%v): loss of precision", src, src)
+		}
+		f.Float32 = float32(vi)
+		f.Valid = true
+		return nil
+	case uint, uint8, uint16, uint32, uint64:
+		vi := reflect.ValueOf(src).Uint()
+		if uint64(float32(vi)) != vi {
+			return fmt.Errorf("null.Float32: failed to scan type %T (%v): loss of precision", src, src)
+		}
+		f.Float32 = float32(vi)
+		f.Valid = true
+		return nil
+	case string:
+		parsed, err := strconv.ParseFloat(val, 32)
+		if err != nil {
+			return fmt.Errorf("null.Float32: failed to scan type %T (%v): %v", src, src, err)
+		}
+		f.Float32 = float32(parsed)
+		f.Valid = true
+		return nil
+	case []byte:
+		parsed, err := strconv.ParseFloat(string(val), 32)
+		if err != nil {
+			return fmt.Errorf("null.Float32: failed to scan type %T (%v): %v", src, src, err)
+		}
+		f.Float32 = float32(parsed)
+		f.Valid = true
+		return nil
+	default:
+		return fmt.Errorf("null.Float32: cannot scan type %T (%v)", src, src)
+	}
+}
+
+// MarshalJSON implements the encoding/json Marshaler interface. It will attempt
+// to encode f into its JSON representation if valid. If the contained value is
+// +/-INF or NaN, a json.UnsupportedValueError will be returned. If f is not
+// valid, it will encode to 'null'.
+func (f Float32) MarshalJSON() ([]byte, error) {
+	if !f.Valid {
+		return []byte("null"), nil
+	}
+	v := float64(f.Float32)
+	if math.IsInf(v, 0) || math.IsNaN(v) {
+		return nil, &json.UnsupportedValueError{
+			Value: reflect.ValueOf(f.Float32),
+			Str:   strconv.FormatFloat(v, 'g', -1, 32),
+		}
+	}
+	return []byte(strconv.FormatFloat(v, 'f', -1, 32)), nil
+}
+
+// UnmarshalJSON implements the encoding/json Unmarshaler interface. It will
+// decode a given []byte into f, so long as the provided []byte is a valid JSON
+// representation of a float within the range of a float32, or null. The 'null'
+// keyword will decode into a null Float32.
+//
+// If the decode fails, the value of f will be unchanged.
+func (f *Float32) UnmarshalJSON(data []byte) error {
+	if f == nil {
+		return fmt.Errorf("null.Float32: UnmarshalJSON called on nil pointer")
+	}
+	var j interface{}
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+	switch val := j.(type) {
+	case float64:
+		// Perform a second unmarshal, this time into a float32. This gives
+		// the JSON parser a chance to meaningfully fail (eg. if the value is
+		// out of the range of a float32).
+		var tmp float32
+		err := json.Unmarshal(data, &tmp)
+		if err != nil {
+			return err
+		}
+		f.Float32 = tmp
+		f.Valid = true
+		return nil
+	case nil:
+		f.Float32 = 0
+		f.Valid = false
+		return nil
+	default:
+		return fmt.Errorf("null.Float32: cannot unmarshal JSON of type %T (%v)",
+			val, data)
+	}
+}
+
+// MarshalMapValue implements the pyrrho/encoding/maps Marshaler interface. It
+// will encode f into its interface{} representation for use in a
+// map[string]interface{} if valid, or return nil otherwise.
+func (f Float32) MarshalMapValue() (interface{}, error) {
+	if f.Valid {
+		return f.Float32, nil
+	}
+	return nil, nil
+}
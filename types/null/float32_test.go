@@ -0,0 +1,332 @@
+package null_test
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/pyrrho/encoding/maps"
+	"github.com/pyrrho/encoding/types/null"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFloat32Ctors(t *testing.T) {
+	require := require.New(t)
+
+	// null.NullFloat32() returns a new null null.Float32.
+	// This is equivalent to null.Float32{}.
+	nul := null.NullFloat32()
+	require.False(nul.Valid)
+
+	empty := null.Float32{}
+	require.False(empty.Valid)
+
+	// null.NewFloat32 constructs a new, valid null.Float32.
+	f := null.NewFloat32(1.25)
+	require.True(f.Valid)
+	require.Equal(float32(1.25), f.Float32)
+
+	z := null.NewFloat32(0)
+	require.True(z.Valid)
+	require.Equal(float32(0), z.Float32)
+}
+
+func TestFloat32ValueOrZero(t *testing.T) {
+	require := require.New(t)
+
+	f := null.NewFloat32(1.25)
+	require.Equal(float32(1.25), f.ValueOrZero())
+
+	nul := null.Float32{}
+	require.Equal(float32(0), nul.ValueOrZero())
+}
+
+func TestFloat32Set(t *testing.T) {
+	require := require.New(t)
+
+	f := null.Float32{}
+	require.False(f.Valid)
+
+	f.Set(1.25)
+	require.True(f.Valid)
+	require.Equal(float32(1.25), f.Float32)
+
+	f.Set(0)
+	require.True(f.Valid)
+	require.Equal(float32(0), f.Float32)
+}
+
+func TestFloat32Null(t *testing.T) {
+	require := require.New(t)
+
+	f := null.NewFloat32(1.25)
+
+	f.Null()
+	require.False(f.Valid)
+}
+
+func TestFloat32IsNil(t *testing.T) {
+	require := require.New(t)
+
+	f := null.NewFloat32(1.25)
+	require.False(f.IsNil())
+
+	z := null.NewFloat32(0)
+	require.False(z.IsNil())
+
+	nul := null.Float32{}
+	require.True(nul.IsNil())
+}
+
+func TestFloat32IsZero(t *testing.T) {
+	require := require.New(t)
+
+	f := null.NewFloat32(1.25)
+	require.False(f.IsZero())
+
+	z := null.NewFloat32(0)
+	require.True(z.IsZero())
+
+	nul := null.Float32{}
+	require.True(nul.IsZero())
+}
+
+func TestFloat32SQLValue(t *testing.T) {
+	require := require.New(t)
+	var val driver.Value
+	var err error
+
+	// float32s are promoted to float64s.
+	f := null.NewFloat32(1.25)
+	val, err = f.Value()
+	require.NoError(err)
+	require.Equal(1.25, val)
+
+	inexact := null.NewFloat32(1.2345)
+	val, err = inexact.Value()
+	require.NoError(err)
+	require.Equal(float64(float32(1.2345)), val)
+
+	zero := null.NewFloat32(0)
+	val, err = zero.Value()
+	require.NoError(err)
+	require.Equal(0.0, val)
+
+	nul := null.Float32{}
+	val, err = nul.Value()
+	require.NoError(err)
+	require.Equal(nil, val)
+}
+
+func TestFloat32SQLScan(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	var f null.Float32
+	err = f.Scan(float32(1.2345))
+	require.NoError(err)
+	require.True(f.Valid)
+	require.Equal(float32(1.2345), f.Float32)
+
+	var f64 null.Float32
+	// float64s that are exactly representable as float32s are accepted ...
+	err = f64.Scan(float64(float32(1.2345)))
+	require.NoError(err)
+	require.True(f64.Valid)
+	require.Equal(float32(1.2345), f64.Float32)
+
+	var lossy null.Float32
+	// ... but those that would lose precision are not.
+	err = lossy.Scan(1.2345)
+	require.Error(err)
+	require.Contains(err.Error(), "loss of precision")
+	require.False(lossy.Valid)
+
+	var i null.Float32
+	err = i.Scan(12345)
+	require.NoError(err)
+	require.True(i.Valid)
+	require.Equal(float32(12345), i.Float32)
+
+	var bigInt null.Float32
+	// float32s have a 24 bit significand.
+	err = bigInt.Scan(1<<24 + 1)
+	require.Error(err)
+	require.Contains(err.Error(), "loss of precision")
+
+	var f32Str null.Float32
+	// NB. Scan will coerce strings, but UnmarshalJSON won't. Decimal strings
+	// are rounded to the nearest float32.
+	err = f32Str.Scan("1.2345")
+	require.NoError(err)
+	require.True(f32Str.Valid)
+	require.Equal(float32(1.2345), f32Str.Float32)
+
+	var f32Bytes null.Float32
+	err = f32Bytes.Scan([]byte("1.2345"))
+	require.NoError(err)
+	require.True(f32Bytes.Valid)
+	require.Equal(float32(1.2345), f32Bytes.Float32)
+
+	var nul null.Float32
+	err = nul.Scan(nil)
+	require.NoError(err)
+	require.False(nul.Valid)
+
+	var wrong null.Float32
+	err = wrong.Scan("hello world")
+	require.Error(err)
+
+	var overflow null.Float32
+	err = overflow.Scan("1e39")
+	require.Error(err)
+
+	var b null.Float32
+	err = b.Scan(true)
+	require.Error(err)
+}
+
+func TestFloat32MarshalJSON(t *testing.T) {
+	require := require.New(t)
+	var data []byte
+	var err error
+
+	f := null.NewFloat32(1.2345)
+	data, err = json.Marshal(f)
+	require.NoError(err)
+	require.EqualValues("1.2345", data)
+	data, err = json.Marshal(&f)
+	require.NoError(err)
+	require.EqualValues("1.2345", data)
+
+	i := null.NewFloat32(12345)
+	data, err = json.Marshal(i)
+	require.NoError(err)
+	require.EqualValues("12345", data)
+	data, err = json.Marshal(&i)
+	require.NoError(err)
+	require.EqualValues("12345", data)
+
+	zero := null.NewFloat32(0)
+	data, err = json.Marshal(zero)
+	require.NoError(err)
+	require.EqualValues("0", data)
+	data, err = json.Marshal(&zero)
+	require.NoError(err)
+	require.EqualValues("0", data)
+
+	nul := null.Float32{}
+	data, err = json.Marshal(nul)
+	require.NoError(err)
+	require.EqualValues("null", data)
+	data, err = json.Marshal(&nul)
+	require.NoError(err)
+	require.EqualValues("null", data)
+
+	nan := null.NewFloat32(float32(math.NaN()))
+	_, err = json.Marshal(nan)
+	require.Error(err)
+	_, err = nan.MarshalJSON()
+	_, ok := err.(*json.UnsupportedValueError)
+	require.True(ok)
+
+	inf := null.NewFloat32(float32(math.Inf(1)))
+	_, err = json.Marshal(inf)
+	require.Error(err)
+	_, err = inf.MarshalJSON()
+	_, ok = err.(*json.UnsupportedValueError)
+	require.True(ok)
+
+	ninf := null.NewFloat32(float32(math.Inf(-1)))
+	_, err = json.Marshal(&ninf)
+	require.Error(err)
+	_, err = ninf.MarshalJSON()
+	_, ok = err.(*json.UnsupportedValueError)
+	require.True(ok)
+}
+
+func TestFloat32UnmarshalJSON(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	// Successful Valid Parses
+
+	var f null.Float32
+	err = json.Unmarshal([]byte("1.2345"), &f)
+	require.NoError(err)
+	require.True(f.Valid)
+	require.Equal(float32(1.2345), f.Float32)
+
+	var i null.Float32
+	err = json.Unmarshal([]byte("12345"), &i)
+	require.NoError(err)
+	require.True(i.Valid)
+	require.Equal(float32(12345), i.Float32)
+
+	// Successful Null Parses
+
+	var nul null.Float32
+	err = json.Unmarshal([]byte("null"), &nul)
+	require.NoError(err)
+	require.False(nul.Valid)
+
+	// Unsuccessful Parses
+
+	var f32Str null.Float32
+	// Floats wrapped in quotes aren't floats.
+	err = json.Unmarshal([]byte(`"1.2345"`), &f32Str)
+	require.Error(err)
+
+	var empty null.Float32
+	err = json.Unmarshal([]byte(""), &empty)
+	require.Error(err)
+
+	var badType null.Float32
+	// Booleans are never floats.
+	err = json.Unmarshal([]byte("true"), &badType)
+	require.Error(err)
+
+	var overflow null.Float32
+	// Valid float64s may be out of the range of a float32.
+	err = json.Unmarshal([]byte("1e39"), &overflow)
+	require.Error(err)
+	require.False(overflow.Valid)
+
+	var nan null.Float32
+	err = json.Unmarshal([]byte("NaN"), &nan)
+	require.Error(err)
+
+	var invalid null.Float32
+	err = invalid.UnmarshalJSON([]byte(":->"))
+	if _, ok := err.(*json.SyntaxError); !ok {
+		require.FailNowf(
+			"Unexpected Error Type",
+			"expected *json.SyntaxError, not %T", err)
+	}
+}
+
+func TestFloat32MarshalMapValue(t *testing.T) {
+	require := require.New(t)
+	type Wrapper struct{ Float32 null.Float32 }
+	var wrapper Wrapper
+	var data map[string]interface{}
+	var err error
+
+	wrapper = Wrapper{null.NewFloat32(1.25)}
+	data, err = maps.Marshal(wrapper)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{"Float32": float32(1.25)}, data)
+	data, err = maps.Marshal(&wrapper)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{"Float32": float32(1.25)}, data)
+
+	// Null Float32s should be encoded as "nil"
+	wrapper = Wrapper{null.Float32{}}
+	data, err = maps.Marshal(wrapper)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{"Float32": nil}, data)
+	data, err = maps.Marshal(&wrapper)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{"Float32": nil}, data)
+}
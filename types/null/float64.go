@@ -123,6 +123,38 @@ func (f *Float64) UnmarshalJSON(data []byte) error {
 	}
 }
 
+// MarshalText implements the encoding TextMarshaler interface. It will encode
+// f into the shortest text representation that will parse back into the same
+// value if valid, or an empty []byte otherwise. +/-INF and NaN are encoded as
+// "+Inf", "-Inf", and "NaN".
+func (f Float64) MarshalText() ([]byte, error) {
+	if !f.Valid {
+		return []byte{}, nil
+	}
+	return []byte(strconv.FormatFloat(f.Float64, 'g', -1, 64)), nil
+}
+
+// UnmarshalText implements the encoding TextUnmarshaler interface. It will
+// decode a floating point number into f. Empty text will decode into a null
+// Float64.
+//
+// If the decode fails, the value of f will be unchanged.
+func (f *Float64) UnmarshalText(text []byte) error {
+	if f == nil {
+		return fmt.Errorf("null.Float64: UnmarshalText called on nil pointer")
+	}
+	if len(text) == 0 {
+		f.Null()
+		return nil
+	}
+	v, err := strconv.ParseFloat(string(text), 64)
+	if err != nil {
+		return err
+	}
+	f.Set(v)
+	return nil
+}
+
 // MarshalMapValue implements the pyrrho/encoding/maps Marshaler interface. It
 // will encode f into its interface{} representation for use in a
 // map[string]interface{} if valid, or return nil otherwise.
@@ -273,6 +273,54 @@ func TestFloat64UnmarshalJSON(t *testing.T) {
 	}
 }
 
+func TestFloat64MarshalText(t *testing.T) {
+	require := require.New(t)
+
+	data, err := null.NewFloat64(1.2345).MarshalText()
+	require.NoError(err)
+	require.EqualValues("1.2345", data)
+
+	data, err = null.NewFloat64(0).MarshalText()
+	require.NoError(err)
+	require.EqualValues("0", data)
+
+	data, err = null.NewFloat64(math.Inf(-1)).MarshalText()
+	require.NoError(err)
+	require.EqualValues("-Inf", data)
+
+	// Null values encode to empty text, distinct from the text of 0.
+	data, err = null.Float64{}.MarshalText()
+	require.NoError(err)
+	require.NotNil(data)
+	require.Len(data, 0)
+}
+
+func TestFloat64UnmarshalText(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	var f null.Float64
+	err = f.UnmarshalText([]byte("1.2345"))
+	require.NoError(err)
+	require.Equal(null.NewFloat64(1.2345), f)
+
+	err = f.UnmarshalText([]byte("-Inf"))
+	require.NoError(err)
+	require.True(math.IsInf(f.Float64, -1))
+
+	// Empty text decodes to null.
+	err = f.UnmarshalText([]byte(""))
+	require.NoError(err)
+	require.False(f.Valid)
+
+	f = null.NewFloat64(1)
+	err = f.UnmarshalText([]byte("one"))
+	require.Error(err)
+	err = f.UnmarshalText([]byte("null"))
+	require.Error(err)
+	require.Equal(null.NewFloat64(1), f)
+}
+
 func TestFloat64MarshalMapValue(t *testing.T) {
 	require := require.New(t)
 	type Wrapper struct{ Float64 null.Float64 }
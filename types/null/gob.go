@@ -0,0 +1,592 @@
+package null
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/pyrrho/encoding/types"
+)
+
+// This file implements the encoding/gob GobEncoder and GobDecoder interfaces
+// for each of the null types. Left to its own devices, gob encodes the exported
+// fields of a struct, and omits those holding zero values; decoding a null value
+// over a valid one would then leave the destination's Valid flag untouched. The
+// GobEncode methods instead always encode the Valid flag, followed by the value
+// if valid, and the GobDecode methods always overwrite both.
+//
+// NB. gob still omits struct fields holding zero values -- including zero-valued
+// null values -- before GobEncode is consulted. As gob itself recommends, values
+// containing null types should be decoded into zero-valued destinations.
+
+// GobEncode implements the encoding/gob GobEncoder interface.
+func (b Bool) GobEncode() ([]byte, error) {
+	return gobEncodeNull(b.Valid, b.Bool)
+}
+
+// GobDecode implements the encoding/gob GobDecoder interface. If the decode
+// fails, the value of b will be unchanged.
+func (b *Bool) GobDecode(data []byte) error {
+	var v bool
+	valid, err := gobDecodeNull(data, &v)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		b.Null()
+		return nil
+	}
+	b.Set(v)
+	return nil
+}
+
+// GobEncode implements the encoding/gob GobEncoder interface.
+func (d Duration) GobEncode() ([]byte, error) {
+	return gobEncodeNull(d.Valid, d.Duration)
+}
+
+// GobDecode implements the encoding/gob GobDecoder interface. If the decode
+// fails, the value of d will be unchanged.
+func (d *Duration) GobDecode(data []byte) error {
+	var v time.Duration
+	valid, err := gobDecodeNull(data, &v)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		d.Null()
+		return nil
+	}
+	d.Set(v)
+	return nil
+}
+
+// GobEncode implements the encoding/gob GobEncoder interface.
+func (f Float32) GobEncode() ([]byte, error) {
+	return gobEncodeNull(f.Valid, f.Float32)
+}
+
+// GobDecode implements the encoding/gob GobDecoder interface. If the decode
+// fails, the value of f will be unchanged.
+func (f *Float32) GobDecode(data []byte) error {
+	var v float32
+	valid, err := gobDecodeNull(data, &v)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		f.Null()
+		return nil
+	}
+	f.Set(v)
+	return nil
+}
+
+// GobEncode implements the encoding/gob GobEncoder interface.
+func (f Float64) GobEncode() ([]byte, error) {
+	return gobEncodeNull(f.Valid, f.Float64)
+}
+
+// GobDecode implements the encoding/gob GobDecoder interface. If the decode
+// fails, the value of f will be unchanged.
+func (f *Float64) GobDecode(data []byte) error {
+	var v float64
+	valid, err := gobDecodeNull(data, &v)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		f.Null()
+		return nil
+	}
+	f.Set(v)
+	return nil
+}
+
+// GobEncode implements the encoding/gob GobEncoder interface.
+func (i Int) GobEncode() ([]byte, error) {
+	return gobEncodeNull(i.Valid, i.Int)
+}
+
+// GobDecode implements the encoding/gob GobDecoder interface. If the decode
+// fails, the value of i will be unchanged.
+func (i *Int) GobDecode(data []byte) error {
+	var v int
+	valid, err := gobDecodeNull(data, &v)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		i.Null()
+		return nil
+	}
+	i.Set(v)
+	return nil
+}
+
+// GobEncode implements the encoding/gob GobEncoder interface.
+func (i Int8) GobEncode() ([]byte, error) {
+	return gobEncodeNull(i.Valid, i.Int8)
+}
+
+// GobDecode implements the encoding/gob GobDecoder interface. If the decode
+// fails, the value of i will be unchanged.
+func (i *Int8) GobDecode(data []byte) error {
+	var v int8
+	valid, err := gobDecodeNull(data, &v)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		i.Null()
+		return nil
+	}
+	i.Set(v)
+	return nil
+}
+
+// GobEncode implements the encoding/gob GobEncoder interface.
+func (i Int16) GobEncode() ([]byte, error) {
+	return gobEncodeNull(i.Valid, i.Int16)
+}
+
+// GobDecode implements the encoding/gob GobDecoder interface. If the decode
+// fails, the value of i will be unchanged.
+func (i *Int16) GobDecode(data []byte) error {
+	var v int16
+	valid, err := gobDecodeNull(data, &v)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		i.Null()
+		return nil
+	}
+	i.Set(v)
+	return nil
+}
+
+// GobEncode implements the encoding/gob GobEncoder interface.
+func (i Int32) GobEncode() ([]byte, error) {
+	return gobEncodeNull(i.Valid, i.Int32)
+}
+
+// GobDecode implements the encoding/gob GobDecoder interface. If the decode
+// fails, the value of i will be unchanged.
+func (i *Int32) GobDecode(data []byte) error {
+	var v int32
+	valid, err := gobDecodeNull(data, &v)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		i.Null()
+		return nil
+	}
+	i.Set(v)
+	return nil
+}
+
+// GobEncode implements the encoding/gob GobEncoder interface.
+func (i Int64) GobEncode() ([]byte, error) {
+	return gobEncodeNull(i.Valid, i.Int64)
+}
+
+// GobDecode implements the encoding/gob GobDecoder interface. If the decode
+// fails, the value of i will be unchanged.
+func (i *Int64) GobDecode(data []byte) error {
+	var v int64
+	valid, err := gobDecodeNull(data, &v)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		i.Null()
+		return nil
+	}
+	i.Set(v)
+	return nil
+}
+
+// GobEncode implements the encoding/gob GobEncoder interface.
+func (s String) GobEncode() ([]byte, error) {
+	return gobEncodeNull(s.Valid, s.String)
+}
+
+// GobDecode implements the encoding/gob GobDecoder interface. If the decode
+// fails, the value of s will be unchanged.
+func (s *String) GobDecode(data []byte) error {
+	var v string
+	valid, err := gobDecodeNull(data, &v)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		s.Null()
+		return nil
+	}
+	s.Set(v)
+	return nil
+}
+
+// GobEncode implements the encoding/gob GobEncoder interface.
+func (t Time) GobEncode() ([]byte, error) {
+	return gobEncodeNull(t.Valid, t.Time)
+}
+
+// GobDecode implements the encoding/gob GobDecoder interface. If the decode
+// fails, the value of t will be unchanged.
+func (t *Time) GobDecode(data []byte) error {
+	var v time.Time
+	valid, err := gobDecodeNull(data, &v)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		t.Null()
+		return nil
+	}
+	t.Set(v)
+	return nil
+}
+
+// GobEncode implements the encoding/gob GobEncoder interface.
+func (i Uint) GobEncode() ([]byte, error) {
+	return gobEncodeNull(i.Valid, i.Uint)
+}
+
+// GobDecode implements the encoding/gob GobDecoder interface. If the decode
+// fails, the value of i will be unchanged.
+func (i *Uint) GobDecode(data []byte) error {
+	var v uint
+	valid, err := gobDecodeNull(data, &v)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		i.Null()
+		return nil
+	}
+	i.Set(v)
+	return nil
+}
+
+// GobEncode implements the encoding/gob GobEncoder interface.
+func (i Uint8) GobEncode() ([]byte, error) {
+	return gobEncodeNull(i.Valid, i.Uint8)
+}
+
+// GobDecode implements the encoding/gob GobDecoder interface. If the decode
+// fails, the value of i will be unchanged.
+func (i *Uint8) GobDecode(data []byte) error {
+	var v uint8
+	valid, err := gobDecodeNull(data, &v)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		i.Null()
+		return nil
+	}
+	i.Set(v)
+	return nil
+}
+
+// GobEncode implements the encoding/gob GobEncoder interface.
+func (i Uint16) GobEncode() ([]byte, error) {
+	return gobEncodeNull(i.Valid, i.Uint16)
+}
+
+// GobDecode implements the encoding/gob GobDecoder interface. If the decode
+// fails, the value of i will be unchanged.
+func (i *Uint16) GobDecode(data []byte) error {
+	var v uint16
+	valid, err := gobDecodeNull(data, &v)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		i.Null()
+		return nil
+	}
+	i.Set(v)
+	return nil
+}
+
+// GobEncode implements the encoding/gob GobEncoder interface.
+func (i Uint32) GobEncode() ([]byte, error) {
+	return gobEncodeNull(i.Valid, i.Uint32)
+}
+
+// GobDecode implements the encoding/gob GobDecoder interface. If the decode
+// fails, the value of i will be unchanged.
+func (i *Uint32) GobDecode(data []byte) error {
+	var v uint32
+	valid, err := gobDecodeNull(data, &v)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		i.Null()
+		return nil
+	}
+	i.Set(v)
+	return nil
+}
+
+// GobEncode implements the encoding/gob GobEncoder interface.
+func (i Uint64) GobEncode() ([]byte, error) {
+	return gobEncodeNull(i.Valid, i.Uint64)
+}
+
+// GobDecode implements the encoding/gob GobDecoder interface. If the decode
+// fails, the value of i will be unchanged.
+func (i *Uint64) GobDecode(data []byte) error {
+	var v uint64
+	valid, err := gobDecodeNull(data, &v)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		i.Null()
+		return nil
+	}
+	i.Set(v)
+	return nil
+}
+
+// GobEncode implements the encoding/gob GobEncoder interface.
+func (u UUID) GobEncode() ([]byte, error) {
+	return gobEncodeNull(u.Valid, u.UUID)
+}
+
+// GobDecode implements the encoding/gob GobDecoder interface. If the decode
+// fails, the value of u will be unchanged.
+func (u *UUID) GobDecode(data []byte) error {
+	var v [16]byte
+	valid, err := gobDecodeNull(data, &v)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		u.Null()
+		return nil
+	}
+	u.Set(v)
+	return nil
+}
+
+// GobEncode implements the encoding/gob GobEncoder interface. b's Mode is
+// encoded after its value.
+func (b ByteSlice) GobEncode() ([]byte, error) {
+	data, err := gobEncodeNull(b.Valid, b.ByteSlice)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(b.Mode); err != nil {
+		return nil, err
+	}
+	return append(data, buf.Bytes()...), nil
+}
+
+// GobDecode implements the encoding/gob GobDecoder interface. The encoded Mode
+// replaces b's; data encoded before Mode was, which lacks one, leaves b's Mode
+// unchanged. If the decode fails, the value of b will be unchanged.
+func (b *ByteSlice) GobDecode(data []byte) error {
+	dec := gob.NewDecoder(bytes.NewReader(data))
+	var valid bool
+	if err := dec.Decode(&valid); err != nil {
+		return err
+	}
+	var v []byte
+	if valid {
+		if err := dec.Decode(&v); err != nil {
+			return err
+		}
+	}
+	m := b.Mode
+	if err := dec.Decode(&m); err != nil && err != io.EOF {
+		return err
+	}
+	b.Mode = m
+	if !valid {
+		b.Null()
+		return nil
+	}
+	// gob decodes empty slices as nil, which Set would treat as null.
+	b.Set(append([]byte{}, v...))
+	return nil
+}
+
+// GobEncode implements the encoding/gob GobEncoder interface.
+func (j RawJSON) GobEncode() ([]byte, error) {
+	return gobEncodeNull(j.Valid, []byte(j.JSON))
+}
+
+// GobDecode implements the encoding/gob GobDecoder interface. If the decode
+// fails, the value of j will be unchanged.
+func (j *RawJSON) GobDecode(data []byte) error {
+	var v []byte
+	valid, err := gobDecodeNull(data, &v)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		j.Null()
+		return nil
+	}
+	// NB. Set would null an empty document, which may be valid here.
+	j.JSON = types.RawJSON(v)
+	j.Valid = true
+	return nil
+}
+
+// GobEncode implements the encoding/gob GobEncoder interface. The geometry is
+// encoded as GeoJSON, as the underlying go-geom types cannot be gob encoded.
+func (p SFPoint) GobEncode() ([]byte, error) {
+	return gobEncodeGeometry(p.Valid, p.Point)
+}
+
+// GobDecode implements the encoding/gob GobDecoder interface. If the decode
+// fails, the value of p will be unchanged.
+func (p *SFPoint) GobDecode(data []byte) error {
+	var v types.SFPoint
+	valid, err := gobDecodeGeometry(data, &v)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		p.Null()
+		return nil
+	}
+	p.Set(v)
+	return nil
+}
+
+// GobEncode implements the encoding/gob GobEncoder interface. The geometry is
+// encoded as GeoJSON, as the underlying go-geom types cannot be gob encoded.
+func (p SFPolygon) GobEncode() ([]byte, error) {
+	return gobEncodeGeometry(p.Valid, p.Polygon)
+}
+
+// GobDecode implements the encoding/gob GobDecoder interface. If the decode
+// fails, the value of p will be unchanged.
+func (p *SFPolygon) GobDecode(data []byte) error {
+	var v types.SFPolygon
+	valid, err := gobDecodeGeometry(data, &v)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		p.Null()
+		return nil
+	}
+	p.Set(v)
+	return nil
+}
+
+// GobEncode implements the encoding/gob GobEncoder interface. The geometry is
+// encoded as GeoJSON, as the underlying go-geom types cannot be gob encoded.
+func (p SFMultiPolygon) GobEncode() ([]byte, error) {
+	return gobEncodeGeometry(p.Valid, p.MultiPolygon)
+}
+
+// GobDecode implements the encoding/gob GobDecoder interface. If the decode
+// fails, the value of p will be unchanged.
+func (p *SFMultiPolygon) GobDecode(data []byte) error {
+	var v types.SFMultiPolygon
+	valid, err := gobDecodeGeometry(data, &v)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		p.Null()
+		return nil
+	}
+	p.Set(v)
+	return nil
+}
+
+// GobEncode implements the encoding/gob GobEncoder interface. The geometry is
+// encoded as GeoJSON, as the underlying go-geom types cannot be gob encoded.
+func (gc SFGeometryCollection) GobEncode() ([]byte, error) {
+	return gobEncodeGeometry(gc.Valid, gc.GeometryCollection)
+}
+
+// GobDecode implements the encoding/gob GobDecoder interface. If the decode
+// fails, the value of gc will be unchanged.
+func (gc *SFGeometryCollection) GobDecode(data []byte) error {
+	var v types.SFGeometryCollection
+	valid, err := gobDecodeGeometry(data, &v)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		gc.Null()
+		return nil
+	}
+	gc.Set(v)
+	return nil
+}
+
+// gobEncodeNull gob encodes valid, followed by v if valid is true.
+func gobEncodeNull(valid bool, v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := gob.NewEncoder(&buf)
+	if err := enc.Encode(valid); err != nil {
+		return nil, err
+	}
+	if valid {
+		if err := enc.Encode(v); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// gobDecodeNull decodes data, as encoded by gobEncodeNull, returning the encoded
+// Valid flag. If that flag is true, the encoded value is decoded into v.
+func gobDecodeNull(data []byte, v interface{}) (bool, error) {
+	dec := gob.NewDecoder(bytes.NewReader(data))
+	var valid bool
+	if err := dec.Decode(&valid); err != nil {
+		return false, err
+	}
+	if !valid {
+		return false, nil
+	}
+	if err := dec.Decode(v); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// gobEncodeGeometry is gobEncodeNull for the geospatial types, which are carried
+// as GeoJSON.
+func gobEncodeGeometry(valid bool, g json.Marshaler) ([]byte, error) {
+	if !valid {
+		return gobEncodeNull(false, nil)
+	}
+	b, err := g.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return gobEncodeNull(true, b)
+}
+
+// gobDecodeGeometry is gobDecodeNull for the geospatial types, which are carried
+// as GeoJSON.
+func gobDecodeGeometry(data []byte, g json.Unmarshaler) (bool, error) {
+	var b []byte
+	valid, err := gobDecodeNull(data, &b)
+	if err != nil || !valid {
+		return false, err
+	}
+	if err := g.UnmarshalJSON(b); err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,125 @@
+package null_test
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+	"time"
+
+	"github.com/pyrrho/encoding/types/null"
+	"github.com/stretchr/testify/require"
+)
+
+type GobRecord struct {
+	Bool      null.Bool
+	Float64   null.Float64
+	Int64     null.Int64
+	Uint8     null.Uint8
+	String    null.String
+	Time      null.Time
+	Duration  null.Duration
+	ByteSlice null.ByteSlice
+	JSON      null.RawJSON
+	UUID      null.UUID
+	Point     null.SFPoint
+}
+
+func TestGobRoundTrip(t *testing.T) {
+	require := require.New(t)
+
+	in := []GobRecord{
+		// All null.
+		{},
+		// All valid, holding zero values.
+		{
+			Bool:      null.NewBool(false),
+			Float64:   null.NewFloat64(0),
+			Int64:     null.NewInt64(0),
+			Uint8:     null.NewUint8(0),
+			String:    null.NewString(""),
+			Time:      null.NewTime(time.Time{}),
+			Duration:  null.NewDuration(0),
+			ByteSlice: null.NewByteSlice([]byte{}),
+			JSON:      null.NewJSONStr(`null`),
+			UUID:      null.NewUUID([16]byte{}),
+			Point:     null.NewSFPointXY(0, 0),
+		},
+		// All valid, holding non-zero values.
+		{
+			Bool:      null.NewBool(true),
+			Float64:   null.NewFloat64(-1.5),
+			Int64:     null.NewInt64(42),
+			Uint8:     null.NewUint8(255),
+			String:    null.NewString("foo"),
+			Time:      null.NewTime(time.Date(2017, 4, 1, 12, 30, 0, 0, time.UTC)),
+			Duration:  null.NewDuration(time.Hour),
+			ByteSlice: null.NewByteSlice([]byte("bar")),
+			JSON:      null.NewJSONStr(`{"a":[1,2]}`),
+			UUID:      null.NewUUID([16]byte{1, 2, 3}),
+			Point:     null.NewSFPointXY(1.5, -2),
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(gob.NewEncoder(&buf).Encode(in))
+
+	var out []GobRecord
+	require.NoError(gob.NewDecoder(&buf).Decode(&out))
+	require.Len(out, 3)
+
+	require.Equal(in[0], out[0])
+	for _, i := range []int{1, 2} {
+		require.True(out[i].Bool.Valid)
+		require.True(out[i].Float64.Valid)
+		require.True(out[i].ByteSlice.Valid)
+		require.True(out[i].JSON.Valid)
+		require.True(out[i].Point.Valid)
+		require.Equal(in[i].Bool, out[i].Bool)
+		require.Equal(in[i].Float64, out[i].Float64)
+		require.Equal(in[i].Int64, out[i].Int64)
+		require.Equal(in[i].Uint8, out[i].Uint8)
+		require.Equal(in[i].String, out[i].String)
+		require.True(in[i].Time.Time.Equal(out[i].Time.Time))
+		require.Equal(in[i].Duration, out[i].Duration)
+		require.Equal(in[i].ByteSlice, out[i].ByteSlice)
+		require.Equal(in[i].JSON, out[i].JSON)
+		require.Equal(in[i].UUID, out[i].UUID)
+		require.Equal(in[i].Point.Point.Coords(), out[i].Point.Point.Coords())
+	}
+}
+
+func TestGobNullOverValid(t *testing.T) {
+	require := require.New(t)
+
+	// Top-level values are always sent, so GobDecode nulls valid values.
+	var buf bytes.Buffer
+	enc := gob.NewEncoder(&buf)
+	require.NoError(enc.Encode(null.NullFloat64()))
+	require.NoError(enc.Encode(null.NewFloat64(0)))
+
+	dec := gob.NewDecoder(&buf)
+	f := null.NewFloat64(1)
+	require.NoError(dec.Decode(&f))
+	require.False(f.Valid)
+	require.NoError(dec.Decode(&f))
+	require.Equal(null.NewFloat64(0), f)
+}
+
+func TestGobByteSliceMode(t *testing.T) {
+	require := require.New(t)
+
+	in := []null.ByteSlice{
+		null.NewByteSliceHex([]byte("foo")),
+		null.NewRawByteSlice([]byte{}),
+		null.NewByteSliceMode(nil, null.ByteSlicePostgresHex),
+	}
+	for _, b := range in {
+		var buf bytes.Buffer
+		require.NoError(gob.NewEncoder(&buf).Encode(b))
+		var out null.ByteSlice
+		require.NoError(gob.NewDecoder(&buf).Decode(&out))
+		require.Equal(b.Mode, out.Mode)
+		require.Equal(b.Valid, out.Valid)
+		require.Equal(b.ByteSlice, out.ByteSlice)
+	}
+}
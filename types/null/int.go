@@ -0,0 +1,240 @@
+package null
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// Int is a nullable wrapper around the platform-dependent int type that
+// implements all of the pyrrho/encoding/types interfaces detailed in the
+// package comments. Values are stored in the database as int64s, and Scan will
+// reject any value that would overflow an int on the current platform.
+//
+// If the Int is valid and contains 0, it will be considered non-nil, and of
+// zero value.
+type Int struct {
+	Int   int
+	Valid bool
+}
+
+// The bounds of the platform-dependent int type.
+const (
+	maxInt = 1<<(strconv.IntSize-1) - 1
+	minInt = -1 << (strconv.IntSize - 1)
+)
+
+// Constructors
+
+// NullInt constructs and returns a new null Int.
+func NullInt() Int {
+	return Int{
+		Int:   0,
+		Valid: false,
+	}
+}
+
+// NewInt constructs and returns a new, valid Int initialized with the value
+// of the given i.
+func NewInt(i int) Int {
+	return Int{
+		Int:   i,
+		Valid: true,
+	}
+}
+
+// NewIntFromPtr constructs and returns a new Int based on the given *int p. If
+// p is nil the new Int will be null. Otherwise a new, valid Int will be
+// initialized with the value pointed to by p.
+func NewIntFromPtr(p *int) Int {
+	if p == nil {
+		return NullInt()
+	}
+	return NewInt(*p)
+}
+
+// Getters and Setters
+
+// ValueOrZero returns the value of i if it is valid; otherwise it returns the
+// zero value for a int (0).
+func (i Int) ValueOrZero() int {
+	if !i.Valid {
+		return 0
+	}
+	return i.Int
+}
+
+// Ptr returns a pointer to a copy of the value of i if it is valid; otherwise
+// it returns nil.
+func (i Int) Ptr() *int {
+	if !i.Valid {
+		return nil
+	}
+	v := i.Int
+	return &v
+}
+
+// Set modifies the value stored in i, and guarantees it is valid.
+func (i *Int) Set(v int) {
+	i.Int = v
+	i.Valid = true
+}
+
+// Null marks i as null with no meaningful value.
+func (i *Int) Null() {
+	i.Int = 0
+	i.Valid = false
+}
+
+// Interfaces
+
+// IsNil implements the pyrrho/encoding IsNiler interface. It will return true
+// if i is null.
+func (i Int) IsNil() bool {
+	return !i.Valid
+}
+
+// IsZero implements the pyrrho/encoding IsZeroer interface. It will return true
+// if i is null or if its value is 0.
+func (i Int) IsZero() bool {
+	return !i.Valid || i.Int == 0
+}
+
+// Value implements the database/sql/driver Valuer interface. Nil is a valid
+// type to be stored in a driver.Value, but int isn't, so if this Int is
+// valid it will cast its int to an int64.
+func (i Int) Value() (driver.Value, error) {
+	if !i.Valid {
+		return nil, nil
+	}
+	return int64(i.Int), nil
+}
+
+// Scan implements the database/sql Scanner interface. It will receive a value
+// from an SQL database and assign it to i, so long as the provided data is of
+// type nil, int, string, or another integer or float type that doesn't
+// overflow int. All other types will result in an error.
+func (i *Int) Scan(src interface{}) error {
+	if i == nil {
+		return fmt.Errorf("null.Int: Scan called on nil pointer")
+	}
+	if src == nil {
+		i.Int = 0
+		i.Valid = false
+		return nil
+	}
+
+	switch val := src.(type) {
+	case int:
+		i.Int = val
+		i.Valid = true
+		return nil
+	case int8, int16, int32, int64:
+		v := reflect.ValueOf(src)
+		vi := v.Int()
+		if vi > maxInt || vi < minInt {
+			return fmt.Errorf("null.Int: failed to scan type %T (%v): overflow", src, src)
+		}
+		i.Int = int(vi)
+		i.Valid = true
+		return nil
+	case uint, uint8, uint16, uint32, uint64:
+		v := reflect.ValueOf(src)
+		vi := v.Uint()
+		if vi > maxInt {
+			return fmt.Errorf("null.Int: failed to scan type %T (%v): overflow", src, src)
+		}
+		i.Int = int(vi)
+		i.Valid = true
+		return nil
+	case string:
+		parsed, err := strconv.ParseInt(val, 10, 0)
+		if err != nil {
+			return fmt.Errorf("null.Int: failed to scan type %T (%v): %v", src, src, err)
+		}
+		i.Int = int(parsed)
+		i.Valid = true
+		return nil
+	case float64:
+		// Use a string intermediate so we can generate an error on any loss of
+		// precision.
+		s := strconv.FormatFloat(val, 'f', -1, 64)
+		parsed, err := strconv.ParseInt(s, 10, 0)
+		if err != nil {
+			return fmt.Errorf("null.Int: failed to convert driver.Value type %T (%v): %v", src, s, err)
+		}
+		i.Int = int(parsed)
+		i.Valid = true
+		return nil
+	case float32:
+		// Use a string intermediate so we can generate an error on any loss of
+		// precision.
+		s := strconv.FormatFloat(float64(val), 'f', -1, 32)
+		parsed, err := strconv.ParseInt(s, 10, 0)
+		if err != nil {
+			return fmt.Errorf("null.Int: failed to convert driver.Value type %T (%v): %v", src, s, err)
+		}
+		i.Int = int(parsed)
+		i.Valid = true
+		return nil
+	default:
+		return fmt.Errorf("null.Int: cannot scan type %T (%v)", src, src)
+	}
+}
+
+// MarshalJSON implements the encoding/json Marshaler interface. It will encode
+// i into its JSON representation if valid, or 'null' otherwise.
+func (i Int) MarshalJSON() ([]byte, error) {
+	if !i.Valid {
+		return []byte("null"), nil
+	}
+	return []byte(strconv.FormatInt(int64(i.Int), 10)), nil
+}
+
+// UnmarshalJSON implements the encoding/json Unmarshaler interface. It will
+// decode a given []byte into i, so long as the provided []byte is a valid JSON
+// representation of an int. The 'null' keyword will decode into a null Int.
+//
+// If the decode fails, the value of i will be unchanged.
+func (i *Int) UnmarshalJSON(data []byte) error {
+	if i == nil {
+		return fmt.Errorf("null.Int: UnmarshalJSON called on nil pointer")
+	}
+	var j interface{}
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+	switch val := j.(type) {
+	case float64:
+		// Perform a second unmarshal, this time into a int. This give the
+		// JSON parse a chance to meaningfully fail (eg. if the conversion from
+		// float to integer will result in a loss of precision).
+		var tmp int
+		err := json.Unmarshal(data, &tmp)
+		if err != nil {
+			return err
+		}
+		i.Int = tmp
+		i.Valid = true
+		return nil
+	case nil:
+		i.Int = 0
+		i.Valid = false
+		return nil
+	default:
+		return fmt.Errorf("null.Int: cannot unmarshal JSON of type %T (%v)",
+			val, data)
+	}
+}
+
+// MarshalMapValue implements the pyrrho/encoding/maps Marshaler interface. It
+// will encode i into its interface{} representation for use in a
+// map[string]interface{} if valid, or return nil otherwise.
+func (i Int) MarshalMapValue() (interface{}, error) {
+	if i.Valid {
+		return i.Int, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,333 @@
+package null_test
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/pyrrho/encoding/maps"
+	"github.com/pyrrho/encoding/types/null"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInt16Ctors(t *testing.T) {
+	require := require.New(t)
+
+	// null.NullInt16() returns a new null null.Int16.
+	// This is equivalent to null.Int16{}.
+	nul := null.NullInt16()
+	require.False(nul.Valid)
+
+	empty := null.Int16{}
+	require.False(empty.Valid)
+
+	// null.NewInt16 constructs a new, valid null.Int16.
+	i := null.NewInt16(12345)
+	require.True(i.Valid)
+	require.Equal(int16(12345), i.Int16)
+
+	z := null.NewInt16(0)
+	require.True(z.Valid)
+	require.Equal(int16(0), z.Int16)
+}
+
+func TestInt16ValueOrZero(t *testing.T) {
+	require := require.New(t)
+
+	valid := null.NewInt16(12345)
+	require.Equal(int16(12345), valid.ValueOrZero())
+
+	nul := null.Int16{}
+	require.Equal(int16(0), nul.ValueOrZero())
+}
+
+func TestInt16Set(t *testing.T) {
+	require := require.New(t)
+
+	i := null.Int16{}
+	require.False(i.Valid)
+
+	i.Set(12345)
+	require.True(i.Valid)
+	require.Equal(int16(12345), i.Int16)
+
+	i.Set(0)
+	require.True(i.Valid)
+	require.Equal(int16(0), i.Int16)
+}
+
+func TestInt16Null(t *testing.T) {
+	require := require.New(t)
+
+	i := null.NewInt16(12345)
+
+	i.Null()
+	require.False(i.Valid)
+}
+
+func TestInt16SQLScanBounds(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	var max null.Int16
+	err = max.Scan(int64(math.MaxInt16))
+	require.NoError(err)
+	require.Equal(int16(math.MaxInt16), max.Int16)
+
+	var min null.Int16
+	err = min.Scan(int64(math.MinInt16))
+	require.NoError(err)
+	require.Equal(int16(math.MinInt16), min.Int16)
+
+	var under null.Int16
+	err = under.Scan(int64(math.MinInt16) - 1)
+	require.Error(err)
+	require.Contains(err.Error(), "overflow")
+
+	var uover null.Int16
+	err = uover.Scan(uint64(math.MaxInt16) + 1)
+	require.Error(err)
+	require.Contains(err.Error(), "overflow")
+
+	var strOver null.Int16
+	err = strOver.Scan(strconv.FormatInt(int64(math.MaxInt16)+1, 10))
+	require.Error(err)
+
+	var floatOver null.Int16
+	err = floatOver.Scan(float64(math.MaxInt16) + 1)
+	require.Error(err)
+}
+
+func TestInt16IsNil(t *testing.T) {
+	require := require.New(t)
+
+	i := null.NewInt16(12345)
+	require.False(i.IsNil())
+
+	z := null.NewInt16(0)
+	require.False(z.IsNil())
+
+	nul := null.Int16{}
+	require.True(nul.IsNil())
+}
+
+func TestInt16IsZero(t *testing.T) {
+	require := require.New(t)
+
+	i := null.NewInt16(12345)
+	require.False(i.IsZero())
+
+	z := null.NewInt16(0)
+	require.True(z.IsZero())
+
+	nul := null.Int16{}
+	require.True(nul.IsZero())
+}
+
+func TestInt16SQLValue(t *testing.T) {
+	require := require.New(t)
+	var val driver.Value
+	var err error
+
+	i := null.NewInt16(12345)
+	val, err = i.Value()
+	require.NoError(err)
+	require.Equal(int64(12345), val)
+
+	z := null.NewInt16(0)
+	val, err = z.Value()
+	require.NoError(err)
+	require.Equal(int64(0), val)
+
+	nul := null.Int16{}
+	val, err = nul.Value()
+	require.NoError(err)
+	require.Equal(nil, val)
+}
+
+func TestInt16SQLScan(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	var i null.Int16
+	err = i.Scan(12345)
+	require.NoError(err)
+	require.True(i.Valid)
+	require.Equal(int16(12345), i.Int16)
+
+	var str null.Int16
+	// NB. Scan will coerce strings, but UnmarshalJSON won't.
+	err = str.Scan("12345")
+	require.NoError(err)
+	require.True(str.Valid)
+	require.Equal(int16(12345), str.Int16)
+
+	var whole null.Int16
+	// Whole floats can be scanned without loss of precision.
+	err = whole.Scan(float64(12345))
+	require.NoError(err)
+	require.True(whole.Valid)
+	require.Equal(int16(12345), whole.Int16)
+
+	var negative null.Int16
+	err = negative.Scan(-12345)
+	require.NoError(err)
+	require.True(negative.Valid)
+	require.Equal(int16(-12345), negative.Int16)
+
+	var nul null.Int16
+	err = nul.Scan(nil)
+	require.NoError(err)
+	require.False(nul.Valid)
+
+	var wrong null.Int16
+	err = wrong.Scan("hello world")
+	require.Error(err)
+
+	var overflow null.Int16
+	err = overflow.Scan(int64(math.MaxInt16) + 1)
+	require.Error(err)
+	require.Contains(err.Error(), "null.Int16:")
+
+	var f null.Int16
+	err = f.Scan(1.2345)
+	require.Error(err)
+
+	var b null.Int16
+	err = b.Scan(true)
+	require.Error(err)
+}
+
+func TestInt16MarshalJSON(t *testing.T) {
+	require := require.New(t)
+	var data []byte
+	var err error
+
+	i := null.NewInt16(12345)
+	data, err = json.Marshal(i)
+	require.NoError(err)
+	require.EqualValues("12345", data)
+	data, err = json.Marshal(&i)
+	require.NoError(err)
+	require.EqualValues("12345", data)
+
+	z := null.NewInt16(0)
+	data, err = json.Marshal(z)
+	require.NoError(err)
+	require.EqualValues("0", data)
+	data, err = json.Marshal(&z)
+	require.NoError(err)
+	require.EqualValues("0", data)
+
+	nul := null.Int16{}
+	data, err = json.Marshal(nul)
+	require.NoError(err)
+	require.EqualValues("null", data)
+	data, err = json.Marshal(&nul)
+	require.NoError(err)
+	require.EqualValues("null", data)
+}
+
+func TestInt16UnmarshalJSON(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	// Successful Valid Parses
+
+	var i null.Int16
+	err = json.Unmarshal([]byte("12345"), &i)
+	require.NoError(err)
+	require.True(i.Valid)
+	require.Equal(int16(12345), i.Int16)
+
+	// Successful Null Parses
+
+	var nul null.Int16
+	err = json.Unmarshal([]byte("null"), &nul)
+	require.NoError(err)
+	require.False(nul.Valid)
+
+	// Unsuccessful Parses
+	// TODO: make types for type mismatches on parsing, and check that the
+	// correct error type is being returned here.
+
+	var intStr null.Int16
+	// Ints wrapped in quotes aren't ints.
+	err = json.Unmarshal([]byte(`"12345"`), &intStr)
+	require.Error(err)
+
+	var empty null.Int16
+	err = json.Unmarshal([]byte(""), &empty)
+	require.Error(err)
+
+	var quotes null.Int16
+	err = json.Unmarshal([]byte(`""`), &quotes)
+	require.Error(err)
+
+	var f null.Int16
+	// Non-integer numbers should not be coerced to ints.
+	err = json.Unmarshal([]byte("1.2345"), &f)
+	require.Error(err)
+
+	var invalid null.Int16
+	err = invalid.UnmarshalJSON([]byte(":->"))
+	if _, ok := err.(*json.SyntaxError); !ok {
+		require.FailNowf(
+			"Unexpected Error Type",
+			"expected *json.SyntaxError, not %T", err)
+	}
+}
+
+func TestInt16UnmarshalJSONOverflow(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	int16Overflow := uint64(math.MaxInt16)
+
+	// Max int16 should decode successfully
+	var i null.Int16
+	err = json.Unmarshal([]byte(strconv.FormatUint(int16Overflow, 10)), &i)
+	require.NoError(err)
+	require.Equal(int16(math.MaxInt16), i.Int16)
+
+	// Attempt to overflow
+	int16Overflow++
+	err = json.Unmarshal([]byte(strconv.FormatUint(int16Overflow, 10)), &i)
+	// Decoded values should overflow int16
+	require.Error(err)
+}
+
+func TestInt16MarshalMapValue(t *testing.T) {
+	require := require.New(t)
+	type Wrapper struct{ Int16 null.Int16 }
+	var wrapper Wrapper
+	var data map[string]interface{}
+	var err error
+
+	wrapper = Wrapper{null.NewInt16(12345)}
+	data, err = maps.Marshal(wrapper)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{"Int16": int16(12345)}, data)
+	data, err = maps.Marshal(&wrapper)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{"Int16": int16(12345)}, data)
+
+	wrapper = Wrapper{null.NewInt16(0)}
+	data, err = maps.Marshal(wrapper)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{"Int16": int16(0)}, data)
+	data, err = maps.Marshal(&wrapper)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{"Int16": int16(0)}, data)
+
+	// Null Int16s should be encoded as "nil"
+	wrapper = Wrapper{null.Int16{}}
+	data, err = maps.Marshal(wrapper)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{"Int16": nil}, data)
+	data, err = maps.Marshal(&wrapper)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{"Int16": nil}, data)
+}
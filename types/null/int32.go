@@ -0,0 +1,213 @@
+package null
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+)
+
+// Int32 is a nullable wrapper around the int32 type that implements all of the
+// pyrrho/encoding/types interfaces detailed in the package comments.
+//
+// If the Int32 is valid and contains 0, it will be considered non-nil, and of
+// zero value.
+type Int32 struct {
+	Int32 int32
+	Valid bool
+}
+
+// Constructors
+
+// NullInt32 constructs and returns a new null Int32.
+func NullInt32() Int32 {
+	return Int32{
+		Int32: 0,
+		Valid: false,
+	}
+}
+
+// NewInt32 constructs and returns a new, valid Int32 initialized with the value
+// of the given i.
+func NewInt32(i int32) Int32 {
+	return Int32{
+		Int32: i,
+		Valid: true,
+	}
+}
+
+// Getters and Setters
+
+// ValueOrZero returns the value of i if it is valid; otherwise it returns the
+// zero value for a int32 (0).
+func (i Int32) ValueOrZero() int32 {
+	if !i.Valid {
+		return 0
+	}
+	return i.Int32
+}
+
+// Set modifies the value stored in i, and guarantees it is valid.
+func (i *Int32) Set(v int32) {
+	i.Int32 = v
+	i.Valid = true
+}
+
+// Null marks i as null with no meaningful value.
+func (i *Int32) Null() {
+	i.Int32 = 0
+	i.Valid = false
+}
+
+// Interfaces
+
+// IsNil implements the pyrrho/encoding IsNiler interface. It will return true
+// if i is null.
+func (i Int32) IsNil() bool {
+	return !i.Valid
+}
+
+// IsZero implements the pyrrho/encoding IsZeroer interface. It will return true
+// if i is null or if its value is 0.
+func (i Int32) IsZero() bool {
+	return !i.Valid || i.Int32 == 0
+}
+
+// Value implements the database/sql/driver Valuer interface. Nil is a valid
+// type to be stored in a driver.Value, but int32 isn't, so if this Int32 is
+// valid it will cast its int32 to an int64.
+func (i Int32) Value() (driver.Value, error) {
+	if !i.Valid {
+		return nil, nil
+	}
+	return int64(i.Int32), nil
+}
+
+// Scan implements the database/sql Scanner interface. It will receive a value
+// from an SQL database and assign it to i, so long as the provided data is of
+// type nil, int32, string, or another integer or float type that doesn't
+// overflow int32. All other types will result in an error.
+func (i *Int32) Scan(src interface{}) error {
+	if i == nil {
+		return fmt.Errorf("null.Int32: Scan called on nil pointer")
+	}
+	if src == nil {
+		i.Int32 = 0
+		i.Valid = false
+		return nil
+	}
+
+	switch val := src.(type) {
+	case int32:
+		i.Int32 = val
+		i.Valid = true
+		return nil
+	case int, int8, int16, int64:
+		v := reflect.ValueOf(src)
+		vi := v.Int()
+		if vi > math.MaxInt32 || vi < math.MinInt32 {
+			return fmt.Errorf("null.Int32: failed to scan type %T (%v): overflow", src, src)
+		}
+		i.Int32 = int32(vi)
+		i.Valid = true
+		return nil
+	case uint, uint8, uint16, uint32, uint64:
+		v := reflect.ValueOf(src)
+		vi := v.Uint()
+		if vi > math.MaxInt32 {
+			return fmt.Errorf("null.Int32: failed to scan type %T (%v): overflow", src, src)
+		}
+		i.Int32 = int32(vi)
+		i.Valid = true
+		return nil
+	case string:
+		parsed, err := strconv.ParseInt(val, 10, 32)
+		if err != nil {
+			return fmt.Errorf("null.Int32: failed to scan type %T (%v): %v", src, src, err)
+		}
+		i.Int32 = int32(parsed)
+		i.Valid = true
+		return nil
+	case float64:
+		// Use a string intermediate so we can generate an error on any loss of
+		// precision.
+		s := strconv.FormatFloat(val, 'f', -1, 64)
+		parsed, err := strconv.ParseInt(s, 10, 32)
+		if err != nil {
+			return fmt.Errorf("null.Int32: failed to convert driver.Value type %T (%v): %v", src, s, err)
+		}
+		i.Int32 = int32(parsed)
+		i.Valid = true
+		return nil
+	case float32:
+		// Use a string intermediate so we can generate an error on any loss of
+		// precision.
+		s := strconv.FormatFloat(float64(val), 'f', -1, 32)
+		parsed, err := strconv.ParseInt(s, 10, 32)
+		if err != nil {
+			return fmt.Errorf("null.Int32: failed to convert driver.Value type %T (%v): %v", src, s, err)
+		}
+		i.Int32 = int32(parsed)
+		i.Valid = true
+		return nil
+	default:
+		return fmt.Errorf("null.Int32: cannot scan type %T (%v)", src, src)
+	}
+}
+
+// MarshalJSON implements the encoding/json Marshaler interface. It will encode
+// i into its JSON representation if valid, or 'null' otherwise.
+func (i Int32) MarshalJSON() ([]byte, error) {
+	if !i.Valid {
+		return []byte("null"), nil
+	}
+	return []byte(strconv.FormatInt(int64(i.Int32), 10)), nil
+}
+
+// UnmarshalJSON implements the encoding/json Unmarshaler interface. It will
+// decode a given []byte into i, so long as the provided []byte is a valid JSON
+// representation of an int. The 'null' keyword will decode into a null Int32.
+//
+// If the decode fails, the value of i will be unchanged.
+func (i *Int32) UnmarshalJSON(data []byte) error {
+	if i == nil {
+		return fmt.Errorf("null.Int32: UnmarshalJSON called on nil pointer")
+	}
+	var j interface{}
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+	switch val := j.(type) {
+	case float64:
+		// Perform a second unmarshal, this time into a int32. This give the
+		// JSON parse a chance to meaningfully fail (eg. if the conversion from
+		// float to integer will result in a loss of precision).
+		var tmp int32
+		err := json.Unmarshal(data, &tmp)
+		if err != nil {
+			return err
+		}
+		i.Int32 = tmp
+		i.Valid = true
+		return nil
+	case nil:
+		i.Int32 = 0
+		i.Valid = false
+		return nil
+	default:
+		return fmt.Errorf("null.Int32: cannot unmarshal JSON of type %T (%v)",
+			val, data)
+	}
+}
+
+// MarshalMapValue implements the pyrrho/encoding/maps Marshaler interface. It
+// will encode i into its interface{} representation for use in a
+// map[string]interface{} if valid, or return nil otherwise.
+func (i Int32) MarshalMapValue() (interface{}, error) {
+	if i.Valid {
+		return i.Int32, nil
+	}
+	return nil, nil
+}
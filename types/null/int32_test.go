@@ -0,0 +1,333 @@
+package null_test
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/pyrrho/encoding/maps"
+	"github.com/pyrrho/encoding/types/null"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInt32Ctors(t *testing.T) {
+	require := require.New(t)
+
+	// null.NullInt32() returns a new null null.Int32.
+	// This is equivalent to null.Int32{}.
+	nul := null.NullInt32()
+	require.False(nul.Valid)
+
+	empty := null.Int32{}
+	require.False(empty.Valid)
+
+	// null.NewInt32 constructs a new, valid null.Int32.
+	i := null.NewInt32(12345)
+	require.True(i.Valid)
+	require.Equal(int32(12345), i.Int32)
+
+	z := null.NewInt32(0)
+	require.True(z.Valid)
+	require.Equal(int32(0), z.Int32)
+}
+
+func TestInt32ValueOrZero(t *testing.T) {
+	require := require.New(t)
+
+	valid := null.NewInt32(12345)
+	require.Equal(int32(12345), valid.ValueOrZero())
+
+	nul := null.Int32{}
+	require.Equal(int32(0), nul.ValueOrZero())
+}
+
+func TestInt32Set(t *testing.T) {
+	require := require.New(t)
+
+	i := null.Int32{}
+	require.False(i.Valid)
+
+	i.Set(12345)
+	require.True(i.Valid)
+	require.Equal(int32(12345), i.Int32)
+
+	i.Set(0)
+	require.True(i.Valid)
+	require.Equal(int32(0), i.Int32)
+}
+
+func TestInt32Null(t *testing.T) {
+	require := require.New(t)
+
+	i := null.NewInt32(12345)
+
+	i.Null()
+	require.False(i.Valid)
+}
+
+func TestInt32SQLScanBounds(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	var max null.Int32
+	err = max.Scan(int64(math.MaxInt32))
+	require.NoError(err)
+	require.Equal(int32(math.MaxInt32), max.Int32)
+
+	var min null.Int32
+	err = min.Scan(int64(math.MinInt32))
+	require.NoError(err)
+	require.Equal(int32(math.MinInt32), min.Int32)
+
+	var under null.Int32
+	err = under.Scan(int64(math.MinInt32) - 1)
+	require.Error(err)
+	require.Contains(err.Error(), "overflow")
+
+	var uover null.Int32
+	err = uover.Scan(uint64(math.MaxInt32) + 1)
+	require.Error(err)
+	require.Contains(err.Error(), "overflow")
+
+	var strOver null.Int32
+	err = strOver.Scan(strconv.FormatInt(int64(math.MaxInt32)+1, 10))
+	require.Error(err)
+
+	var floatOver null.Int32
+	err = floatOver.Scan(float64(math.MaxInt32) + 1)
+	require.Error(err)
+}
+
+func TestInt32IsNil(t *testing.T) {
+	require := require.New(t)
+
+	i := null.NewInt32(12345)
+	require.False(i.IsNil())
+
+	z := null.NewInt32(0)
+	require.False(z.IsNil())
+
+	nul := null.Int32{}
+	require.True(nul.IsNil())
+}
+
+func TestInt32IsZero(t *testing.T) {
+	require := require.New(t)
+
+	i := null.NewInt32(12345)
+	require.False(i.IsZero())
+
+	z := null.NewInt32(0)
+	require.True(z.IsZero())
+
+	nul := null.Int32{}
+	require.True(nul.IsZero())
+}
+
+func TestInt32SQLValue(t *testing.T) {
+	require := require.New(t)
+	var val driver.Value
+	var err error
+
+	i := null.NewInt32(12345)
+	val, err = i.Value()
+	require.NoError(err)
+	require.Equal(int64(12345), val)
+
+	z := null.NewInt32(0)
+	val, err = z.Value()
+	require.NoError(err)
+	require.Equal(int64(0), val)
+
+	nul := null.Int32{}
+	val, err = nul.Value()
+	require.NoError(err)
+	require.Equal(nil, val)
+}
+
+func TestInt32SQLScan(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	var i null.Int32
+	err = i.Scan(12345)
+	require.NoError(err)
+	require.True(i.Valid)
+	require.Equal(int32(12345), i.Int32)
+
+	var str null.Int32
+	// NB. Scan will coerce strings, but UnmarshalJSON won't.
+	err = str.Scan("12345")
+	require.NoError(err)
+	require.True(str.Valid)
+	require.Equal(int32(12345), str.Int32)
+
+	var whole null.Int32
+	// Whole floats can be scanned without loss of precision.
+	err = whole.Scan(float64(12345))
+	require.NoError(err)
+	require.True(whole.Valid)
+	require.Equal(int32(12345), whole.Int32)
+
+	var negative null.Int32
+	err = negative.Scan(-12345)
+	require.NoError(err)
+	require.True(negative.Valid)
+	require.Equal(int32(-12345), negative.Int32)
+
+	var nul null.Int32
+	err = nul.Scan(nil)
+	require.NoError(err)
+	require.False(nul.Valid)
+
+	var wrong null.Int32
+	err = wrong.Scan("hello world")
+	require.Error(err)
+
+	var overflow null.Int32
+	err = overflow.Scan(int64(math.MaxInt32) + 1)
+	require.Error(err)
+	require.Contains(err.Error(), "null.Int32:")
+
+	var f null.Int32
+	err = f.Scan(1.2345)
+	require.Error(err)
+
+	var b null.Int32
+	err = b.Scan(true)
+	require.Error(err)
+}
+
+func TestInt32MarshalJSON(t *testing.T) {
+	require := require.New(t)
+	var data []byte
+	var err error
+
+	i := null.NewInt32(12345)
+	data, err = json.Marshal(i)
+	require.NoError(err)
+	require.EqualValues("12345", data)
+	data, err = json.Marshal(&i)
+	require.NoError(err)
+	require.EqualValues("12345", data)
+
+	z := null.NewInt32(0)
+	data, err = json.Marshal(z)
+	require.NoError(err)
+	require.EqualValues("0", data)
+	data, err = json.Marshal(&z)
+	require.NoError(err)
+	require.EqualValues("0", data)
+
+	nul := null.Int32{}
+	data, err = json.Marshal(nul)
+	require.NoError(err)
+	require.EqualValues("null", data)
+	data, err = json.Marshal(&nul)
+	require.NoError(err)
+	require.EqualValues("null", data)
+}
+
+func TestInt32UnmarshalJSON(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	// Successful Valid Parses
+
+	var i null.Int32
+	err = json.Unmarshal([]byte("12345"), &i)
+	require.NoError(err)
+	require.True(i.Valid)
+	require.Equal(int32(12345), i.Int32)
+
+	// Successful Null Parses
+
+	var nul null.Int32
+	err = json.Unmarshal([]byte("null"), &nul)
+	require.NoError(err)
+	require.False(nul.Valid)
+
+	// Unsuccessful Parses
+	// TODO: make types for type mismatches on parsing, and check that the
+	// correct error type is being returned here.
+
+	var intStr null.Int32
+	// Ints wrapped in quotes aren't ints.
+	err = json.Unmarshal([]byte(`"12345"`), &intStr)
+	require.Error(err)
+
+	var empty null.Int32
+	err = json.Unmarshal([]byte(""), &empty)
+	require.Error(err)
+
+	var quotes null.Int32
+	err = json.Unmarshal([]byte(`""`), &quotes)
+	require.Error(err)
+
+	var f null.Int32
+	// Non-integer numbers should not be coerced to ints.
+	err = json.Unmarshal([]byte("1.2345"), &f)
+	require.Error(err)
+
+	var invalid null.Int32
+	err = invalid.UnmarshalJSON([]byte(":->"))
+	if _, ok := err.(*json.SyntaxError); !ok {
+		require.FailNowf(
+			"Unexpected Error Type",
+			"expected *json.SyntaxError, not %T", err)
+	}
+}
+
+func TestInt32UnmarshalJSONOverflow(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	int32Overflow := uint64(math.MaxInt32)
+
+	// Max int32 should decode successfully
+	var i null.Int32
+	err = json.Unmarshal([]byte(strconv.FormatUint(int32Overflow, 10)), &i)
+	require.NoError(err)
+	require.Equal(int32(math.MaxInt32), i.Int32)
+
+	// Attempt to overflow
+	int32Overflow++
+	err = json.Unmarshal([]byte(strconv.FormatUint(int32Overflow, 10)), &i)
+	// Decoded values should overflow int32
+	require.Error(err)
+}
+
+func TestInt32MarshalMapValue(t *testing.T) {
+	require := require.New(t)
+	type Wrapper struct{ Int32 null.Int32 }
+	var wrapper Wrapper
+	var data map[string]interface{}
+	var err error
+
+	wrapper = Wrapper{null.NewInt32(12345)}
+	data, err = maps.Marshal(wrapper)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{"Int32": int32(12345)}, data)
+	data, err = maps.Marshal(&wrapper)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{"Int32": int32(12345)}, data)
+
+	wrapper = Wrapper{null.NewInt32(0)}
+	data, err = maps.Marshal(wrapper)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{"Int32": int32(0)}, data)
+	data, err = maps.Marshal(&wrapper)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{"Int32": int32(0)}, data)
+
+	// Null Int32s should be encoded as "nil"
+	wrapper = Wrapper{null.Int32{}}
+	data, err = maps.Marshal(wrapper)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{"Int32": nil}, data)
+	data, err = maps.Marshal(&wrapper)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{"Int32": nil}, data)
+}
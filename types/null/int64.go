@@ -120,6 +120,36 @@ func (i *Int64) UnmarshalJSON(data []byte) error {
 	}
 }
 
+// MarshalText implements the encoding TextMarshaler interface. It will encode
+// i into its base 10 text representation if valid, or an empty []byte
+// otherwise.
+func (i Int64) MarshalText() ([]byte, error) {
+	if !i.Valid {
+		return []byte{}, nil
+	}
+	return []byte(strconv.FormatInt(i.Int64, 10)), nil
+}
+
+// UnmarshalText implements the encoding TextUnmarshaler interface. It will
+// decode a base 10 integer into i. Empty text will decode into a null Int64.
+//
+// If the decode fails, the value of i will be unchanged.
+func (i *Int64) UnmarshalText(text []byte) error {
+	if i == nil {
+		return fmt.Errorf("null.Int64: UnmarshalText called on nil pointer")
+	}
+	if len(text) == 0 {
+		i.Null()
+		return nil
+	}
+	v, err := strconv.ParseInt(string(text), 10, 64)
+	if err != nil {
+		return err
+	}
+	i.Set(v)
+	return nil
+}
+
 // MarshalMapValue implements the pyrrho/encoding/maps Marshaler interface. It
 // will encode i into its interface{} representation for use in a
 // map[string]interface{} if valid, or return nil otherwise.
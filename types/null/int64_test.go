@@ -9,6 +9,7 @@ import (
 
 	"github.com/pyrrho/encoding/maps"
 	"github.com/pyrrho/encoding/types/null"
+	"github.com/pyrrho/encoding/types/typestest"
 	"github.com/stretchr/testify/require"
 )
 
@@ -247,6 +248,53 @@ func TestInt64UnmarshalJSONOverflow(t *testing.T) {
 	require.Error(err)
 }
 
+func TestInt64MarshalText(t *testing.T) {
+	require := require.New(t)
+
+	data, err := null.NewInt64(-12345).MarshalText()
+	require.NoError(err)
+	require.EqualValues("-12345", data)
+
+	data, err = null.NewInt64(0).MarshalText()
+	require.NoError(err)
+	require.EqualValues("0", data)
+
+	// Null values encode to empty text, distinct from the text of 0.
+	data, err = null.Int64{}.MarshalText()
+	require.NoError(err)
+	require.NotNil(data)
+	require.Len(data, 0)
+
+	// Int64s are usable as map keys in encoding/json.
+	data, err = json.Marshal(map[null.Int64]int{null.NewInt64(1): 2})
+	require.NoError(err)
+	require.EqualValues(`{"1":2}`, data)
+}
+
+func TestInt64UnmarshalText(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	var i null.Int64
+	err = i.UnmarshalText([]byte("12345"))
+	require.NoError(err)
+	require.Equal(null.NewInt64(12345), i)
+
+	// Empty text decodes to null.
+	err = i.UnmarshalText([]byte(""))
+	require.NoError(err)
+	require.False(i.Valid)
+
+	i = null.NewInt64(1)
+	err = i.UnmarshalText([]byte("1.5"))
+	require.Error(err)
+	err = i.UnmarshalText([]byte("null"))
+	require.Error(err)
+	err = i.UnmarshalText([]byte("9223372036854775808"))
+	require.Error(err)
+	require.Equal(null.NewInt64(1), i)
+}
+
 func TestInt64MarshalMapValue(t *testing.T) {
 	require := require.New(t)
 	type Wrapper struct{ Int64 null.Int64 }
@@ -279,3 +327,9 @@ func TestInt64MarshalMapValue(t *testing.T) {
 	require.NoError(err)
 	require.Equal(map[string]interface{}{"Int64": nil}, data)
 }
+
+func TestInt64Conformance(t *testing.T) {
+	typestest.AssertInterfaceConformance(t, null.NewInt64(42))
+	typestest.AssertInterfaceConformance(t, null.NewInt64(0))
+	typestest.AssertInterfaceConformance(t, null.Int64{})
+}
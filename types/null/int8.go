@@ -0,0 +1,213 @@
+package null
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+)
+
+// Int8 is a nullable wrapper around the int8 type that implements all of the
+// pyrrho/encoding/types interfaces detailed in the package comments.
+//
+// If the Int8 is valid and contains 0, it will be considered non-nil, and of
+// zero value.
+type Int8 struct {
+	Int8  int8
+	Valid bool
+}
+
+// Constructors
+
+// NullInt8 constructs and returns a new null Int8.
+func NullInt8() Int8 {
+	return Int8{
+		Int8:  0,
+		Valid: false,
+	}
+}
+
+// NewInt8 constructs and returns a new, valid Int8 initialized with the value
+// of the given i.
+func NewInt8(i int8) Int8 {
+	return Int8{
+		Int8:  i,
+		Valid: true,
+	}
+}
+
+// Getters and Setters
+
+// ValueOrZero returns the value of i if it is valid; otherwise it returns the
+// zero value for a int8 (0).
+func (i Int8) ValueOrZero() int8 {
+	if !i.Valid {
+		return 0
+	}
+	return i.Int8
+}
+
+// Set modifies the value stored in i, and guarantees it is valid.
+func (i *Int8) Set(v int8) {
+	i.Int8 = v
+	i.Valid = true
+}
+
+// Null marks i as null with no meaningful value.
+func (i *Int8) Null() {
+	i.Int8 = 0
+	i.Valid = false
+}
+
+// Interfaces
+
+// IsNil implements the pyrrho/encoding IsNiler interface. It will return true
+// if i is null.
+func (i Int8) IsNil() bool {
+	return !i.Valid
+}
+
+// IsZero implements the pyrrho/encoding IsZeroer interface. It will return true
+// if i is null or if its value is 0.
+func (i Int8) IsZero() bool {
+	return !i.Valid || i.Int8 == 0
+}
+
+// Value implements the database/sql/driver Valuer interface. Nil is a valid
+// type to be stored in a driver.Value, but int8 isn't, so if this Int8 is
+// valid it will cast its int8 to an int64.
+func (i Int8) Value() (driver.Value, error) {
+	if !i.Valid {
+		return nil, nil
+	}
+	return int64(i.Int8), nil
+}
+
+// Scan implements the database/sql Scanner interface. It will receive a value
+// from an SQL database and assign it to i, so long as the provided data is of
+// type nil, int8, string, or another integer or float type that doesn't
+// overflow int8. All other types will result in an error.
+func (i *Int8) Scan(src interface{}) error {
+	if i == nil {
+		return fmt.Errorf("null.Int8: Scan called on nil pointer")
+	}
+	if src == nil {
+		i.Int8 = 0
+		i.Valid = false
+		return nil
+	}
+
+	switch val := src.(type) {
+	case int8:
+		i.Int8 = val
+		i.Valid = true
+		return nil
+	case int, int16, int32, int64:
+		v := reflect.ValueOf(src)
+		vi := v.Int()
+		if vi > math.MaxInt8 || vi < math.MinInt8 {
+			return fmt.Errorf("null.Int8: failed to scan type %T (%v): overflow", src, src)
+		}
+		i.Int8 = int8(vi)
+		i.Valid = true
+		return nil
+	case uint, uint8, uint16, uint32, uint64:
+		v := reflect.ValueOf(src)
+		vi := v.Uint()
+		if vi > math.MaxInt8 {
+			return fmt.Errorf("null.Int8: failed to scan type %T (%v): overflow", src, src)
+		}
+		i.Int8 = int8(vi)
+		i.Valid = true
+		return nil
+	case string:
+		parsed, err := strconv.ParseInt(val, 10, 8)
+		if err != nil {
+			return fmt.Errorf("null.Int8: failed to scan type %T (%v): %v", src, src, err)
+		}
+		i.Int8 = int8(parsed)
+		i.Valid = true
+		return nil
+	case float64:
+		// Use a string intermediate so we can generate an error on any loss of
+		// precision.
+		s := strconv.FormatFloat(val, 'f', -1, 64)
+		parsed, err := strconv.ParseInt(s, 10, 8)
+		if err != nil {
+			return fmt.Errorf("null.Int8: failed to convert driver.Value type %T (%v): %v", src, s, err)
+		}
+		i.Int8 = int8(parsed)
+		i.Valid = true
+		return nil
+	case float32:
+		// Use a string intermediate so we can generate an error on any loss of
+		// precision.
+		s := strconv.FormatFloat(float64(val), 'f', -1, 32)
+		parsed, err := strconv.ParseInt(s, 10, 8)
+		if err != nil {
+			return fmt.Errorf("null.Int8: failed to convert driver.Value type %T (%v): %v", src, s, err)
+		}
+		i.Int8 = int8(parsed)
+		i.Valid = true
+		return nil
+	default:
+		return fmt.Errorf("null.Int8: cannot scan type %T (%v)", src, src)
+	}
+}
+
+// MarshalJSON implements the encoding/json Marshaler interface. It will encode
+// i into its JSON representation if valid, or 'null' otherwise.
+func (i Int8) MarshalJSON() ([]byte, error) {
+	if !i.Valid {
+		return []byte("null"), nil
+	}
+	return []byte(strconv.FormatInt(int64(i.Int8), 10)), nil
+}
+
+// UnmarshalJSON implements the encoding/json Unmarshaler interface. It will
+// decode a given []byte into i, so long as the provided []byte is a valid JSON
+// representation of an int. The 'null' keyword will decode into a null Int8.
+//
+// If the decode fails, the value of i will be unchanged.
+func (i *Int8) UnmarshalJSON(data []byte) error {
+	if i == nil {
+		return fmt.Errorf("null.Int8: UnmarshalJSON called on nil pointer")
+	}
+	var j interface{}
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+	switch val := j.(type) {
+	case float64:
+		// Perform a second unmarshal, this time into a int8. This give the
+		// JSON parse a chance to meaningfully fail (eg. if the conversion from
+		// float to integer will result in a loss of precision).
+		var tmp int8
+		err := json.Unmarshal(data, &tmp)
+		if err != nil {
+			return err
+		}
+		i.Int8 = tmp
+		i.Valid = true
+		return nil
+	case nil:
+		i.Int8 = 0
+		i.Valid = false
+		return nil
+	default:
+		return fmt.Errorf("null.Int8: cannot unmarshal JSON of type %T (%v)",
+			val, data)
+	}
+}
+
+// MarshalMapValue implements the pyrrho/encoding/maps Marshaler interface. It
+// will encode i into its interface{} representation for use in a
+// map[string]interface{} if valid, or return nil otherwise.
+func (i Int8) MarshalMapValue() (interface{}, error) {
+	if i.Valid {
+		return i.Int8, nil
+	}
+	return nil, nil
+}
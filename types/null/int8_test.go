@@ -0,0 +1,333 @@
+package null_test
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/pyrrho/encoding/maps"
+	"github.com/pyrrho/encoding/types/null"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInt8Ctors(t *testing.T) {
+	require := require.New(t)
+
+	// null.NullInt8() returns a new null null.Int8.
+	// This is equivalent to null.Int8{}.
+	nul := null.NullInt8()
+	require.False(nul.Valid)
+
+	empty := null.Int8{}
+	require.False(empty.Valid)
+
+	// null.NewInt8 constructs a new, valid null.Int8.
+	i := null.NewInt8(123)
+	require.True(i.Valid)
+	require.Equal(int8(123), i.Int8)
+
+	z := null.NewInt8(0)
+	require.True(z.Valid)
+	require.Equal(int8(0), z.Int8)
+}
+
+func TestInt8ValueOrZero(t *testing.T) {
+	require := require.New(t)
+
+	valid := null.NewInt8(123)
+	require.Equal(int8(123), valid.ValueOrZero())
+
+	nul := null.Int8{}
+	require.Equal(int8(0), nul.ValueOrZero())
+}
+
+func TestInt8Set(t *testing.T) {
+	require := require.New(t)
+
+	i := null.Int8{}
+	require.False(i.Valid)
+
+	i.Set(123)
+	require.True(i.Valid)
+	require.Equal(int8(123), i.Int8)
+
+	i.Set(0)
+	require.True(i.Valid)
+	require.Equal(int8(0), i.Int8)
+}
+
+func TestInt8Null(t *testing.T) {
+	require := require.New(t)
+
+	i := null.NewInt8(123)
+
+	i.Null()
+	require.False(i.Valid)
+}
+
+func TestInt8SQLScanBounds(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	var max null.Int8
+	err = max.Scan(int64(math.MaxInt8))
+	require.NoError(err)
+	require.Equal(int8(math.MaxInt8), max.Int8)
+
+	var min null.Int8
+	err = min.Scan(int64(math.MinInt8))
+	require.NoError(err)
+	require.Equal(int8(math.MinInt8), min.Int8)
+
+	var under null.Int8
+	err = under.Scan(int64(math.MinInt8) - 1)
+	require.Error(err)
+	require.Contains(err.Error(), "overflow")
+
+	var uover null.Int8
+	err = uover.Scan(uint64(math.MaxInt8) + 1)
+	require.Error(err)
+	require.Contains(err.Error(), "overflow")
+
+	var strOver null.Int8
+	err = strOver.Scan(strconv.FormatInt(int64(math.MaxInt8)+1, 10))
+	require.Error(err)
+
+	var floatOver null.Int8
+	err = floatOver.Scan(float64(math.MaxInt8) + 1)
+	require.Error(err)
+}
+
+func TestInt8IsNil(t *testing.T) {
+	require := require.New(t)
+
+	i := null.NewInt8(123)
+	require.False(i.IsNil())
+
+	z := null.NewInt8(0)
+	require.False(z.IsNil())
+
+	nul := null.Int8{}
+	require.True(nul.IsNil())
+}
+
+func TestInt8IsZero(t *testing.T) {
+	require := require.New(t)
+
+	i := null.NewInt8(123)
+	require.False(i.IsZero())
+
+	z := null.NewInt8(0)
+	require.True(z.IsZero())
+
+	nul := null.Int8{}
+	require.True(nul.IsZero())
+}
+
+func TestInt8SQLValue(t *testing.T) {
+	require := require.New(t)
+	var val driver.Value
+	var err error
+
+	i := null.NewInt8(123)
+	val, err = i.Value()
+	require.NoError(err)
+	require.Equal(int64(123), val)
+
+	z := null.NewInt8(0)
+	val, err = z.Value()
+	require.NoError(err)
+	require.Equal(int64(0), val)
+
+	nul := null.Int8{}
+	val, err = nul.Value()
+	require.NoError(err)
+	require.Equal(nil, val)
+}
+
+func TestInt8SQLScan(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	var i null.Int8
+	err = i.Scan(123)
+	require.NoError(err)
+	require.True(i.Valid)
+	require.Equal(int8(123), i.Int8)
+
+	var str null.Int8
+	// NB. Scan will coerce strings, but UnmarshalJSON won't.
+	err = str.Scan("123")
+	require.NoError(err)
+	require.True(str.Valid)
+	require.Equal(int8(123), str.Int8)
+
+	var whole null.Int8
+	// Whole floats can be scanned without loss of precision.
+	err = whole.Scan(float64(123))
+	require.NoError(err)
+	require.True(whole.Valid)
+	require.Equal(int8(123), whole.Int8)
+
+	var negative null.Int8
+	err = negative.Scan(-123)
+	require.NoError(err)
+	require.True(negative.Valid)
+	require.Equal(int8(-123), negative.Int8)
+
+	var nul null.Int8
+	err = nul.Scan(nil)
+	require.NoError(err)
+	require.False(nul.Valid)
+
+	var wrong null.Int8
+	err = wrong.Scan("hello world")
+	require.Error(err)
+
+	var overflow null.Int8
+	err = overflow.Scan(int64(math.MaxInt8) + 1)
+	require.Error(err)
+	require.Contains(err.Error(), "null.Int8:")
+
+	var f null.Int8
+	err = f.Scan(1.2345)
+	require.Error(err)
+
+	var b null.Int8
+	err = b.Scan(true)
+	require.Error(err)
+}
+
+func TestInt8MarshalJSON(t *testing.T) {
+	require := require.New(t)
+	var data []byte
+	var err error
+
+	i := null.NewInt8(123)
+	data, err = json.Marshal(i)
+	require.NoError(err)
+	require.EqualValues("123", data)
+	data, err = json.Marshal(&i)
+	require.NoError(err)
+	require.EqualValues("123", data)
+
+	z := null.NewInt8(0)
+	data, err = json.Marshal(z)
+	require.NoError(err)
+	require.EqualValues("0", data)
+	data, err = json.Marshal(&z)
+	require.NoError(err)
+	require.EqualValues("0", data)
+
+	nul := null.Int8{}
+	data, err = json.Marshal(nul)
+	require.NoError(err)
+	require.EqualValues("null", data)
+	data, err = json.Marshal(&nul)
+	require.NoError(err)
+	require.EqualValues("null", data)
+}
+
+func TestInt8UnmarshalJSON(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	// Successful Valid Parses
+
+	var i null.Int8
+	err = json.Unmarshal([]byte("123"), &i)
+	require.NoError(err)
+	require.True(i.Valid)
+	require.Equal(int8(123), i.Int8)
+
+	// Successful Null Parses
+
+	var nul null.Int8
+	err = json.Unmarshal([]byte("null"), &nul)
+	require.NoError(err)
+	require.False(nul.Valid)
+
+	// Unsuccessful Parses
+	// TODO: make types for type mismatches on parsing, and check that the
+	// correct error type is being returned here.
+
+	var intStr null.Int8
+	// Ints wrapped in quotes aren't ints.
+	err = json.Unmarshal([]byte(`"123"`), &intStr)
+	require.Error(err)
+
+	var empty null.Int8
+	err = json.Unmarshal([]byte(""), &empty)
+	require.Error(err)
+
+	var quotes null.Int8
+	err = json.Unmarshal([]byte(`""`), &quotes)
+	require.Error(err)
+
+	var f null.Int8
+	// Non-integer numbers should not be coerced to ints.
+	err = json.Unmarshal([]byte("1.2345"), &f)
+	require.Error(err)
+
+	var invalid null.Int8
+	err = invalid.UnmarshalJSON([]byte(":->"))
+	if _, ok := err.(*json.SyntaxError); !ok {
+		require.FailNowf(
+			"Unexpected Error Type",
+			"expected *json.SyntaxError, not %T", err)
+	}
+}
+
+func TestInt8UnmarshalJSONOverflow(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	int8Overflow := uint64(math.MaxInt8)
+
+	// Max int8 should decode successfully
+	var i null.Int8
+	err = json.Unmarshal([]byte(strconv.FormatUint(int8Overflow, 10)), &i)
+	require.NoError(err)
+	require.Equal(int8(math.MaxInt8), i.Int8)
+
+	// Attempt to overflow
+	int8Overflow++
+	err = json.Unmarshal([]byte(strconv.FormatUint(int8Overflow, 10)), &i)
+	// Decoded values should overflow int8
+	require.Error(err)
+}
+
+func TestInt8MarshalMapValue(t *testing.T) {
+	require := require.New(t)
+	type Wrapper struct{ Int8 null.Int8 }
+	var wrapper Wrapper
+	var data map[string]interface{}
+	var err error
+
+	wrapper = Wrapper{null.NewInt8(123)}
+	data, err = maps.Marshal(wrapper)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{"Int8": int8(123)}, data)
+	data, err = maps.Marshal(&wrapper)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{"Int8": int8(123)}, data)
+
+	wrapper = Wrapper{null.NewInt8(0)}
+	data, err = maps.Marshal(wrapper)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{"Int8": int8(0)}, data)
+	data, err = maps.Marshal(&wrapper)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{"Int8": int8(0)}, data)
+
+	// Null Int8s should be encoded as "nil"
+	wrapper = Wrapper{null.Int8{}}
+	data, err = maps.Marshal(wrapper)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{"Int8": nil}, data)
+	data, err = maps.Marshal(&wrapper)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{"Int8": nil}, data)
+}
@@ -0,0 +1,332 @@
+package null_test
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/pyrrho/encoding/maps"
+	"github.com/pyrrho/encoding/types/null"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntCtors(t *testing.T) {
+	require := require.New(t)
+
+	// null.NullInt() returns a new null null.Int.
+	// This is equivalent to null.Int{}.
+	nul := null.NullInt()
+	require.False(nul.Valid)
+
+	empty := null.Int{}
+	require.False(empty.Valid)
+
+	// null.NewInt constructs a new, valid null.Int.
+	i := null.NewInt(12345)
+	require.True(i.Valid)
+	require.Equal(int(12345), i.Int)
+
+	z := null.NewInt(0)
+	require.True(z.Valid)
+	require.Equal(int(0), z.Int)
+}
+
+func TestIntValueOrZero(t *testing.T) {
+	require := require.New(t)
+
+	valid := null.NewInt(12345)
+	require.Equal(int(12345), valid.ValueOrZero())
+
+	nul := null.Int{}
+	require.Equal(int(0), nul.ValueOrZero())
+}
+
+func TestIntSet(t *testing.T) {
+	require := require.New(t)
+
+	i := null.Int{}
+	require.False(i.Valid)
+
+	i.Set(12345)
+	require.True(i.Valid)
+	require.Equal(int(12345), i.Int)
+
+	i.Set(0)
+	require.True(i.Valid)
+	require.Equal(int(0), i.Int)
+}
+
+func TestIntNull(t *testing.T) {
+	require := require.New(t)
+
+	i := null.NewInt(12345)
+
+	i.Null()
+	require.False(i.Valid)
+}
+
+func TestIntFromPtr(t *testing.T) {
+	require := require.New(t)
+
+	v := 12345
+	i := null.NewIntFromPtr(&v)
+	require.True(i.Valid)
+	require.Equal(12345, i.Int)
+
+	// The new Int holds a copy of the pointed-to value.
+	v = 54321
+	require.Equal(12345, i.Int)
+
+	nul := null.NewIntFromPtr(nil)
+	require.False(nul.Valid)
+}
+
+func TestIntPtr(t *testing.T) {
+	require := require.New(t)
+
+	i := null.NewInt(12345)
+	p := i.Ptr()
+	require.NotNil(p)
+	require.Equal(12345, *p)
+
+	// The returned pointer refers to a copy of the value.
+	*p = 54321
+	require.Equal(12345, i.Int)
+
+	nul := null.Int{}
+	require.Nil(nul.Ptr())
+}
+
+func TestIntIsNil(t *testing.T) {
+	require := require.New(t)
+
+	i := null.NewInt(12345)
+	require.False(i.IsNil())
+
+	z := null.NewInt(0)
+	require.False(z.IsNil())
+
+	nul := null.Int{}
+	require.True(nul.IsNil())
+}
+
+func TestIntIsZero(t *testing.T) {
+	require := require.New(t)
+
+	i := null.NewInt(12345)
+	require.False(i.IsZero())
+
+	z := null.NewInt(0)
+	require.True(z.IsZero())
+
+	nul := null.Int{}
+	require.True(nul.IsZero())
+}
+
+func TestIntSQLValue(t *testing.T) {
+	require := require.New(t)
+	var val driver.Value
+	var err error
+
+	i := null.NewInt(12345)
+	val, err = i.Value()
+	require.NoError(err)
+	require.Equal(int64(12345), val)
+
+	z := null.NewInt(0)
+	val, err = z.Value()
+	require.NoError(err)
+	require.Equal(int64(0), val)
+
+	nul := null.Int{}
+	val, err = nul.Value()
+	require.NoError(err)
+	require.Equal(nil, val)
+}
+
+func TestIntSQLScan(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	var i null.Int
+	err = i.Scan(12345)
+	require.NoError(err)
+	require.True(i.Valid)
+	require.Equal(int(12345), i.Int)
+
+	var str null.Int
+	// NB. Scan will coerce strings, but UnmarshalJSON won't.
+	err = str.Scan("12345")
+	require.NoError(err)
+	require.True(str.Valid)
+	require.Equal(int(12345), str.Int)
+
+	var whole null.Int
+	// Whole floats can be scanned without loss of precision.
+	err = whole.Scan(float64(12345))
+	require.NoError(err)
+	require.True(whole.Valid)
+	require.Equal(int(12345), whole.Int)
+
+	var negative null.Int
+	err = negative.Scan(-12345)
+	require.NoError(err)
+	require.True(negative.Valid)
+	require.Equal(int(-12345), negative.Int)
+
+	var nul null.Int
+	err = nul.Scan(nil)
+	require.NoError(err)
+	require.False(nul.Valid)
+
+	var wrong null.Int
+	err = wrong.Scan("hello world")
+	require.Error(err)
+
+	var overflow null.Int
+	err = overflow.Scan(uint64(math.MaxUint64))
+	require.Error(err)
+	require.Contains(err.Error(), "null.Int:")
+
+	var f null.Int
+	err = f.Scan(1.2345)
+	require.Error(err)
+
+	var b null.Int
+	err = b.Scan(true)
+	require.Error(err)
+}
+
+func TestIntMarshalJSON(t *testing.T) {
+	require := require.New(t)
+	var data []byte
+	var err error
+
+	i := null.NewInt(12345)
+	data, err = json.Marshal(i)
+	require.NoError(err)
+	require.EqualValues("12345", data)
+	data, err = json.Marshal(&i)
+	require.NoError(err)
+	require.EqualValues("12345", data)
+
+	z := null.NewInt(0)
+	data, err = json.Marshal(z)
+	require.NoError(err)
+	require.EqualValues("0", data)
+	data, err = json.Marshal(&z)
+	require.NoError(err)
+	require.EqualValues("0", data)
+
+	nul := null.Int{}
+	data, err = json.Marshal(nul)
+	require.NoError(err)
+	require.EqualValues("null", data)
+	data, err = json.Marshal(&nul)
+	require.NoError(err)
+	require.EqualValues("null", data)
+}
+
+func TestIntUnmarshalJSON(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	// Successful Valid Parses
+
+	var i null.Int
+	err = json.Unmarshal([]byte("12345"), &i)
+	require.NoError(err)
+	require.True(i.Valid)
+	require.Equal(int(12345), i.Int)
+
+	// Successful Null Parses
+
+	var nul null.Int
+	err = json.Unmarshal([]byte("null"), &nul)
+	require.NoError(err)
+	require.False(nul.Valid)
+
+	// Unsuccessful Parses
+	// TODO: make types for type mismatches on parsing, and check that the
+	// correct error type is being returned here.
+
+	var intStr null.Int
+	// Ints wrapped in quotes aren't ints.
+	err = json.Unmarshal([]byte(`"12345"`), &intStr)
+	require.Error(err)
+
+	var empty null.Int
+	err = json.Unmarshal([]byte(""), &empty)
+	require.Error(err)
+
+	var quotes null.Int
+	err = json.Unmarshal([]byte(`""`), &quotes)
+	require.Error(err)
+
+	var f null.Int
+	// Non-integer numbers should not be coerced to ints.
+	err = json.Unmarshal([]byte("1.2345"), &f)
+	require.Error(err)
+
+	var invalid null.Int
+	err = invalid.UnmarshalJSON([]byte(":->"))
+	if _, ok := err.(*json.SyntaxError); !ok {
+		require.FailNowf(
+			"Unexpected Error Type",
+			"expected *json.SyntaxError, not %T", err)
+	}
+}
+
+func TestIntUnmarshalJSONOverflow(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	intOverflow := uint64(1<<(strconv.IntSize-1) - 1)
+
+	// Max int should decode successfully
+	var i null.Int
+	err = json.Unmarshal([]byte(strconv.FormatUint(intOverflow, 10)), &i)
+	require.NoError(err)
+	require.Equal(int(1<<(strconv.IntSize-1)-1), i.Int)
+
+	// Attempt to overflow
+	intOverflow++
+	err = json.Unmarshal([]byte(strconv.FormatUint(intOverflow, 10)), &i)
+	// Decoded values should overflow int
+	require.Error(err)
+}
+
+func TestIntMarshalMapValue(t *testing.T) {
+	require := require.New(t)
+	type Wrapper struct{ Int null.Int }
+	var wrapper Wrapper
+	var data map[string]interface{}
+	var err error
+
+	wrapper = Wrapper{null.NewInt(12345)}
+	data, err = maps.Marshal(wrapper)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{"Int": int(12345)}, data)
+	data, err = maps.Marshal(&wrapper)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{"Int": int(12345)}, data)
+
+	wrapper = Wrapper{null.NewInt(0)}
+	data, err = maps.Marshal(wrapper)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{"Int": int(0)}, data)
+	data, err = maps.Marshal(&wrapper)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{"Int": int(0)}, data)
+
+	// Null Ints should be encoded as "nil"
+	wrapper = Wrapper{null.Int{}}
+	data, err = maps.Marshal(wrapper)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{"Int": nil}, data)
+	data, err = maps.Marshal(&wrapper)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{"Int": nil}, data)
+}
@@ -0,0 +1,250 @@
+package null
+
+import (
+	"strconv"
+	"time"
+)
+
+// nullToken is the text the String methods of the null types return for null
+// values. See SetNullToken.
+var nullToken = ""
+
+// SetNullToken sets the text the String methods of the null types -- and the
+// Str method of String -- return for null values. By default this is the empty
+// string. Set it to `\N`, for example, to produce text suitable for the
+// PostgreSQL COPY command.
+//
+// This value is read at call-time, and should be set once during program
+// initialization.
+func SetNullToken(s string) {
+	nullToken = s
+}
+
+// The methods below render each null type as text, for use with encoding/csv
+// and similar formats; the value's text form if valid, or the null token
+// otherwise. null.String's method is named Str, as a String method would shadow
+// the String field of its embedded sql.NullString. null.UUID's and null.Date's
+// String methods are defined alongside their types.
+//
+// As these methods implement fmt.Stringer, they also change how the fmt
+// package's %v and %s verbs print these types. A valid value prints as its text
+// form rather than as a struct, eg. 42, and a null value prints as the null
+// token; by default, the empty string. null.String, having no String method,
+// still prints as a struct.
+
+// String returns the text form of b, "true" or "false", if valid, or the null
+// token otherwise.
+func (b Bool) String() string {
+	if !b.Valid {
+		return nullToken
+	}
+	return strconv.FormatBool(b.Bool)
+}
+
+// String returns the text form of d, formatted by time.Duration's String
+// method, if valid, or the null token otherwise.
+func (d Duration) String() string {
+	if !d.Valid {
+		return nullToken
+	}
+	return d.Duration.String()
+}
+
+// String returns the text form of f, in the shortest form that parses back into
+// the same value, if valid, or the null token otherwise.
+func (f Float32) String() string {
+	if !f.Valid {
+		return nullToken
+	}
+	return strconv.FormatFloat(float64(f.Float32), 'g', -1, 32)
+}
+
+// String returns the text form of f, in the shortest form that parses back into
+// the same value, if valid, or the null token otherwise.
+func (f Float64) String() string {
+	if !f.Valid {
+		return nullToken
+	}
+	return strconv.FormatFloat(f.Float64, 'g', -1, 64)
+}
+
+// String returns the text form of i, in base 10, if valid, or the null token
+// otherwise.
+func (i Int) String() string {
+	if !i.Valid {
+		return nullToken
+	}
+	return strconv.Itoa(i.Int)
+}
+
+// String returns the text form of i, in base 10, if valid, or the null token
+// otherwise.
+func (i Int8) String() string {
+	if !i.Valid {
+		return nullToken
+	}
+	return strconv.FormatInt(int64(i.Int8), 10)
+}
+
+// String returns the text form of i, in base 10, if valid, or the null token
+// otherwise.
+func (i Int16) String() string {
+	if !i.Valid {
+		return nullToken
+	}
+	return strconv.FormatInt(int64(i.Int16), 10)
+}
+
+// String returns the text form of i, in base 10, if valid, or the null token
+// otherwise.
+func (i Int32) String() string {
+	if !i.Valid {
+		return nullToken
+	}
+	return strconv.FormatInt(int64(i.Int32), 10)
+}
+
+// String returns the text form of i, in base 10, if valid, or the null token
+// otherwise.
+func (i Int64) String() string {
+	if !i.Valid {
+		return nullToken
+	}
+	return strconv.FormatInt(i.Int64, 10)
+}
+
+// String returns the text form of i, in base 10, if valid, or the null token
+// otherwise.
+func (i Uint) String() string {
+	if !i.Valid {
+		return nullToken
+	}
+	return strconv.FormatUint(uint64(i.Uint), 10)
+}
+
+// String returns the text form of i, in base 10, if valid, or the null token
+// otherwise.
+func (i Uint8) String() string {
+	if !i.Valid {
+		return nullToken
+	}
+	return strconv.FormatUint(uint64(i.Uint8), 10)
+}
+
+// String returns the text form of i, in base 10, if valid, or the null token
+// otherwise.
+func (i Uint16) String() string {
+	if !i.Valid {
+		return nullToken
+	}
+	return strconv.FormatUint(uint64(i.Uint16), 10)
+}
+
+// String returns the text form of i, in base 10, if valid, or the null token
+// otherwise.
+func (i Uint32) String() string {
+	if !i.Valid {
+		return nullToken
+	}
+	return strconv.FormatUint(uint64(i.Uint32), 10)
+}
+
+// String returns the text form of i, in base 10, if valid, or the null token
+// otherwise.
+func (i Uint64) String() string {
+	if !i.Valid {
+		return nullToken
+	}
+	return strconv.FormatUint(i.Uint64, 10)
+}
+
+// String returns the text form of t, formatted as RFC 3339, if valid, or the
+// null token otherwise.
+func (t Time) String() string {
+	if !t.Valid {
+		return nullToken
+	}
+	return t.Time.Format(time.RFC3339Nano)
+}
+
+// String returns the text form of b, base64 encoded -- or hex encoded; see
+// ByteSlice.Mode -- if valid, or the null token otherwise.
+func (b ByteSlice) String() string {
+	if !b.Valid {
+		return nullToken
+	}
+	return string(encodeByteSlice(b.ByteSlice, b.mode()))
+}
+
+// String returns the text form of j, as its JSON text, if valid, or the null
+// token otherwise.
+func (j RawJSON) String() string {
+	if !j.Valid {
+		return nullToken
+	}
+	return string(j.JSON)
+}
+
+// Str returns s.String if valid, or the null token otherwise.
+func (s String) Str() string {
+	if !s.Valid {
+		return nullToken
+	}
+	return s.String
+}
+
+// String returns the GeoJSON text form of p if valid, or the null token
+// otherwise. An uninitialized geometry has no GeoJSON form, and will be
+// rendered as the null token as well.
+func (p SFPoint) String() string {
+	if !p.Valid {
+		return nullToken
+	}
+	b, err := p.Point.MarshalJSON()
+	if err != nil {
+		return nullToken
+	}
+	return string(b)
+}
+
+// String returns the GeoJSON text form of p if valid, or the null token
+// otherwise. An uninitialized geometry has no GeoJSON form, and will be
+// rendered as the null token as well.
+func (p SFPolygon) String() string {
+	if !p.Valid {
+		return nullToken
+	}
+	b, err := p.Polygon.MarshalJSON()
+	if err != nil {
+		return nullToken
+	}
+	return string(b)
+}
+
+// String returns the GeoJSON text form of p if valid, or the null token
+// otherwise. An uninitialized geometry has no GeoJSON form, and will be
+// rendered as the null token as well.
+func (p SFMultiPolygon) String() string {
+	if !p.Valid {
+		return nullToken
+	}
+	b, err := p.MultiPolygon.MarshalJSON()
+	if err != nil {
+		return nullToken
+	}
+	return string(b)
+}
+
+// String returns the GeoJSON text form of gc if valid, or the null token
+// otherwise. An uninitialized geometry has no GeoJSON form, and will be
+// rendered as the null token as well.
+func (gc SFGeometryCollection) String() string {
+	if !gc.Valid {
+		return nullToken
+	}
+	b, err := gc.GeometryCollection.MarshalJSON()
+	if err != nil {
+		return nullToken
+	}
+	return string(b)
+}
@@ -0,0 +1,63 @@
+package null_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/pyrrho/encoding/types/null"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNullTokenRendering(t *testing.T) {
+	require := require.New(t)
+	defer null.SetNullToken("")
+
+	for _, tc := range []struct {
+		valid    func() string
+		null     func() string
+		expected string
+	}{
+		{null.NewBool(false).String, null.Bool{}.String, "false"},
+		{null.NewDuration(90 * time.Second).String, null.Duration{}.String, "1m30s"},
+		{null.NewFloat32(1.1).String, null.Float32{}.String, "1.1"},
+		{null.NewFloat64(-0.5).String, null.Float64{}.String, "-0.5"},
+		{null.NewInt(0).String, null.Int{}.String, "0"},
+		{null.NewInt8(-8).String, null.Int8{}.String, "-8"},
+		{null.NewInt16(16).String, null.Int16{}.String, "16"},
+		{null.NewInt32(32).String, null.Int32{}.String, "32"},
+		{null.NewInt64(-64).String, null.Int64{}.String, "-64"},
+		{null.NewUint(1).String, null.Uint{}.String, "1"},
+		{null.NewUint8(255).String, null.Uint8{}.String, "255"},
+		{null.NewUint16(16).String, null.Uint16{}.String, "16"},
+		{null.NewUint32(32).String, null.Uint32{}.String, "32"},
+		{null.NewUint64(18446744073709551615).String, null.Uint64{}.String, "18446744073709551615"},
+		{null.NewString("").Str, null.String{}.Str, ""},
+		{null.NewString("foo").Str, null.String{}.Str, "foo"},
+		{null.NewTime(time.Date(2017, 4, 1, 12, 30, 0, 5, time.UTC)).String, null.Time{}.String, "2017-04-01T12:30:00.000000005Z"},
+		{null.NewByteSlice([]byte("hi")).String, null.ByteSlice{}.String, "aGk="},
+		{null.NewJSONStr(`{"a":1}`).String, null.RawJSON{}.String, `{"a":1}`},
+		{null.NewUUID([16]byte{15: 1}).String, null.UUID{}.String, "00000000-0000-0000-0000-000000000001"},
+		{null.NewSFPointXY(1, 2).String, null.SFPoint{}.String, `{"type":"Point","coordinates":[1,2]}`},
+	} {
+		null.SetNullToken("")
+		require.Equal(tc.expected, tc.valid())
+		require.Equal("", tc.null())
+
+		null.SetNullToken(`\N`)
+		require.Equal(tc.expected, tc.valid())
+		require.Equal(`\N`, tc.null())
+	}
+}
+
+func TestNullTokenFormatting(t *testing.T) {
+	require := require.New(t)
+	defer null.SetNullToken("")
+
+	// The fmt package prints the null types through their String methods.
+	require.Equal("42", fmt.Sprintf("%v", null.NewInt64(42)))
+	require.Equal("42", fmt.Sprintf("%s", null.NewInt64(42)))
+	require.Equal("", fmt.Sprintf("%v", null.NullInt64()))
+	null.SetNullToken(`\N`)
+	require.Equal(`\N`, fmt.Sprintf("%v", null.NullInt64()))
+}
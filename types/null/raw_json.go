@@ -4,6 +4,7 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"fmt"
+	"io"
 
 	"github.com/pyrrho/encoding/types"
 )
@@ -52,6 +53,26 @@ func NewJSONStr(s string) RawJSON {
 	}
 }
 
+// ReadJSON reads all of r, and returns a new RawJSON object initialized with its
+// contents. If r is empty the new RawJSON will be null. If the read fails, or if
+// the contents are not valid JSON, an error will be returned.
+func ReadJSON(r io.Reader) (RawJSON, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return NullJSON(), err
+	}
+	if len(b) == 0 {
+		return NullJSON(), nil
+	}
+	if !json.Valid(b) {
+		return NullJSON(), fmt.Errorf("null.RawJSON: cannot read invalid JSON")
+	}
+	return RawJSON{
+		JSON:  types.RawJSON(b),
+		Valid: true,
+	}, nil
+}
+
 // Getters and Setters
 
 // ValueOrZero will return the value of j if it is valid, or a newly constructed
@@ -95,6 +116,18 @@ func (j *RawJSON) Null() {
 	j.Valid = false
 }
 
+// ToByteSlice returns a ByteSlice holding a copy of the JSON contained in j,
+// or a null ByteSlice if j is null.
+func (j RawJSON) ToByteSlice() ByteSlice {
+	if !j.Valid {
+		return NullByteSlice()
+	}
+	return ByteSlice{
+		ByteSlice: append([]byte{}, j.JSON...),
+		Valid:     true,
+	}
+}
+
 // Interfaces
 
 // IsNil implements the pyrrho/encoding IsNiler interface. It will return true
@@ -125,10 +158,10 @@ func (j RawJSON) Value() (driver.Value, error) {
 }
 
 // Scan implements the database/sql Scanner interface. It expects to receive a
-// valid JSON value as a string or a []byte, or NULL as a nil from an SQL
-// database. A zero-length string or []byte, or a nil will be considered NULL,
-// and j will be nulled, otherwise the the value will be assigned to j. Scan
-// will not validate the incoming JSON.
+// valid JSON value as a string, a []byte, a json.RawMessage, or a fmt.Stringer,
+// or NULL as a nil from an SQL database. Zero-length content or a nil will be
+// considered NULL, and j will be nulled, otherwise the the value will be
+// assigned to j. Scan will not validate the incoming JSON.
 func (j *RawJSON) Scan(src interface{}) error {
 	if j == nil {
 		return fmt.Errorf("null.RawJSON: Scan called on nil pointer")
@@ -147,6 +180,15 @@ func (j *RawJSON) Scan(src interface{}) error {
 		j.JSON.Set(x)
 		j.Valid = true
 		return nil
+	case json.RawMessage:
+		if len(x) == 0 {
+			j.JSON = nil
+			j.Valid = false
+			return nil
+		}
+		j.JSON.Set(types.RawJSON(x))
+		j.Valid = true
+		return nil
 	case string:
 		if len(x) == 0 {
 			j.JSON = nil
@@ -156,6 +198,9 @@ func (j *RawJSON) Scan(src interface{}) error {
 		j.JSON.SetStr(x)
 		j.Valid = true
 		return nil
+	case fmt.Stringer:
+		j.SetStr(x.String())
+		return nil
 	default:
 		return fmt.Errorf("null.RawJSON: cannot scan type %T (%v)", src, src)
 	}
@@ -197,11 +242,15 @@ func (j *RawJSON) UnmarshalJSON(data []byte) error {
 
 // MarshalMapValue implements the pyrrho/encoding/maps Marshaler interface. It
 // will encode j into its interface{} representation for use in a
-// map[string]interface{} by passing it through JSON.Unmarshal if valid, or the
-// 'null' keyword otherwise.
+// map[string]interface{} by passing it through JSON.Unmarshal if valid, or
+// return nil otherwise.
+//
+// NB. Prior versions returned the JSON 'null' keyword, []byte("null"), for
+// invalid values. Callers that depend on that representation can Marshal with
+// the pyrrho/encoding/maps NullMapValueJSON option.
 func (j RawJSON) MarshalMapValue() (interface{}, error) {
 	if !j.Valid {
-		return []byte("null"), nil
+		return nil, nil
 	}
 	return j.JSON.MarshalMapValue()
 }
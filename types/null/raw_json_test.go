@@ -1,6 +1,7 @@
 package null_test
 
 import (
+	"bytes"
 	"database/sql/driver"
 	"encoding/json"
 	"testing"
@@ -66,6 +67,26 @@ func TestRawJSONCtors(t *testing.T) {
 	require.False(e7.Valid)
 }
 
+func TestRawJSONReadJSON(t *testing.T) {
+	require := require.New(t)
+
+	j, err := null.ReadJSON(bytes.NewReader([]byte(`{"foo": [1, 2, 3]}`)))
+	require.NoError(err)
+	require.True(j.Valid)
+	require.Equal(types.NewJSONStr(`{"foo": [1, 2, 3]}`), j.JSON)
+
+	// Empty input is null ...
+	j, err = null.ReadJSON(bytes.NewReader(nil))
+	require.NoError(err)
+	require.False(j.Valid)
+
+	// ... while malformed input is an error.
+	j, err = null.ReadJSON(bytes.NewReader([]byte(`{"foo":`)))
+	require.Error(err)
+	require.Contains(err.Error(), "null.RawJSON:")
+	require.False(j.Valid)
+}
+
 func TestRawJSONValueOrZero(t *testing.T) {
 	require := require.New(t)
 
@@ -115,6 +136,23 @@ func TestRawJSONNull(t *testing.T) {
 	require.False(j.Valid)
 }
 
+func TestRawJSONToByteSlice(t *testing.T) {
+	require := require.New(t)
+
+	j := null.NewJSONStr(`{"foo":"bar"}`)
+	b := j.ToByteSlice()
+	require.True(b.Valid)
+	require.Equal([]byte(`{"foo":"bar"}`), b.ByteSlice)
+
+	// The conversion copies.
+	b.ByteSlice[0] = '['
+	require.EqualValues(`{"foo":"bar"}`, j.JSON)
+
+	nul := null.NullJSON().ToByteSlice()
+	require.False(nul.Valid)
+	require.Equal(null.NullByteSlice(), nul)
+}
+
 func TestRawJSONIsNil(t *testing.T) {
 	require := require.New(t)
 
@@ -280,6 +318,42 @@ func TestRawJSONSQLScan(t *testing.T) {
 	require.EqualValues(":->", invalids.JSON)
 }
 
+type jsonStringer string
+
+func (s jsonStringer) String() string { return string(s) }
+
+func TestRawJSONSQLScanRawMessageAndStringer(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	var rm null.RawJSON
+	err = rm.Scan(json.RawMessage(`{"foo":42.0,"bar":"baz"}`))
+	require.NoError(err)
+	require.True(rm.Valid)
+	require.EqualValues(`{"foo":42.0,"bar":"baz"}`, rm.JSON)
+
+	emptyRM := null.NewJSONStr(`"Hello World"`)
+	err = emptyRM.Scan(json.RawMessage{})
+	require.NoError(err)
+	require.False(emptyRM.Valid)
+
+	nilRM := null.NewJSONStr(`"Hello World"`)
+	err = nilRM.Scan(json.RawMessage(nil))
+	require.NoError(err)
+	require.False(nilRM.Valid)
+
+	var str null.RawJSON
+	err = str.Scan(jsonStringer(`[1,2,3]`))
+	require.NoError(err)
+	require.True(str.Valid)
+	require.EqualValues(`[1,2,3]`, str.JSON)
+
+	emptyStr := null.NewJSONStr(`"Hello World"`)
+	err = emptyStr.Scan(jsonStringer(""))
+	require.NoError(err)
+	require.False(emptyStr.Valid)
+}
+
 func TestRawJSONMarshalJSON(t *testing.T) {
 	require := require.New(t)
 	var err error
@@ -394,18 +468,18 @@ func TestRawJSONMarshalMapValue(t *testing.T) {
 	wrapper = Wrapper{null.RawJSON{}}
 	data, err = maps.Marshal(wrapper)
 	require.NoError(err)
-	require.Equal([]byte("null"), data["JSONText"])
+	require.Nil(data["JSONText"])
 	data, err = maps.Marshal(&wrapper)
 	require.NoError(err)
-	require.Equal([]byte("null"), data["JSONText"])
+	require.Nil(data["JSONText"])
 
 	wrapper = Wrapper{null.NullJSON()}
 	data, err = maps.Marshal(wrapper)
 	require.NoError(err)
-	require.Equal([]byte("null"), data["JSONText"])
+	require.Nil(data["JSONText"])
 	data, err = maps.Marshal(&wrapper)
 	require.NoError(err)
-	require.Equal([]byte("null"), data["JSONText"])
+	require.Nil(data["JSONText"])
 
 	// .. and other behavior is consistent with types.RawJSON.
 
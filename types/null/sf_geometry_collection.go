@@ -0,0 +1,200 @@
+package null
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+
+	"github.com/twpayne/go-geom"
+
+	"github.com/pyrrho/encoding/types"
+)
+
+// SFGeometryCollection is a wrapper around types.SFGeometryCollection that
+// makes the type null-aware, in terms of both the JSON 'null' keyword, and SQL
+// NULL values. It implements all of the pyrrho/encoding/types interfaces
+// detailed in the package comments.
+type SFGeometryCollection struct {
+	GeometryCollection types.SFGeometryCollection
+	Valid              bool
+}
+
+// Constructors
+
+// NullSFGeometryCollection constructs and returns a new null
+// SFGeometryCollection object.
+func NullSFGeometryCollection() SFGeometryCollection {
+	return SFGeometryCollection{
+		GeometryCollection: types.SFGeometryCollection{},
+		Valid:              false,
+	}
+}
+
+// NewSFGeometryCollection constructs and returns a new SFGeometryCollection
+// object based on the given types.SFGeometryCollection gc. If gc is nil, the
+// new SFGeometryCollection will be null. Otherwise a new, valid
+// SFGeometryCollection will be initialized with a copy of gc.
+func NewSFGeometryCollection(gc types.SFGeometryCollection) SFGeometryCollection {
+	if gc.IsNil() {
+		return NullSFGeometryCollection()
+	}
+	return SFGeometryCollection{
+		GeometryCollection: types.NewSFGeometryCollection(gc.GeometryCollection),
+		Valid:              true,
+	}
+}
+
+// NewSFGeometryCollectionOf constructs and returns a new, valid
+// SFGeometryCollection object holding the given geometries, in order.
+func NewSFGeometryCollectionOf(gs ...geom.T) SFGeometryCollection {
+	return SFGeometryCollection{
+		GeometryCollection: types.NewSFGeometryCollectionOf(gs...),
+		Valid:              true,
+	}
+}
+
+// Getters and Setters
+
+// ValueOrZero will return the value of gc if it is valid, or a newly
+// constructed zero-value types.SFGeometryCollection otherwise.
+func (gc SFGeometryCollection) ValueOrZero() types.SFGeometryCollection {
+	if !gc.Valid {
+		return types.SFGeometryCollection{}
+	}
+	return gc.GeometryCollection
+}
+
+// Set copies the given types.SFGeometryCollection value into gc. If the given
+// value is nil, gc will be nulled.
+func (gc *SFGeometryCollection) Set(v types.SFGeometryCollection) {
+	if v.IsNil() {
+		gc.GeometryCollection = types.SFGeometryCollection{}
+		gc.Valid = false
+		return
+	}
+	gc.GeometryCollection = v
+	gc.Valid = true
+}
+
+// Null will set gc to null; gc.Valid will be false, and gc.GeometryCollection
+// will contain no meaningful value.
+func (gc *SFGeometryCollection) Null() {
+	gc.GeometryCollection = types.SFGeometryCollection{}
+	gc.Valid = false
+}
+
+// Interfaces
+
+// IsNil implements the pyrrho/encoding IsNiler interface. It will return true
+// if gc is null.
+func (gc SFGeometryCollection) IsNil() bool {
+	return !gc.Valid
+}
+
+// IsZero implements the pyrrho/encoding IsZeroer interface. It will return true
+// if gc is null or if the contained SFGeometryCollection is a zero value.
+func (gc SFGeometryCollection) IsZero() bool {
+	if !gc.Valid {
+		return true
+	}
+	return gc.GeometryCollection.IsZero()
+}
+
+// Value implements the database/sql/driver Valuer interface. It will return the
+// value of gc as a driver.Value. If gc is null, nil will be returned.
+func (gc SFGeometryCollection) Value() (driver.Value, error) {
+	if !gc.Valid {
+		return nil, nil
+	}
+	return gc.GeometryCollection.Value()
+}
+
+// Scan implements the database/sql Scanner interface. It expects to receive a
+// valid WKB encoded []byte describing a GeometryCollection, or NULL as a nil
+// from an SQL database. A zero-length or nil []byte will be considered NULL,
+// and gc will be nulled. Otherwise, the value will be passed to
+// types.SFGeometryCollection to be scanned and parsed as a WKB
+// GeometryCollection.
+//
+// A string will be treated as GeoJSON text, and is only accepted when
+// types.GeometryValueOutput is types.GeometryValueGeoJSON. An empty string
+// will be considered NULL.
+func (gc *SFGeometryCollection) Scan(src interface{}) error {
+	if gc == nil {
+		return fmt.Errorf("null.SFGeometryCollection: Scan called on nil pointer")
+	}
+	switch x := src.(type) {
+	case nil:
+		gc.Valid = false
+		return nil
+	case []byte:
+		if len(x) == 0 {
+			gc.GeometryCollection = types.SFGeometryCollection{}
+			gc.Valid = false
+			return nil
+		}
+		err := gc.GeometryCollection.Scan(x)
+		if err != nil {
+			return err
+		}
+		gc.Valid = true
+		return nil
+	case string:
+		if len(x) == 0 {
+			gc.GeometryCollection = types.SFGeometryCollection{}
+			gc.Valid = false
+			return nil
+		}
+		err := gc.GeometryCollection.Scan(x)
+		if err != nil {
+			return err
+		}
+		gc.Valid = true
+		return nil
+	default:
+		return fmt.Errorf("null.SFGeometryCollection: cannot scan type %T (%v)", src, src)
+	}
+}
+
+// MarshalJSON implements the encoding/json Marshaler interface. It will return
+// the GeoJSON encoded representation of gc, or "null" if gc is null.
+func (gc SFGeometryCollection) MarshalJSON() ([]byte, error) {
+	if !gc.Valid {
+		return []byte("null"), nil
+	}
+	return gc.GeometryCollection.MarshalJSON()
+}
+
+// UnmarshalJSON implements the encoding/json Unmarshaler interface. It expects
+// to receive a valid GeoJSON Geometry of the type GeometryCollection, and will
+// assign the value of that data to gc. If the incoming JSON is the 'null'
+// keyword, gc will have no valid value.
+func (gc *SFGeometryCollection) UnmarshalJSON(data []byte) error {
+	if gc == nil {
+		return fmt.Errorf("null.SFGeometryCollection: UnmarshalJSON called on nil pointer")
+	}
+	var k interface{}
+	if err := json.Unmarshal(data, &k); err != nil {
+		return err
+	}
+	if k == nil {
+		gc.GeometryCollection = types.SFGeometryCollection{}
+		gc.Valid = false
+		return nil
+	}
+	if err := gc.GeometryCollection.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	gc.Valid = true
+	return nil
+}
+
+// MarshalMapValue implements the pyrrho/encoding/maps Marshaler interface. It
+// will return the contained types.SFGeometryCollection if gc is valid, or the
+// 'null' keyword otherwise.
+func (gc SFGeometryCollection) MarshalMapValue() (interface{}, error) {
+	if !gc.Valid {
+		return []byte("null"), nil
+	}
+	return gc.GeometryCollection.MarshalMapValue()
+}
@@ -0,0 +1,243 @@
+package null_test
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/twpayne/go-geom"
+
+	"github.com/pyrrho/encoding/maps"
+	"github.com/pyrrho/encoding/types"
+	"github.com/pyrrho/encoding/types/null"
+)
+
+var (
+	// These are OpenGIS Simple Feature representations of the XY test
+	// GeometryCollection; a Point followed by a Polygon.
+	testGeometryCollectionGeoJSON = []byte(`{"type":"GeometryCollection","geometries":[{"type":"Point","coordinates":[1.2,2.3]},{"type":"Polygon","coordinates":[[[30,10],[40,40],[20,40],[10,20],[30,10]]]}]}`)
+	testGeometryCollectionWKB     = []byte{
+		0x01, 0x07, 0x00, 0x00, 0x00, 0x02, 0x00, 0x00,
+		0x00, 0x01, 0x01, 0x00, 0x00, 0x00, 0x33, 0x33,
+		0x33, 0x33, 0x33, 0x33, 0xf3, 0x3f, 0x66, 0x66,
+		0x66, 0x66, 0x66, 0x66, 0x02, 0x40, 0x01, 0x03,
+		0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x05,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x3e, 0x40, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x24, 0x40, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x44, 0x40, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x44, 0x40, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x34, 0x40, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x44, 0x40, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x24, 0x40, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x34, 0x40, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x3e, 0x40, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x24, 0x40,
+	}
+	testGeometryCollectionPoint   = types.NewSFPointXY(1.2, 2.3)
+	testGeometryCollectionPolygon = types.NewSFPolygonXY([][2]float64{
+		{30, 10},
+		{40, 40},
+		{20, 40},
+		{10, 20},
+		{30, 10},
+	})
+	testSFGeometryCollection = types.NewSFGeometryCollectionOf(
+		&testGeometryCollectionPoint.Point,
+		&testGeometryCollectionPolygon.Polygon)
+)
+
+// requireGeometryCollectionMembers asserts that gc holds exactly the test Point
+// followed by the test Polygon, with their geometry types intact.
+func requireGeometryCollectionMembers(require *require.Assertions, gc null.SFGeometryCollection) {
+	require.True(gc.Valid)
+	require.Equal(2, gc.GeometryCollection.NumGeoms())
+
+	p, ok := gc.GeometryCollection.Geom(0).(*geom.Point)
+	require.True(ok, "first member should be a *geom.Point")
+	require.Equal(testGeometryCollectionPoint.Coords(), p.Coords())
+
+	poly, ok := gc.GeometryCollection.Geom(1).(*geom.Polygon)
+	require.True(ok, "second member should be a *geom.Polygon")
+	require.Equal(testGeometryCollectionPolygon.Coords(), poly.Coords())
+}
+
+func TestSFGeometryCollectionCtors(t *testing.T) {
+	require := require.New(t)
+
+	// null.NullSFGeometryCollection returns a new null
+	// null.SFGeometryCollection. This is equivalent to
+	// null.SFGeometryCollection{}.
+	na := null.NullSFGeometryCollection()
+	require.False(na.Valid)
+
+	// Passing a nil types.SFGeometryCollection to
+	// null.NewSFGeometryCollection does the same thing.
+	nb := null.NewSFGeometryCollection(types.SFGeometryCollection{})
+	require.False(nb.Valid)
+
+	gca := null.NewSFGeometryCollectionOf(
+		&testGeometryCollectionPoint.Point,
+		&testGeometryCollectionPolygon.Polygon)
+	requireGeometryCollectionMembers(require, gca)
+
+	gcb := null.NewSFGeometryCollection(testSFGeometryCollection)
+	requireGeometryCollectionMembers(require, gcb)
+}
+
+func TestSFGeometryCollectionValueOrZero(t *testing.T) {
+	require := require.New(t)
+
+	gc := null.NewSFGeometryCollection(testSFGeometryCollection)
+	require.EqualValues(testSFGeometryCollection, gc.ValueOrZero())
+
+	n := null.SFGeometryCollection{}
+	require.EqualValues(types.SFGeometryCollection{}, n.ValueOrZero())
+}
+
+func TestSFGeometryCollectionSet(t *testing.T) {
+	require := require.New(t)
+
+	gc := null.SFGeometryCollection{}
+
+	gc.Set(testSFGeometryCollection)
+	require.True(gc.Valid)
+	require.EqualValues(testSFGeometryCollection, gc.ValueOrZero())
+
+	gc.Set(types.SFGeometryCollection{})
+	require.False(gc.Valid)
+}
+
+func TestSFGeometryCollectionNull(t *testing.T) {
+	require := require.New(t)
+
+	gc := null.NewSFGeometryCollection(testSFGeometryCollection)
+
+	gc.Null()
+	require.False(gc.Valid)
+}
+
+func TestSFGeometryCollectionIsNil(t *testing.T) {
+	require := require.New(t)
+
+	gc := null.NewSFGeometryCollection(testSFGeometryCollection)
+	require.False(gc.IsNil())
+
+	nul := null.NewSFGeometryCollectionOf()
+	require.False(nul.IsNil())
+
+	empty := null.SFGeometryCollection{}
+	require.True(empty.IsNil())
+}
+
+func TestSFGeometryCollectionIsZero(t *testing.T) {
+	require := require.New(t)
+
+	gc := null.NewSFGeometryCollection(testSFGeometryCollection)
+	require.False(gc.IsZero())
+
+	nul := null.NewSFGeometryCollectionOf()
+	require.True(nul.IsZero())
+
+	empty := null.SFGeometryCollection{}
+	require.True(empty.IsZero())
+}
+
+func TestSFGeometryCollectionSQLValue(t *testing.T) {
+	require := require.New(t)
+	var val driver.Value
+	var err error
+
+	gc := null.NewSFGeometryCollection(testSFGeometryCollection)
+	val, err = gc.Value()
+	require.NoError(err)
+	require.EqualValues(testGeometryCollectionWKB, val)
+
+	n := null.SFGeometryCollection{}
+	val, err = n.Value()
+	require.NoError(err)
+	require.Nil(val)
+}
+
+func TestSFGeometryCollectionSQLScan(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	var gc null.SFGeometryCollection
+	err = gc.Scan(driver.Value(testGeometryCollectionWKB))
+	require.NoError(err)
+	requireGeometryCollectionMembers(require, gc)
+
+	var n null.SFGeometryCollection
+	err = n.Scan(driver.Value(nil))
+	require.NoError(err)
+	require.Equal(null.NullSFGeometryCollection(), n)
+}
+
+func TestSFGeometryCollectionMarshalJSON(t *testing.T) {
+	require := require.New(t)
+	var data []byte
+	var err error
+
+	gc := null.NewSFGeometryCollection(testSFGeometryCollection)
+	data, err = json.Marshal(gc)
+	require.NoError(err)
+	require.EqualValues(testGeometryCollectionGeoJSON, data)
+	data, err = json.Marshal(&gc)
+	require.NoError(err)
+	require.EqualValues(testGeometryCollectionGeoJSON, data)
+
+	n := null.SFGeometryCollection{}
+	data, err = json.Marshal(n)
+	require.NoError(err)
+	require.EqualValues("null", data)
+	data, err = json.Marshal(&n)
+	require.NoError(err)
+	require.EqualValues("null", data)
+}
+
+func TestSFGeometryCollectionUnmarshalJSON(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	var gc null.SFGeometryCollection
+	err = json.Unmarshal(testGeometryCollectionGeoJSON, &gc)
+	require.NoError(err)
+	requireGeometryCollectionMembers(require, gc)
+
+	var n null.SFGeometryCollection
+	err = json.Unmarshal([]byte("null"), &n)
+	require.NoError(err)
+	require.False(n.Valid)
+}
+
+func TestSFGeometryCollectionJSONRoundTrip(t *testing.T) {
+	require := require.New(t)
+
+	type Wrapper struct{ GeometryCollection null.SFGeometryCollection }
+	in := Wrapper{null.NewSFGeometryCollection(testSFGeometryCollection)}
+	data, err := json.Marshal(in)
+	require.NoError(err)
+
+	var out Wrapper
+	err = json.Unmarshal(data, &out)
+	require.NoError(err)
+	requireGeometryCollectionMembers(require, out.GeometryCollection)
+}
+
+func TestSFGeometryCollectionMarshsalMapValue(t *testing.T) {
+	require := require.New(t)
+	type Wrapper struct{ GeometryCollection null.SFGeometryCollection }
+	var wrapper Wrapper
+	var data map[string]interface{}
+	var err error
+
+	wrapper = Wrapper{null.NewSFGeometryCollection(testSFGeometryCollection)}
+	data, err = maps.Marshal(wrapper)
+	require.NoError(err)
+	require.Equal(testSFGeometryCollection, data["GeometryCollection"])
+	data, err = maps.Marshal(&wrapper)
+	require.NoError(err)
+	require.Equal(testSFGeometryCollection, data["GeometryCollection"])
+}
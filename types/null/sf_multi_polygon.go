@@ -0,0 +1,208 @@
+package null
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pyrrho/encoding/types"
+)
+
+// SFMultiPolygon is a wrapper around types.SFMultiPolygon that makes the type
+// null-aware, in terms of both the JSON 'null' keyword, and SQL NULL values. It
+// implements all of the pyrrho/encoding/types interfaces detailed in the
+// package comments.
+type SFMultiPolygon struct {
+	MultiPolygon types.SFMultiPolygon
+	Valid        bool
+}
+
+// Constructors
+
+// NullSFMultiPolygon constructs and returns a new null SFMultiPolygon object.
+func NullSFMultiPolygon() SFMultiPolygon {
+	return SFMultiPolygon{
+		MultiPolygon: types.SFMultiPolygon{},
+		Valid:        false,
+	}
+}
+
+// NewSFMultiPolygon constructs and returns a new SFMultiPolygon object based on
+// the given types.SFMultiPolygon p. If p is nil, the new SFMultiPolygon will be
+// null. Otherwise a new, valid SFMultiPolygon will be initialized with a copy
+// of p.
+func NewSFMultiPolygon(p types.SFMultiPolygon) SFMultiPolygon {
+	if p.IsNil() {
+		return NullSFMultiPolygon()
+	}
+	return SFMultiPolygon{
+		MultiPolygon: types.NewSFMultiPolygon(p.MultiPolygon),
+		Valid:        true,
+	}
+}
+
+// NewSFMultiPolygonXY constructs and returns a new SFMultiPolygon object based
+// on the given polygons, each made up of an external and (optionally) internal
+// shapes.
+func NewSFMultiPolygonXY(polygons ...[][][2]float64) SFMultiPolygon {
+	return SFMultiPolygon{
+		MultiPolygon: types.NewSFMultiPolygonXY(polygons...),
+		Valid:        true,
+	}
+}
+
+// NewSFMultiPolygonXYZ constructs and returns a new SFMultiPolygon object based
+// on the given polygons, each made up of an external and (optionally) internal
+// shapes.
+func NewSFMultiPolygonXYZ(polygons ...[][][3]float64) SFMultiPolygon {
+	return SFMultiPolygon{
+		MultiPolygon: types.NewSFMultiPolygonXYZ(polygons...),
+		Valid:        true,
+	}
+}
+
+// Getters and Setters
+
+// ValueOrZero will return the value of p if it is valid, or a newly constructed
+// zero-value types.SFMultiPolygon otherwise.
+func (p SFMultiPolygon) ValueOrZero() types.SFMultiPolygon {
+	if !p.Valid {
+		return types.SFMultiPolygon{}
+	}
+	return p.MultiPolygon
+}
+
+// Set copies the given types.SFMultiPolygon value into p. If the given value is
+// nil, p will be nulled.
+func (p *SFMultiPolygon) Set(v types.SFMultiPolygon) {
+	if v.IsNil() {
+		p.MultiPolygon = types.SFMultiPolygon{}
+		p.Valid = false
+		return
+	}
+	p.MultiPolygon = v
+	p.Valid = true
+}
+
+// Null will set p to null; p.Valid will be false, and p.MultiPolygon will
+// contain no meaningful value.
+func (p *SFMultiPolygon) Null() {
+	p.MultiPolygon = types.SFMultiPolygon{}
+	p.Valid = false
+}
+
+// Interfaces
+
+// IsNil implements the pyrrho/encoding IsNiler interface. It will return true
+// if p is null.
+func (p SFMultiPolygon) IsNil() bool {
+	return !p.Valid
+}
+
+// IsZero implements the pyrrho/encoding IsZeroer interface. It will return true
+// if p is null or if the contained SFMultiPolygon is a zero value.
+func (p SFMultiPolygon) IsZero() bool {
+	if !p.Valid {
+		return true
+	}
+	return p.MultiPolygon.IsZero()
+}
+
+// Value implements the database/sql/driver Valuer interface. It will return the
+// value of p as a driver.Value. If p is null, nil will be returned.
+func (p SFMultiPolygon) Value() (driver.Value, error) {
+	if !p.Valid {
+		return nil, nil
+	}
+	return p.MultiPolygon.Value()
+}
+
+// Scan implements the database/sql Scanner interface. It expects to receive a
+// valid WKB encoded []byte describing a MultiPolygon, or NULL as a nil from
+// an SQL database. A zero-length or nil []byte will be considered NULL, and p
+// will be nulled. Otherwise, the value will be passed to types.SFMultiPolygon
+// to be scanned and parsed as a WKB MultiPolygon.
+//
+// A string will be treated as GeoJSON text, and is only accepted when
+// types.GeometryValueOutput is types.GeometryValueGeoJSON. An empty string
+// will be considered NULL.
+func (p *SFMultiPolygon) Scan(src interface{}) error {
+	if p == nil {
+		return fmt.Errorf("null.SFMultiPolygon: Scan called on nil pointer")
+	}
+	switch x := src.(type) {
+	case nil:
+		p.Valid = false
+		return nil
+	case []byte:
+		if len(x) == 0 {
+			p.MultiPolygon = types.SFMultiPolygon{}
+			p.Valid = false
+			return nil
+		}
+		err := p.MultiPolygon.Scan(x)
+		if err != nil {
+			return err
+		}
+		p.Valid = true
+		return nil
+	case string:
+		if len(x) == 0 {
+			p.MultiPolygon = types.SFMultiPolygon{}
+			p.Valid = false
+			return nil
+		}
+		err := p.MultiPolygon.Scan(x)
+		if err != nil {
+			return err
+		}
+		p.Valid = true
+		return nil
+	default:
+		return fmt.Errorf("null.SFMultiPolygon: cannot scan type %T (%v)", src, src)
+	}
+}
+
+// MarshalJSON implements the encoding/json Marshaler interface. It will return
+// the GeoJSON encoded representation of p, or "null" if p is null.
+func (p SFMultiPolygon) MarshalJSON() ([]byte, error) {
+	if !p.Valid {
+		return []byte("null"), nil
+	}
+	return p.MultiPolygon.MarshalJSON()
+}
+
+// UnmarshalJSON implements the encoding/json Unmarshaler interface. It expects
+// to receive a valid GeoJSON Geometry of the type MultiPolygon, and will assign
+// the value of that data to p. If the incoming JSON is the 'null' keyword,
+// p will have no valid value.
+func (p *SFMultiPolygon) UnmarshalJSON(data []byte) error {
+	if p == nil {
+		return fmt.Errorf("null.SFMultiPolygon: UnmarshalJSON called on nil pointer")
+	}
+	var k interface{}
+	if err := json.Unmarshal(data, &k); err != nil {
+		return err
+	}
+	if k == nil {
+		p.MultiPolygon = types.SFMultiPolygon{}
+		p.Valid = false
+		return nil
+	}
+	if err := p.MultiPolygon.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	p.Valid = true
+	return nil
+}
+
+// MarshalMapValue implements the pyrrho/encoding/maps Marshaler interface. It
+// will encode p into its interface{} representation for use in a
+// map[string]interface{} by passing it through JSON.Unmarshal if valid, or the
+// 'null' keyword otherwise.
+func (p SFMultiPolygon) MarshalMapValue() (interface{}, error) {
+	if !p.Valid {
+		return []byte("null"), nil
+	}
+	return p.MultiPolygon.MarshalMapValue()
+}
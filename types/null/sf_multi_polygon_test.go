@@ -0,0 +1,274 @@
+package null_test
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/pyrrho/encoding/maps"
+	"github.com/pyrrho/encoding/types"
+	"github.com/pyrrho/encoding/types/null"
+)
+
+var (
+	// These are OpenGIS Simple Feature representations of the two-polygon XY
+	// test MultiPolygon; the second polygon has a hole.
+	testMultiPolygonGeoJSON = []byte(`{"type":"MultiPolygon","coordinates":[[[[40,40],[20,45],[45,30],[40,40]]],[[[20,35],[10,30],[10,10],[30,5],[45,20],[20,35]],[[30,20],[20,15],[20,25],[30,20]]]]}`)
+	testMultiPolygonWKB     = []byte{
+		0x01, 0x06, 0x00, 0x00, 0x00, 0x02, 0x00, 0x00,
+		0x00, 0x01, 0x03, 0x00, 0x00, 0x00, 0x01, 0x00,
+		0x00, 0x00, 0x04, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x44, 0x40, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x44, 0x40, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x34, 0x40, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x80, 0x46, 0x40, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x80, 0x46, 0x40, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x3e, 0x40, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x44, 0x40, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x44, 0x40, 0x01, 0x03,
+		0x00, 0x00, 0x00, 0x02, 0x00, 0x00, 0x00, 0x06,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x34, 0x40, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x80, 0x41, 0x40, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x24, 0x40, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x3e, 0x40, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x24, 0x40, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x24, 0x40, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x3e, 0x40, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x14, 0x40, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x80, 0x46, 0x40, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x34, 0x40, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x34, 0x40, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x80, 0x41, 0x40, 0x04, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x3e, 0x40, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x34, 0x40, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x34, 0x40, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x2e, 0x40, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x34, 0x40, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x39, 0x40, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x3e, 0x40, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x34, 0x40,
+	}
+	testMultiPolygonFirst = [][][2]float64{
+		{
+			{40, 40},
+			{20, 45},
+			{45, 30},
+			{40, 40},
+		},
+	}
+	testMultiPolygonSecond = [][][2]float64{
+		{
+			{20, 35},
+			{10, 30},
+			{10, 10},
+			{30, 5},
+			{45, 20},
+			{20, 35},
+		},
+		{
+			{30, 20},
+			{20, 15},
+			{20, 25},
+			{30, 20},
+		},
+	}
+	testSFMultiPolygonXY = types.NewSFMultiPolygonXY(
+		testMultiPolygonFirst,
+		testMultiPolygonSecond)
+	// A different multi-polygon to test the third dimension.
+	testSFMultiPolygonXYZ = types.NewSFMultiPolygonXYZ([][][3]float64{{
+		{40, 40, 1},
+		{20, 45, 2},
+		{45, 30, 3},
+		{40, 40, 1},
+	}})
+	// A malformed test multi-polygon.
+	testMalformedMultiPolygon = types.NewSFMultiPolygonXY([][][2]float64{{
+		{0.0, 0.0},
+		{0.0, 0.0},
+		{0.0, 0.0},
+		{0.0, 0.0},
+	}})
+)
+
+func TestSFMultiPolygonCtors(t *testing.T) {
+	require := require.New(t)
+
+	// null.NullSFMultiPolygon returns a new null null.SFMultiPolygon.
+	// This is equivalent to null.SFMultiPolygon{}.
+	na := null.NullSFMultiPolygon()
+	require.False(na.Valid)
+
+	// Passing a nil types.SFMultiPolygon to null.NewSFMultiPolygon does the
+	// same thing.
+	nb := null.NewSFMultiPolygon(types.SFMultiPolygon{})
+	require.False(nb.Valid)
+
+	pa := null.NewSFMultiPolygonXY(testMultiPolygonFirst, testMultiPolygonSecond)
+	require.True(pa.Valid)
+	require.Equal(testSFMultiPolygonXY, pa.MultiPolygon)
+
+	pb := null.NewSFMultiPolygonXYZ([][][3]float64{{
+		{40, 40, 1},
+		{20, 45, 2},
+		{45, 30, 3},
+		{40, 40, 1},
+	}})
+	require.True(pb.Valid)
+	require.Equal(testSFMultiPolygonXYZ, pb.MultiPolygon)
+}
+
+func TestSFMultiPolygonValueOrZero(t *testing.T) {
+	require := require.New(t)
+
+	p := null.NewSFMultiPolygonXY(testMultiPolygonFirst, testMultiPolygonSecond)
+	require.EqualValues(testSFMultiPolygonXY, p.ValueOrZero())
+
+	n := null.SFMultiPolygon{}
+	require.EqualValues(types.SFMultiPolygon{}, n.ValueOrZero())
+}
+
+func TestSFMultiPolygonSet(t *testing.T) {
+	require := require.New(t)
+
+	p := null.SFMultiPolygon{}
+
+	p.Set(testSFMultiPolygonXY)
+	require.True(p.Valid)
+	require.EqualValues(testSFMultiPolygonXY, p.ValueOrZero())
+
+	p.Set(types.SFMultiPolygon{})
+	require.False(p.Valid)
+
+	p.Set(testMalformedMultiPolygon)
+	require.True(p.Valid)
+	require.EqualValues(testMalformedMultiPolygon, p.ValueOrZero())
+}
+
+func TestSFMultiPolygonNull(t *testing.T) {
+	require := require.New(t)
+
+	p := null.NewSFMultiPolygon(testSFMultiPolygonXY)
+
+	p.Null()
+	require.False(p.Valid)
+}
+
+func TestSFMultiPolygonIsNil(t *testing.T) {
+	require := require.New(t)
+
+	p := null.NewSFMultiPolygonXY(testMultiPolygonFirst, testMultiPolygonSecond)
+	require.False(p.IsNil())
+
+	malformed := null.NewSFMultiPolygon(testMalformedMultiPolygon)
+	require.False(malformed.IsNil())
+
+	nul := null.NewSFMultiPolygonXY()
+	require.False(nul.IsNil())
+
+	empty := null.SFMultiPolygon{}
+	require.True(empty.IsNil())
+}
+
+func TestSFMultiPolygonIsZero(t *testing.T) {
+	require := require.New(t)
+
+	p := null.NewSFMultiPolygonXY(testMultiPolygonFirst, testMultiPolygonSecond)
+	require.False(p.IsZero())
+
+	malformed := null.NewSFMultiPolygon(testMalformedMultiPolygon)
+	require.True(malformed.IsZero())
+
+	nul := null.NewSFMultiPolygonXY()
+	require.True(nul.IsZero())
+
+	empty := null.SFMultiPolygon{}
+	require.True(empty.IsZero())
+}
+
+func TestSFMultiPolygonSQLValue(t *testing.T) {
+	require := require.New(t)
+	var val driver.Value
+	var err error
+
+	p := null.NewSFMultiPolygonXY(testMultiPolygonFirst, testMultiPolygonSecond)
+	val, err = p.Value()
+	require.NoError(err)
+	require.EqualValues(testMultiPolygonWKB, val)
+
+	n := null.SFMultiPolygon{}
+	val, err = n.Value()
+	require.NoError(err)
+	require.Nil(val)
+}
+
+func TestSFMultiPolygonSQLScan(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	var p null.SFMultiPolygon
+	err = p.Scan(driver.Value(testMultiPolygonWKB))
+	require.NoError(err)
+	require.Equal(null.NewSFMultiPolygon(testSFMultiPolygonXY), p)
+
+	var n null.SFMultiPolygon
+	err = n.Scan(driver.Value(nil))
+	require.NoError(err)
+	require.Equal(null.NullSFMultiPolygon(), n)
+}
+
+func TestSFMultiPolygonMarshalJSON(t *testing.T) {
+	require := require.New(t)
+	var data []byte
+	var err error
+
+	p := null.NewSFMultiPolygonXY(testMultiPolygonFirst, testMultiPolygonSecond)
+	data, err = json.Marshal(p)
+	require.NoError(err)
+	require.EqualValues(testMultiPolygonGeoJSON, data)
+	data, err = json.Marshal(&p)
+	require.NoError(err)
+	require.EqualValues(testMultiPolygonGeoJSON, data)
+
+	n := null.SFMultiPolygon{}
+	data, err = json.Marshal(n)
+	require.NoError(err)
+	require.EqualValues("null", data)
+	data, err = json.Marshal(&n)
+	require.NoError(err)
+	require.EqualValues("null", data)
+}
+
+func TestSFMultiPolygonUnmarshalJSON(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	var p null.SFMultiPolygon
+	err = json.Unmarshal(testMultiPolygonGeoJSON, &p)
+	require.NoError(err)
+	require.Equal(null.NewSFMultiPolygon(testSFMultiPolygonXY), p)
+
+	var n null.SFMultiPolygon
+	err = json.Unmarshal([]byte("null"), &n)
+	require.NoError(err)
+	require.False(n.Valid)
+}
+
+func TestSFMultiPolygonMarshsalMapValue(t *testing.T) {
+	require := require.New(t)
+	type Wrapper struct{ MultiPolygon null.SFMultiPolygon }
+	var wrapper Wrapper
+	var data map[string]interface{}
+	var err error
+
+	wrapper = Wrapper{null.NewSFMultiPolygonXY(testMultiPolygonFirst, testMultiPolygonSecond)}
+	data, err = maps.Marshal(wrapper)
+	require.NoError(err)
+	require.Equal(testSFMultiPolygonXY, data["MultiPolygon"])
+	data, err = maps.Marshal(&wrapper)
+	require.NoError(err)
+	require.Equal(testSFMultiPolygonXY, data["MultiPolygon"])
+}
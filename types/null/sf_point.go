@@ -118,6 +118,10 @@ func (p SFPoint) Value() (driver.Value, error) {
 // an SQL database. A zero-length or nil []byte will be considered NULL, and p
 // will be nulled. Otherwise, the value will be passed to types.SFPoint to be
 // scanned and parsed as a WKB Point.
+//
+// A string will be treated as GeoJSON text, and is only accepted when
+// types.GeometryValueOutput is types.GeometryValueGeoJSON. An empty string
+// will be considered NULL.
 func (p *SFPoint) Scan(src interface{}) error {
 	if p == nil {
 		return fmt.Errorf("null.SFPoint: Scan called on nil pointer")
@@ -138,6 +142,18 @@ func (p *SFPoint) Scan(src interface{}) error {
 		}
 		p.Valid = true
 		return nil
+	case string:
+		if len(x) == 0 {
+			p.Point = types.SFPoint{}
+			p.Valid = false
+			return nil
+		}
+		err := p.Point.Scan(x)
+		if err != nil {
+			return err
+		}
+		p.Valid = true
+		return nil
 	default:
 		return fmt.Errorf("null.SFPoint: cannot scan type %T (%v)", src, src)
 	}
@@ -138,6 +138,30 @@ func TestSFPointSQLScan(t *testing.T) {
 	require.Equal(null.NullSFPoint(), n)
 }
 
+func TestSFPointSQLScanGeoJSON(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	types.GeometryValueOutput = types.GeometryValueGeoJSON
+	defer func() { types.GeometryValueOutput = types.GeometryValueWKB }()
+
+	p := null.NewSFPoint(testSFPointXY)
+	val, err := p.Value()
+	require.NoError(err)
+	require.Equal(string(testPointXYGeoJSON), val)
+
+	var s null.SFPoint
+	err = s.Scan(val)
+	require.NoError(err)
+	require.True(s.Valid)
+	require.Equal(testSFPointXY.Coords(), s.Point.Coords())
+
+	n := null.NewSFPoint(testSFPointXY)
+	err = n.Scan("")
+	require.NoError(err)
+	require.False(n.Valid)
+}
+
 func TestSFPointMarshalJSON(t *testing.T) {
 	require := require.New(t)
 	var data []byte
@@ -118,6 +118,10 @@ func (p SFPolygon) Value() (driver.Value, error) {
 // an SQL database. A zero-length or nil []byte will be considered NULL, and p
 // will be nulled. Otherwise, the value will be passed to types.SFPolygon to be
 // scanned and parsed as a WKB Polygon.
+//
+// A string will be treated as GeoJSON text, and is only accepted when
+// types.GeometryValueOutput is types.GeometryValueGeoJSON. An empty string
+// will be considered NULL.
 func (p *SFPolygon) Scan(src interface{}) error {
 	if p == nil {
 		return fmt.Errorf("null.SFPolygon: Scan called on nil pointer")
@@ -138,6 +142,18 @@ func (p *SFPolygon) Scan(src interface{}) error {
 		}
 		p.Valid = true
 		return nil
+	case string:
+		if len(x) == 0 {
+			p.Polygon = types.SFPolygon{}
+			p.Valid = false
+			return nil
+		}
+		err := p.Polygon.Scan(x)
+		if err != nil {
+			return err
+		}
+		p.Valid = true
+		return nil
 	default:
 		return fmt.Errorf("null.SFPolygon: cannot scan type %T (%v)", src, src)
 	}
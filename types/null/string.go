@@ -4,8 +4,16 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"reflect"
+	"unicode/utf8"
 )
 
+// ScanValidUTF8, if true, causes String.Scan to return an error -- leaving the
+// String unchanged -- when the scanned value is not valid UTF-8. Invalid UTF-8
+// is usually the result of scanning binary data from a misconfigured column,
+// and would otherwise only surface later, when the String is marshaled.
+var ScanValidUTF8 = false
+
 // String is a wrapper around the database/sql NullString type that implements
 // all of the pyrrho/encoding/types interfaces detailed in the package comments
 // that sql.NullString doesn't implement out of the box.
@@ -73,6 +81,45 @@ func (s String) IsZero() bool {
 	return !s.Valid || s.String == ""
 }
 
+// Scan implements the database/sql Scanner interface. It defers to
+// sql.NullString's Scan and, if ScanValidUTF8 is set, additionally checks that
+// the scanned value is valid UTF-8. A []rune, or a fixed-size byte array --
+// as delivered by some uncommon drivers -- will be converted to a string
+// before it is scanned.
+func (s *String) Scan(src interface{}) error {
+	if s == nil {
+		return fmt.Errorf("null.String: Scan called on nil pointer")
+	}
+	src = stringScanSrc(src)
+	if !ScanValidUTF8 {
+		return s.NullString.Scan(src)
+	}
+	var tmp sql.NullString
+	if err := tmp.Scan(src); err != nil {
+		return err
+	}
+	if tmp.Valid && !utf8.ValidString(tmp.String) {
+		return fmt.Errorf("null.String: cannot scan invalid UTF-8 %q", tmp.String)
+	}
+	s.NullString = tmp
+	return nil
+}
+
+// stringScanSrc returns src converted to a string if it is a []rune or an array
+// of bytes, and returns src unmodified otherwise.
+func stringScanSrc(src interface{}) interface{} {
+	if r, ok := src.([]rune); ok {
+		return string(r)
+	}
+	v := reflect.ValueOf(src)
+	if v.Kind() == reflect.Array && v.Type().Elem().Kind() == reflect.Uint8 {
+		b := make([]byte, v.Len())
+		reflect.Copy(reflect.ValueOf(b), v)
+		return string(b)
+	}
+	return src
+}
+
 // MarshalJSON implements the encoding/json Marshaler interface. It will return
 // the value of s if valid, otherwise 'null'.
 func (s String) MarshalJSON() ([]byte, error) {
@@ -147,6 +147,57 @@ func TestStringSQLScan(t *testing.T) {
 	require.NoError(err)
 	require.True(b.Valid)
 	require.Equal("true", b.String)
+
+	var r null.String
+	err = r.Scan([]rune("héllo"))
+	require.NoError(err)
+	require.True(r.Valid)
+	require.Equal("héllo", r.String)
+
+	var a null.String
+	err = a.Scan([4]byte{'t', 'e', 's', 't'})
+	require.NoError(err)
+	require.True(a.Valid)
+	require.Equal("test", a.String)
+}
+
+func TestStringSQLScanValidUTF8(t *testing.T) {
+	require := require.New(t)
+	var err error
+	invalid := []byte{'a', 0xff, 'b'}
+
+	// By default, invalid UTF-8 is scanned as-is ...
+	var lax null.String
+	err = lax.Scan(invalid)
+	require.NoError(err)
+	require.True(lax.Valid)
+	require.Equal(string(invalid), lax.String)
+
+	// ... but with ScanValidUTF8 set, it's an error, and the String is
+	// unchanged.
+	null.ScanValidUTF8 = true
+	defer func() { null.ScanValidUTF8 = false }()
+
+	strict := null.NewString("unchanged")
+	err = strict.Scan(invalid)
+	require.Error(err)
+	require.Contains(err.Error(), "UTF-8")
+	require.Equal(null.NewString("unchanged"), strict)
+
+	err = strict.Scan(string(invalid))
+	require.Error(err)
+
+	// Valid UTF-8 and NULLs are unaffected.
+	var valid null.String
+	err = valid.Scan([]byte("héllo"))
+	require.NoError(err)
+	require.True(valid.Valid)
+	require.Equal("héllo", valid.String)
+
+	var nul null.String
+	err = nul.Scan(nil)
+	require.NoError(err)
+	require.False(nul.Valid)
 }
 
 func TestStringMarshalJSON(t *testing.T) {
@@ -8,6 +8,31 @@ import (
 	"time"
 )
 
+// TimeLayouts is the list of layouts, as understood by time.Parse, that
+// NewTimeStr, Time.UnmarshalJSON, and Time.UnmarshalText will try -- in order
+// -- when parsing a string. If none of them match, the string will be parsed
+// as an ISO 8601 timestamp. TimeLayouts is read at parse-time, and should be
+// set once during program initialization.
+var TimeLayouts = []string{time.RFC3339}
+
+// TimeOutputLayout is the layout, as understood by time.Time.Format, that
+// Time.MarshalJSON and Time.MarshalText will use to render valid Times. It
+// defaults to time.RFC3339Nano, the RFC 3339 layout time.Time itself marshals
+// with. TimeOutputLayout is read at marshal-time, and should be set once
+// during program initialization.
+var TimeOutputLayout = time.RFC3339Nano
+
+// parseTime parses s with each of TimeLayouts in turn, falling back to ISO
+// 8601.
+func parseTime(s string) (time.Time, error) {
+	for _, layout := range TimeLayouts {
+		if tmp, err := time.Parse(layout, s); err == nil {
+			return tmp, nil
+		}
+	}
+	return iso8601.Parse([]byte(s))
+}
+
 // Time is a nullable wrapper around the time.Time type implementing all of the
 // pyrrho/encoding/types interfaces detailed in the package comments.
 //
@@ -37,14 +62,15 @@ func NewTime(t time.Time) Time {
 	}
 }
 
-// NewTimeStr parses a given string, s, as an ISO 8601 and returns
+// NewTimeStr parses a given string, s, with each of TimeLayouts in turn --
+// falling back to ISO 8601 -- and returns a new, valid Time holding the
+// result. If s is the empty string, a new null Time will be returned.
 func NewTimeStr(s string) (Time, error) {
 	if len(s) == 0 {
 		return Time{}, nil
 	}
 
-	var tmp time.Time
-	tmp, err := iso8601.Parse([]byte(s))
+	tmp, err := parseTime(s)
 	if err != nil {
 		return Time{}, err
 	}
@@ -54,6 +80,22 @@ func NewTimeStr(s string) (Time, error) {
 	}, nil
 }
 
+// NewTimeStrLayout parses a given string, s, with the given layout, as
+// time.Parse would, and returns a new, valid Time holding the result. This is
+// useful for database-style strings that are not ISO 8601; eg. MySQL DATETIME
+// values, with the layout "2006-01-02 15:04:05". If s is the empty string, a
+// new null Time will be returned.
+func NewTimeStrLayout(s, layout string) (Time, error) {
+	if len(s) == 0 {
+		return NullTime(), nil
+	}
+	tmp, err := time.Parse(layout, s)
+	if err != nil {
+		return Time{}, err
+	}
+	return NewTime(tmp), nil
+}
+
 // Getters and Setters
 
 // ValueOrZero returns the value of t if it is valid; otherwise it returns the
@@ -123,18 +165,18 @@ func (t *Time) Scan(src interface{}) error {
 }
 
 // MarshalJSON implements the encoding/json Marshaler interface. It will encode
-// t into its JSON RFC 3339 string representation if valid, or
-// 'null' otherwise.
+// t into a JSON string, formatted with TimeOutputLayout, if valid, or 'null'
+// otherwise.
 func (t Time) MarshalJSON() ([]byte, error) {
 	if !t.Valid {
 		return []byte("null"), nil
 	}
-	return t.Time.MarshalJSON()
+	return json.Marshal(t.Time.Format(TimeOutputLayout))
 }
 
 // UnmarshalJSON implements the encoding/json Unmarshaler interface. It will
-// decode a given []byte into t so long as the provided []byte
-// is a valid JSON representation of an ISO 8601 string. Empty strings and
+// decode a given []byte into t so long as the provided []byte is a valid JSON
+// string matching one of TimeLayouts, or ISO 8601. Empty strings and
 // the 'null' keyword will both decode into a null NullTime.
 //
 // If the decode fails, the value of t will be unchanged.
@@ -153,10 +195,7 @@ func (t *Time) UnmarshalJSON(data []byte) error {
 			t.Valid = false
 			return nil
 		}
-		// TODO: If time.Time.UnmarshalJSON doesn't change the value of the
-		// receiver we could skip the temporary object by calling .UnmarshalJSON
-		// on t.Time.
-		tmp, err := iso8601.Parse([]byte(val))
+		tmp, err := parseTime(val)
 		if err != nil {
 			return err
 		}
@@ -173,6 +212,37 @@ func (t *Time) UnmarshalJSON(data []byte) error {
 	}
 }
 
+// MarshalText implements the encoding TextMarshaler interface. It will encode
+// t into its text representation, formatted with TimeOutputLayout, if valid, or
+// an empty []byte otherwise.
+func (t Time) MarshalText() ([]byte, error) {
+	if !t.Valid {
+		return []byte{}, nil
+	}
+	return []byte(t.Time.Format(TimeOutputLayout)), nil
+}
+
+// UnmarshalText implements the encoding TextUnmarshaler interface. It will
+// decode text matching one of TimeLayouts, or ISO 8601, into t. Empty text will
+// decode into a null Time.
+//
+// If the decode fails, the value of t will be unchanged.
+func (t *Time) UnmarshalText(text []byte) error {
+	if t == nil {
+		return fmt.Errorf("null.Time: UnmarshalText called on nil pointer")
+	}
+	if len(text) == 0 {
+		t.Null()
+		return nil
+	}
+	tmp, err := parseTime(string(text))
+	if err != nil {
+		return err
+	}
+	t.Set(tmp)
+	return nil
+}
+
 // MarshalMapValue implements the pyrrho/encoding/maps Marshaler interface. It
 // will encode t into an interface{} representation for use in a
 // map[Time]interface{} if valid, or return nil otherwise.
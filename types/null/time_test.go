@@ -8,6 +8,7 @@ import (
 
 	"github.com/pyrrho/encoding/maps"
 	"github.com/pyrrho/encoding/types/null"
+	"github.com/pyrrho/encoding/types/typestest"
 	"github.com/stretchr/testify/require"
 )
 
@@ -67,6 +68,28 @@ func TestTimeCtors(t *testing.T) {
 	require.False(badString.Valid)
 }
 
+func TestTimeStrLayout(t *testing.T) {
+	require := require.New(t)
+
+	// MySQL DATETIME strings have no zone, and so are parsed as UTC.
+	ti, err := null.NewTimeStrLayout("2012-12-21 21:21:21", "2006-01-02 15:04:05")
+	require.NoError(err)
+	require.True(ti.Valid)
+	require.Equal(timeValue, ti.Time)
+
+	date, err := null.NewTimeStrLayout("2012-12-21", "2006-01-02")
+	require.NoError(err)
+	require.True(date.Valid)
+	require.Equal(time.Date(2012, time.December, 21, 0, 0, 0, 0, time.UTC), date.Time)
+
+	nul, err := null.NewTimeStrLayout("", "2006-01-02 15:04:05")
+	require.NoError(err)
+	require.False(nul.Valid)
+
+	_, err = null.NewTimeStrLayout(timeString, "2006-01-02 15:04:05")
+	require.Error(err)
+}
+
 func TestTimeValueOrZero(t *testing.T) {
 	require := require.New(t)
 
@@ -285,3 +308,78 @@ func TestTimeMarshalMapValue(t *testing.T) {
 	require.NoError(err)
 	require.Equal(map[string]interface{}{"Time": nil}, data)
 }
+
+func TestTimeMarshalText(t *testing.T) {
+	require := require.New(t)
+
+	data, err := null.NewTime(timeValue).MarshalText()
+	require.NoError(err)
+	require.EqualValues(timeString, data)
+
+	data, err = null.Time{}.MarshalText()
+	require.NoError(err)
+	require.Empty(data)
+}
+
+func TestTimeUnmarshalText(t *testing.T) {
+	require := require.New(t)
+
+	var ti null.Time
+	require.NoError(ti.UnmarshalText([]byte(timeString)))
+	require.Equal(null.NewTime(timeValue), ti)
+
+	require.NoError(ti.UnmarshalText([]byte{}))
+	require.False(ti.Valid)
+
+	ti = null.NewTime(timeValue)
+	require.Error(ti.UnmarshalText([]byte("December 12th, 12:02")))
+	require.Equal(null.NewTime(timeValue), ti)
+}
+
+func TestTimeLayouts(t *testing.T) {
+	require := require.New(t)
+	defer func(layouts []string, output string) {
+		null.TimeLayouts = layouts
+		null.TimeOutputLayout = output
+	}(null.TimeLayouts, null.TimeOutputLayout)
+
+	null.TimeLayouts = []string{time.RFC3339, "2006-01-02", time.RFC1123}
+
+	// RFC 3339 still parses.
+	ti, err := null.NewTimeStr(timeString)
+	require.NoError(err)
+	require.Equal(null.NewTime(timeValue), ti)
+
+	date := null.NewTime(time.Date(2012, 12, 21, 0, 0, 0, 0, time.UTC))
+	ti, err = null.NewTimeStr("2012-12-21")
+	require.NoError(err)
+	require.Equal(date, ti)
+	ti = null.Time{}
+	require.NoError(json.Unmarshal([]byte(`"2012-12-21"`), &ti))
+	require.Equal(date, ti)
+	ti = null.Time{}
+	require.NoError(ti.UnmarshalText([]byte("2012-12-21")))
+	require.Equal(date, ti)
+
+	ti, err = null.NewTimeStr("Fri, 21 Dec 2012 21:21:21 UTC")
+	require.NoError(err)
+	require.True(ti.Valid)
+	require.True(timeValue.Equal(ti.Time))
+
+	_, err = null.NewTimeStr("December 12th, 12:02")
+	require.Error(err)
+
+	null.TimeOutputLayout = "2006-01-02"
+	data, err := json.Marshal(null.NewTime(timeValue))
+	require.NoError(err)
+	require.EqualValues(`"2012-12-21"`, data)
+	data, err = null.NewTime(timeValue).MarshalText()
+	require.NoError(err)
+	require.EqualValues("2012-12-21", data)
+}
+
+func TestTimeConformance(t *testing.T) {
+	typestest.AssertInterfaceConformance(t, null.NewTime(timeValue))
+	typestest.AssertInterfaceConformance(t, null.NewTime(time.Time{}))
+	typestest.AssertInterfaceConformance(t, null.Time{})
+}
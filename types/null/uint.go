@@ -0,0 +1,223 @@
+package null
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+)
+
+// Uint is a nullable wrapper around the platform-dependent uint type that
+// implements all of the pyrrho/encoding/types interfaces detailed in the
+// package comments.
+//
+// If the Uint is valid and contains 0, it will be considered non-nil, and of
+// zero value.
+type Uint struct {
+	Uint  uint
+	Valid bool
+}
+
+// The upper bound of the platform-dependent uint type.
+const maxUint = 1<<strconv.IntSize - 1
+
+// Constructors
+
+// NullUint constructs and returns a new null Uint.
+func NullUint() Uint {
+	return Uint{
+		Uint:  0,
+		Valid: false,
+	}
+}
+
+// NewUint constructs and returns a new, valid Uint initialized with the value
+// of the given i.
+func NewUint(i uint) Uint {
+	return Uint{
+		Uint:  i,
+		Valid: true,
+	}
+}
+
+// Getters and Setters
+
+// ValueOrZero returns the value of i if it is valid; otherwise it returns the
+// zero value for a uint (0).
+func (i Uint) ValueOrZero() uint {
+	if !i.Valid {
+		return 0
+	}
+	return i.Uint
+}
+
+// Set modifies the value stored in i, and guarantees it is valid.
+func (i *Uint) Set(v uint) {
+	i.Uint = v
+	i.Valid = true
+}
+
+// Null marks i as null with no meaningful value.
+func (i *Uint) Null() {
+	i.Uint = 0
+	i.Valid = false
+}
+
+// Interfaces
+
+// IsNil implements the pyrrho/encoding IsNiler interface. It will return true
+// if i is null.
+func (i Uint) IsNil() bool {
+	return !i.Valid
+}
+
+// IsZero implements the pyrrho/encoding IsZeroer interface. It will return true
+// if i is null or if its value is 0.
+func (i Uint) IsZero() bool {
+	return !i.Valid || i.Uint == 0
+}
+
+// Value implements the database/sql/driver Valuer interface. Nil is a valid
+// type to be stored in a driver.Value, but uint isn't, so if this Uint is
+// valid it will cast its uint to an int64. Values greater than math.MaxInt64
+// cannot be represented by a driver.Value, and will result in an error.
+func (i Uint) Value() (driver.Value, error) {
+	if !i.Valid {
+		return nil, nil
+	}
+	if uint64(i.Uint) > math.MaxInt64 {
+		return nil, fmt.Errorf("null.Uint: cannot store value %d; overflows int64", i.Uint)
+	}
+	return int64(i.Uint), nil
+}
+
+// Scan implements the database/sql Scanner interface. It will receive a value
+// from an SQL database and assign it to i, so long as the provided data is of
+// type nil, uint, string, or another integer or float type that doesn't
+// overflow uint and is not negative. All other types will result in an error.
+func (i *Uint) Scan(src interface{}) error {
+	if i == nil {
+		return fmt.Errorf("null.Uint: Scan called on nil pointer")
+	}
+	if src == nil {
+		i.Uint = 0
+		i.Valid = false
+		return nil
+	}
+
+	switch val := src.(type) {
+	case uint:
+		i.Uint = val
+		i.Valid = true
+		return nil
+	case uint8, uint16, uint32, uint64:
+		v := reflect.ValueOf(src)
+		vi := v.Uint()
+		if vi > maxUint {
+			return fmt.Errorf("null.Uint: failed to scan type %T (%v): overflow", src, src)
+		}
+		i.Uint = uint(vi)
+		i.Valid = true
+		return nil
+	case int, int8, int16, int32, int64:
+		v := reflect.ValueOf(src)
+		vi := v.Int()
+		if vi < 0 {
+			return fmt.Errorf("null.Uint: failed to scan type %T (%v): negative value", src, src)
+		} else if uint64(vi) > maxUint {
+			return fmt.Errorf("null.Uint: failed to scan type %T (%v): overflow", src, src)
+		}
+		i.Uint = uint(vi)
+		i.Valid = true
+		return nil
+	case string:
+		parsed, err := strconv.ParseUint(val, 10, 0)
+		if err != nil {
+			return fmt.Errorf("null.Uint: failed to scan type %T (%v): %v", src, src, err)
+		}
+		i.Uint = uint(parsed)
+		i.Valid = true
+		return nil
+	case float64:
+		// Use a string intermediate so we can generate an error on any loss of
+		// precision.
+		s := strconv.FormatFloat(val, 'f', -1, 64)
+		parsed, err := strconv.ParseUint(s, 10, 0)
+		if err != nil {
+			return fmt.Errorf("null.Uint: failed to convert driver.Value type %T (%v): %v", src, s, err)
+		}
+		i.Uint = uint(parsed)
+		i.Valid = true
+		return nil
+	case float32:
+		// Use a string intermediate so we can generate an error on any loss of
+		// precision.
+		s := strconv.FormatFloat(float64(val), 'f', -1, 32)
+		parsed, err := strconv.ParseUint(s, 10, 0)
+		if err != nil {
+			return fmt.Errorf("null.Uint: failed to convert driver.Value type %T (%v): %v", src, s, err)
+		}
+		i.Uint = uint(parsed)
+		i.Valid = true
+		return nil
+	default:
+		return fmt.Errorf("null.Uint: cannot scan type %T (%v)", src, src)
+	}
+}
+
+// MarshalJSON implements the encoding/json Marshaler interface. It will encode
+// i into its JSON representation if valid, or 'null' otherwise.
+func (i Uint) MarshalJSON() ([]byte, error) {
+	if !i.Valid {
+		return []byte("null"), nil
+	}
+	return []byte(strconv.FormatUint(uint64(i.Uint), 10)), nil
+}
+
+// UnmarshalJSON implements the encoding/json Unmarshaler interface. It will
+// decode a given []byte into i, so long as the provided []byte is a valid JSON
+// representation of an int. The 'null' keyword will decode into a null Uint.
+//
+// If the decode fails, the value of i will be unchanged.
+func (i *Uint) UnmarshalJSON(data []byte) error {
+	if i == nil {
+		return fmt.Errorf("null.Uint: UnmarshalJSON called on nil pointer")
+	}
+	var j interface{}
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+	switch val := j.(type) {
+	case float64:
+		// Perform a second unmarshal, this time into a uint. This give the
+		// JSON parse a chance to meaningfully fail (eg. if the conversion from
+		// float to integer will result in a loss of precision).
+		var tmp uint
+		err := json.Unmarshal(data, &tmp)
+		if err != nil {
+			return err
+		}
+		i.Uint = tmp
+		i.Valid = true
+		return nil
+	case nil:
+		i.Uint = 0
+		i.Valid = false
+		return nil
+	default:
+		return fmt.Errorf("null.Uint: cannot unmarshal JSON of type %T (%v)",
+			val, data)
+	}
+}
+
+// MarshalMapValue implements the pyrrho/encoding/maps Marshaler interface. It
+// will encode i into its interface{} representation for use in a
+// map[string]interface{} if valid, or return nil otherwise.
+func (i Uint) MarshalMapValue() (interface{}, error) {
+	if i.Valid {
+		return i.Uint, nil
+	}
+	return nil, nil
+}
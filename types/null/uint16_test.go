@@ -0,0 +1,325 @@
+package null_test
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/pyrrho/encoding/maps"
+	"github.com/pyrrho/encoding/types/null"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUint16Ctors(t *testing.T) {
+	require := require.New(t)
+
+	// null.NullUint16() returns a new null null.Uint16.
+	// This is equivalent to null.Uint16{}.
+	nul := null.NullUint16()
+	require.False(nul.Valid)
+
+	empty := null.Uint16{}
+	require.False(empty.Valid)
+
+	// null.NewUint16 constructs a new, valid null.Uint16.
+	i := null.NewUint16(12345)
+	require.True(i.Valid)
+	require.Equal(uint16(12345), i.Uint16)
+
+	z := null.NewUint16(0)
+	require.True(z.Valid)
+	require.Equal(uint16(0), z.Uint16)
+}
+
+func TestUint16ValueOrZero(t *testing.T) {
+	require := require.New(t)
+
+	valid := null.NewUint16(12345)
+	require.Equal(uint16(12345), valid.ValueOrZero())
+
+	nul := null.Uint16{}
+	require.Equal(uint16(0), nul.ValueOrZero())
+}
+
+func TestUint16Set(t *testing.T) {
+	require := require.New(t)
+
+	i := null.Uint16{}
+	require.False(i.Valid)
+
+	i.Set(12345)
+	require.True(i.Valid)
+	require.Equal(uint16(12345), i.Uint16)
+
+	i.Set(0)
+	require.True(i.Valid)
+	require.Equal(uint16(0), i.Uint16)
+}
+
+func TestUint16Null(t *testing.T) {
+	require := require.New(t)
+
+	i := null.NewUint16(12345)
+
+	i.Null()
+	require.False(i.Valid)
+}
+
+func TestUint16SQLScanBounds(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	var max null.Uint16
+	err = max.Scan(uint64(math.MaxUint16))
+	require.NoError(err)
+	require.Equal(uint16(math.MaxUint16), max.Uint16)
+
+	var over null.Uint16
+	err = over.Scan(uint64(math.MaxUint16) + 1)
+	require.Error(err)
+
+	var strOver null.Uint16
+	err = strOver.Scan("65536")
+	require.Error(err)
+
+	var negative null.Uint16
+	err = negative.Scan(int64(-1))
+	require.Error(err)
+	require.Contains(err.Error(), "negative value")
+}
+
+func TestUint16IsNil(t *testing.T) {
+	require := require.New(t)
+
+	i := null.NewUint16(12345)
+	require.False(i.IsNil())
+
+	z := null.NewUint16(0)
+	require.False(z.IsNil())
+
+	nul := null.Uint16{}
+	require.True(nul.IsNil())
+}
+
+func TestUint16IsZero(t *testing.T) {
+	require := require.New(t)
+
+	i := null.NewUint16(12345)
+	require.False(i.IsZero())
+
+	z := null.NewUint16(0)
+	require.True(z.IsZero())
+
+	nul := null.Uint16{}
+	require.True(nul.IsZero())
+}
+
+func TestUint16SQLValue(t *testing.T) {
+	require := require.New(t)
+	var val driver.Value
+	var err error
+
+	i := null.NewUint16(12345)
+	val, err = i.Value()
+	require.NoError(err)
+	require.Equal(int64(12345), val)
+
+	z := null.NewUint16(0)
+	val, err = z.Value()
+	require.NoError(err)
+	require.Equal(int64(0), val)
+
+	nul := null.Uint16{}
+	val, err = nul.Value()
+	require.NoError(err)
+	require.Equal(nil, val)
+}
+
+func TestUint16SQLScan(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	var i null.Uint16
+	err = i.Scan(12345)
+	require.NoError(err)
+	require.True(i.Valid)
+	require.Equal(uint16(12345), i.Uint16)
+
+	var str null.Uint16
+	// NB. Scan will coerce strings, but UnmarshalJSON won't.
+	err = str.Scan("12345")
+	require.NoError(err)
+	require.True(str.Valid)
+	require.Equal(uint16(12345), str.Uint16)
+
+	var whole null.Uint16
+	// Whole floats can be scanned without loss of precision.
+	err = whole.Scan(float64(12345))
+	require.NoError(err)
+	require.True(whole.Valid)
+	require.Equal(uint16(12345), whole.Uint16)
+
+	var nul null.Uint16
+	err = nul.Scan(nil)
+	require.NoError(err)
+	require.False(nul.Valid)
+
+	var wrong null.Uint16
+	err = wrong.Scan("hello world")
+	require.Error(err)
+
+	var overflow null.Uint16
+	err = overflow.Scan(uint64(math.MaxUint16) + 1)
+	require.Error(err)
+	require.Contains(err.Error(), "null.Uint16:")
+
+	var negative null.Uint16
+	err = negative.Scan(-12345)
+	require.Error(err)
+
+	var f null.Uint16
+	err = f.Scan(1.2345)
+	require.Error(err)
+
+	var b null.Uint16
+	err = b.Scan(true)
+	require.Error(err)
+}
+
+func TestUint16MarshalJSON(t *testing.T) {
+	require := require.New(t)
+	var data []byte
+	var err error
+
+	i := null.NewUint16(12345)
+	data, err = json.Marshal(i)
+	require.NoError(err)
+	require.EqualValues("12345", data)
+	data, err = json.Marshal(&i)
+	require.NoError(err)
+	require.EqualValues("12345", data)
+
+	z := null.NewUint16(0)
+	data, err = json.Marshal(z)
+	require.NoError(err)
+	require.EqualValues("0", data)
+	data, err = json.Marshal(&z)
+	require.NoError(err)
+	require.EqualValues("0", data)
+
+	nul := null.Uint16{}
+	data, err = json.Marshal(nul)
+	require.NoError(err)
+	require.EqualValues("null", data)
+	data, err = json.Marshal(&nul)
+	require.NoError(err)
+	require.EqualValues("null", data)
+}
+
+func TestUint16UnmarshalJSON(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	// Successful Valid Parses
+
+	var i null.Uint16
+	err = json.Unmarshal([]byte("12345"), &i)
+	require.NoError(err)
+	require.True(i.Valid)
+	require.Equal(uint16(12345), i.Uint16)
+
+	// Successful Null Parses
+
+	var nul null.Uint16
+	err = json.Unmarshal([]byte("null"), &nul)
+	require.NoError(err)
+	require.False(nul.Valid)
+
+	// Unsuccessful Parses
+	// TODO: make types for type mismatches on parsing, and check that the
+	// correct error type is being returned here.
+
+	var intStr null.Uint16
+	// Ints wrapped in quotes aren't ints.
+	err = json.Unmarshal([]byte(`"12345"`), &intStr)
+	require.Error(err)
+
+	var empty null.Uint16
+	err = json.Unmarshal([]byte(""), &empty)
+	require.Error(err)
+
+	var quotes null.Uint16
+	err = json.Unmarshal([]byte(`""`), &quotes)
+	require.Error(err)
+
+	var f null.Uint16
+	// Non-integer numbers should not be coerced to ints.
+	err = json.Unmarshal([]byte("1.2345"), &f)
+	require.Error(err)
+
+	var negative null.Uint16
+	err = json.Unmarshal([]byte("-12345"), &negative)
+	require.Error(err)
+
+	var invalid null.Uint16
+	err = invalid.UnmarshalJSON([]byte(":->"))
+	if _, ok := err.(*json.SyntaxError); !ok {
+		require.FailNowf(
+			"Unexpected Error Type",
+			"expected *json.SyntaxError, not %T", err)
+	}
+}
+
+func TestUint16UnmarshalJSONOverflow(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	uint16Overflow := uint64(math.MaxUint16)
+
+	// Max uint16 should decode successfully
+	var i null.Uint16
+	err = json.Unmarshal([]byte(strconv.FormatUint(uint16Overflow, 10)), &i)
+	require.NoError(err)
+	require.Equal(uint16(math.MaxUint16), i.Uint16)
+
+	// Attempt to overflow
+	uint16Overflow++
+	err = json.Unmarshal([]byte(strconv.FormatUint(uint16Overflow, 10)), &i)
+	// Decoded values should overflow uint16
+	require.Error(err)
+}
+
+func TestUint16MarshalMapValue(t *testing.T) {
+	require := require.New(t)
+	type Wrapper struct{ Uint16 null.Uint16 }
+	var wrapper Wrapper
+	var data map[string]interface{}
+	var err error
+
+	wrapper = Wrapper{null.NewUint16(12345)}
+	data, err = maps.Marshal(wrapper)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{"Uint16": uint16(12345)}, data)
+	data, err = maps.Marshal(&wrapper)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{"Uint16": uint16(12345)}, data)
+
+	wrapper = Wrapper{null.NewUint16(0)}
+	data, err = maps.Marshal(wrapper)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{"Uint16": uint16(0)}, data)
+	data, err = maps.Marshal(&wrapper)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{"Uint16": uint16(0)}, data)
+
+	// Null Uint16s should be encoded as "nil"
+	wrapper = Wrapper{null.Uint16{}}
+	data, err = maps.Marshal(wrapper)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{"Uint16": nil}, data)
+	data, err = maps.Marshal(&wrapper)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{"Uint16": nil}, data)
+}
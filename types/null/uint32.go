@@ -0,0 +1,215 @@
+package null
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+)
+
+// Uint32 is a nullable wrapper around the uint32 type that implements all of the
+// pyrrho/encoding/types interfaces detailed in the package comments.
+//
+// If the Uint32 is valid and contains 0, it will be considered non-nil, and of
+// zero value.
+type Uint32 struct {
+	Uint32 uint32
+	Valid  bool
+}
+
+// Constructors
+
+// NullUint32 constructs and returns a new null Uint32.
+func NullUint32() Uint32 {
+	return Uint32{
+		Uint32: 0,
+		Valid:  false,
+	}
+}
+
+// NewUint32 constructs and returns a new, valid Uint32 initialized with the value
+// of the given i.
+func NewUint32(i uint32) Uint32 {
+	return Uint32{
+		Uint32: i,
+		Valid:  true,
+	}
+}
+
+// Getters and Setters
+
+// ValueOrZero returns the value of i if it is valid; otherwise it returns the
+// zero value for a uint32 (0).
+func (i Uint32) ValueOrZero() uint32 {
+	if !i.Valid {
+		return 0
+	}
+	return i.Uint32
+}
+
+// Set modifies the value stored in i, and guarantees it is valid.
+func (i *Uint32) Set(v uint32) {
+	i.Uint32 = v
+	i.Valid = true
+}
+
+// Null marks i as null with no meaningful value.
+func (i *Uint32) Null() {
+	i.Uint32 = 0
+	i.Valid = false
+}
+
+// Interfaces
+
+// IsNil implements the pyrrho/encoding IsNiler interface. It will return true
+// if i is null.
+func (i Uint32) IsNil() bool {
+	return !i.Valid
+}
+
+// IsZero implements the pyrrho/encoding IsZeroer interface. It will return true
+// if i is null or if its value is 0.
+func (i Uint32) IsZero() bool {
+	return !i.Valid || i.Uint32 == 0
+}
+
+// Value implements the database/sql/driver Valuer interface. Nil is a valid
+// type to be stored in a driver.Value, but uint32 isn't, so if this Uint32 is
+// valid it will cast its uint32 to an int64.
+func (i Uint32) Value() (driver.Value, error) {
+	if !i.Valid {
+		return nil, nil
+	}
+	return int64(i.Uint32), nil
+}
+
+// Scan implements the database/sql Scanner interface. It will receive a value
+// from an SQL database and assign it to i, so long as the provided data is of
+// type nil, uint32, string, or another integer or float type that doesn't
+// overflow uint32 and is not negative. All other types will result in an error.
+func (i *Uint32) Scan(src interface{}) error {
+	if i == nil {
+		return fmt.Errorf("null.Uint32: Scan called on nil pointer")
+	}
+	if src == nil {
+		i.Uint32 = 0
+		i.Valid = false
+		return nil
+	}
+
+	switch val := src.(type) {
+	case uint32:
+		i.Uint32 = val
+		i.Valid = true
+		return nil
+	case uint, uint8, uint16, uint64:
+		v := reflect.ValueOf(src)
+		vi := v.Uint()
+		if vi > math.MaxUint32 {
+			return fmt.Errorf("null.Uint32: failed to scan type %T (%v): overflow", src, src)
+		}
+		i.Uint32 = uint32(vi)
+		i.Valid = true
+		return nil
+	case int, int8, int16, int32, int64:
+		v := reflect.ValueOf(src)
+		vi := v.Int()
+		if vi < 0 {
+			return fmt.Errorf("null.Uint32: failed to scan type %T (%v): negative value", src, src)
+		} else if uint64(vi) > math.MaxUint32 {
+			return fmt.Errorf("null.Uint32: failed to scan type %T (%v): overflow", src, src)
+		}
+		i.Uint32 = uint32(vi)
+		i.Valid = true
+		return nil
+	case string:
+		parsed, err := strconv.ParseUint(val, 10, 32)
+		if err != nil {
+			return fmt.Errorf("null.Uint32: failed to scan type %T (%v): %v", src, src, err)
+		}
+		i.Uint32 = uint32(parsed)
+		i.Valid = true
+		return nil
+	case float64:
+		// Use a string intermediate so we can generate an error on any loss of
+		// precision.
+		s := strconv.FormatFloat(val, 'f', -1, 64)
+		parsed, err := strconv.ParseUint(s, 10, 32)
+		if err != nil {
+			return fmt.Errorf("null.Uint32: failed to convert driver.Value type %T (%v): %v", src, s, err)
+		}
+		i.Uint32 = uint32(parsed)
+		i.Valid = true
+		return nil
+	case float32:
+		// Use a string intermediate so we can generate an error on any loss of
+		// precision.
+		s := strconv.FormatFloat(float64(val), 'f', -1, 32)
+		parsed, err := strconv.ParseUint(s, 10, 32)
+		if err != nil {
+			return fmt.Errorf("null.Uint32: failed to convert driver.Value type %T (%v): %v", src, s, err)
+		}
+		i.Uint32 = uint32(parsed)
+		i.Valid = true
+		return nil
+	default:
+		return fmt.Errorf("null.Uint32: cannot scan type %T (%v)", src, src)
+	}
+}
+
+// MarshalJSON implements the encoding/json Marshaler interface. It will encode
+// i into its JSON representation if valid, or 'null' otherwise.
+func (i Uint32) MarshalJSON() ([]byte, error) {
+	if !i.Valid {
+		return []byte("null"), nil
+	}
+	return []byte(strconv.FormatUint(uint64(i.Uint32), 10)), nil
+}
+
+// UnmarshalJSON implements the encoding/json Unmarshaler interface. It will
+// decode a given []byte into i, so long as the provided []byte is a valid JSON
+// representation of an int. The 'null' keyword will decode into a null Uint32.
+//
+// If the decode fails, the value of i will be unchanged.
+func (i *Uint32) UnmarshalJSON(data []byte) error {
+	if i == nil {
+		return fmt.Errorf("null.Uint32: UnmarshalJSON called on nil pointer")
+	}
+	var j interface{}
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+	switch val := j.(type) {
+	case float64:
+		// Perform a second unmarshal, this time into a uint32. This give the
+		// JSON parse a chance to meaningfully fail (eg. if the conversion from
+		// float to integer will result in a loss of precision).
+		var tmp uint32
+		err := json.Unmarshal(data, &tmp)
+		if err != nil {
+			return err
+		}
+		i.Uint32 = tmp
+		i.Valid = true
+		return nil
+	case nil:
+		i.Uint32 = 0
+		i.Valid = false
+		return nil
+	default:
+		return fmt.Errorf("null.Uint32: cannot unmarshal JSON of type %T (%v)",
+			val, data)
+	}
+}
+
+// MarshalMapValue implements the pyrrho/encoding/maps Marshaler interface. It
+// will encode i into its interface{} representation for use in a
+// map[string]interface{} if valid, or return nil otherwise.
+func (i Uint32) MarshalMapValue() (interface{}, error) {
+	if i.Valid {
+		return i.Uint32, nil
+	}
+	return nil, nil
+}
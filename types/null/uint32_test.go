@@ -0,0 +1,325 @@
+package null_test
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/pyrrho/encoding/maps"
+	"github.com/pyrrho/encoding/types/null"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUint32Ctors(t *testing.T) {
+	require := require.New(t)
+
+	// null.NullUint32() returns a new null null.Uint32.
+	// This is equivalent to null.Uint32{}.
+	nul := null.NullUint32()
+	require.False(nul.Valid)
+
+	empty := null.Uint32{}
+	require.False(empty.Valid)
+
+	// null.NewUint32 constructs a new, valid null.Uint32.
+	i := null.NewUint32(12345)
+	require.True(i.Valid)
+	require.Equal(uint32(12345), i.Uint32)
+
+	z := null.NewUint32(0)
+	require.True(z.Valid)
+	require.Equal(uint32(0), z.Uint32)
+}
+
+func TestUint32ValueOrZero(t *testing.T) {
+	require := require.New(t)
+
+	valid := null.NewUint32(12345)
+	require.Equal(uint32(12345), valid.ValueOrZero())
+
+	nul := null.Uint32{}
+	require.Equal(uint32(0), nul.ValueOrZero())
+}
+
+func TestUint32Set(t *testing.T) {
+	require := require.New(t)
+
+	i := null.Uint32{}
+	require.False(i.Valid)
+
+	i.Set(12345)
+	require.True(i.Valid)
+	require.Equal(uint32(12345), i.Uint32)
+
+	i.Set(0)
+	require.True(i.Valid)
+	require.Equal(uint32(0), i.Uint32)
+}
+
+func TestUint32Null(t *testing.T) {
+	require := require.New(t)
+
+	i := null.NewUint32(12345)
+
+	i.Null()
+	require.False(i.Valid)
+}
+
+func TestUint32SQLScanBounds(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	var max null.Uint32
+	err = max.Scan(uint64(math.MaxUint32))
+	require.NoError(err)
+	require.Equal(uint32(math.MaxUint32), max.Uint32)
+
+	var over null.Uint32
+	err = over.Scan(uint64(math.MaxUint32) + 1)
+	require.Error(err)
+
+	var strOver null.Uint32
+	err = strOver.Scan("4294967296")
+	require.Error(err)
+
+	var negative null.Uint32
+	err = negative.Scan(int64(-1))
+	require.Error(err)
+	require.Contains(err.Error(), "negative value")
+}
+
+func TestUint32IsNil(t *testing.T) {
+	require := require.New(t)
+
+	i := null.NewUint32(12345)
+	require.False(i.IsNil())
+
+	z := null.NewUint32(0)
+	require.False(z.IsNil())
+
+	nul := null.Uint32{}
+	require.True(nul.IsNil())
+}
+
+func TestUint32IsZero(t *testing.T) {
+	require := require.New(t)
+
+	i := null.NewUint32(12345)
+	require.False(i.IsZero())
+
+	z := null.NewUint32(0)
+	require.True(z.IsZero())
+
+	nul := null.Uint32{}
+	require.True(nul.IsZero())
+}
+
+func TestUint32SQLValue(t *testing.T) {
+	require := require.New(t)
+	var val driver.Value
+	var err error
+
+	i := null.NewUint32(12345)
+	val, err = i.Value()
+	require.NoError(err)
+	require.Equal(int64(12345), val)
+
+	z := null.NewUint32(0)
+	val, err = z.Value()
+	require.NoError(err)
+	require.Equal(int64(0), val)
+
+	nul := null.Uint32{}
+	val, err = nul.Value()
+	require.NoError(err)
+	require.Equal(nil, val)
+}
+
+func TestUint32SQLScan(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	var i null.Uint32
+	err = i.Scan(12345)
+	require.NoError(err)
+	require.True(i.Valid)
+	require.Equal(uint32(12345), i.Uint32)
+
+	var str null.Uint32
+	// NB. Scan will coerce strings, but UnmarshalJSON won't.
+	err = str.Scan("12345")
+	require.NoError(err)
+	require.True(str.Valid)
+	require.Equal(uint32(12345), str.Uint32)
+
+	var whole null.Uint32
+	// Whole floats can be scanned without loss of precision.
+	err = whole.Scan(float64(12345))
+	require.NoError(err)
+	require.True(whole.Valid)
+	require.Equal(uint32(12345), whole.Uint32)
+
+	var nul null.Uint32
+	err = nul.Scan(nil)
+	require.NoError(err)
+	require.False(nul.Valid)
+
+	var wrong null.Uint32
+	err = wrong.Scan("hello world")
+	require.Error(err)
+
+	var overflow null.Uint32
+	err = overflow.Scan(uint64(math.MaxUint32) + 1)
+	require.Error(err)
+	require.Contains(err.Error(), "null.Uint32:")
+
+	var negative null.Uint32
+	err = negative.Scan(-12345)
+	require.Error(err)
+
+	var f null.Uint32
+	err = f.Scan(1.2345)
+	require.Error(err)
+
+	var b null.Uint32
+	err = b.Scan(true)
+	require.Error(err)
+}
+
+func TestUint32MarshalJSON(t *testing.T) {
+	require := require.New(t)
+	var data []byte
+	var err error
+
+	i := null.NewUint32(12345)
+	data, err = json.Marshal(i)
+	require.NoError(err)
+	require.EqualValues("12345", data)
+	data, err = json.Marshal(&i)
+	require.NoError(err)
+	require.EqualValues("12345", data)
+
+	z := null.NewUint32(0)
+	data, err = json.Marshal(z)
+	require.NoError(err)
+	require.EqualValues("0", data)
+	data, err = json.Marshal(&z)
+	require.NoError(err)
+	require.EqualValues("0", data)
+
+	nul := null.Uint32{}
+	data, err = json.Marshal(nul)
+	require.NoError(err)
+	require.EqualValues("null", data)
+	data, err = json.Marshal(&nul)
+	require.NoError(err)
+	require.EqualValues("null", data)
+}
+
+func TestUint32UnmarshalJSON(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	// Successful Valid Parses
+
+	var i null.Uint32
+	err = json.Unmarshal([]byte("12345"), &i)
+	require.NoError(err)
+	require.True(i.Valid)
+	require.Equal(uint32(12345), i.Uint32)
+
+	// Successful Null Parses
+
+	var nul null.Uint32
+	err = json.Unmarshal([]byte("null"), &nul)
+	require.NoError(err)
+	require.False(nul.Valid)
+
+	// Unsuccessful Parses
+	// TODO: make types for type mismatches on parsing, and check that the
+	// correct error type is being returned here.
+
+	var intStr null.Uint32
+	// Ints wrapped in quotes aren't ints.
+	err = json.Unmarshal([]byte(`"12345"`), &intStr)
+	require.Error(err)
+
+	var empty null.Uint32
+	err = json.Unmarshal([]byte(""), &empty)
+	require.Error(err)
+
+	var quotes null.Uint32
+	err = json.Unmarshal([]byte(`""`), &quotes)
+	require.Error(err)
+
+	var f null.Uint32
+	// Non-integer numbers should not be coerced to ints.
+	err = json.Unmarshal([]byte("1.2345"), &f)
+	require.Error(err)
+
+	var negative null.Uint32
+	err = json.Unmarshal([]byte("-12345"), &negative)
+	require.Error(err)
+
+	var invalid null.Uint32
+	err = invalid.UnmarshalJSON([]byte(":->"))
+	if _, ok := err.(*json.SyntaxError); !ok {
+		require.FailNowf(
+			"Unexpected Error Type",
+			"expected *json.SyntaxError, not %T", err)
+	}
+}
+
+func TestUint32UnmarshalJSONOverflow(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	uint32Overflow := uint64(math.MaxUint32)
+
+	// Max uint32 should decode successfully
+	var i null.Uint32
+	err = json.Unmarshal([]byte(strconv.FormatUint(uint32Overflow, 10)), &i)
+	require.NoError(err)
+	require.Equal(uint32(math.MaxUint32), i.Uint32)
+
+	// Attempt to overflow
+	uint32Overflow++
+	err = json.Unmarshal([]byte(strconv.FormatUint(uint32Overflow, 10)), &i)
+	// Decoded values should overflow uint32
+	require.Error(err)
+}
+
+func TestUint32MarshalMapValue(t *testing.T) {
+	require := require.New(t)
+	type Wrapper struct{ Uint32 null.Uint32 }
+	var wrapper Wrapper
+	var data map[string]interface{}
+	var err error
+
+	wrapper = Wrapper{null.NewUint32(12345)}
+	data, err = maps.Marshal(wrapper)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{"Uint32": uint32(12345)}, data)
+	data, err = maps.Marshal(&wrapper)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{"Uint32": uint32(12345)}, data)
+
+	wrapper = Wrapper{null.NewUint32(0)}
+	data, err = maps.Marshal(wrapper)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{"Uint32": uint32(0)}, data)
+	data, err = maps.Marshal(&wrapper)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{"Uint32": uint32(0)}, data)
+
+	// Null Uint32s should be encoded as "nil"
+	wrapper = Wrapper{null.Uint32{}}
+	data, err = maps.Marshal(wrapper)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{"Uint32": nil}, data)
+	data, err = maps.Marshal(&wrapper)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{"Uint32": nil}, data)
+}
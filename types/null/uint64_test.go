@@ -0,0 +1,332 @@
+package null_test
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/pyrrho/encoding/maps"
+	"github.com/pyrrho/encoding/types/null"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUint64Ctors(t *testing.T) {
+	require := require.New(t)
+
+	// null.NullUint64() returns a new null null.Uint64.
+	// This is equivalent to null.Uint64{}.
+	nul := null.NullUint64()
+	require.False(nul.Valid)
+
+	empty := null.Uint64{}
+	require.False(empty.Valid)
+
+	// null.NewUint64 constructs a new, valid null.Uint64.
+	i := null.NewUint64(12345)
+	require.True(i.Valid)
+	require.Equal(uint64(12345), i.Uint64)
+
+	z := null.NewUint64(0)
+	require.True(z.Valid)
+	require.Equal(uint64(0), z.Uint64)
+}
+
+func TestUint64ValueOrZero(t *testing.T) {
+	require := require.New(t)
+
+	valid := null.NewUint64(12345)
+	require.Equal(uint64(12345), valid.ValueOrZero())
+
+	nul := null.Uint64{}
+	require.Equal(uint64(0), nul.ValueOrZero())
+}
+
+func TestUint64Set(t *testing.T) {
+	require := require.New(t)
+
+	i := null.Uint64{}
+	require.False(i.Valid)
+
+	i.Set(12345)
+	require.True(i.Valid)
+	require.Equal(uint64(12345), i.Uint64)
+
+	i.Set(0)
+	require.True(i.Valid)
+	require.Equal(uint64(0), i.Uint64)
+}
+
+func TestUint64Null(t *testing.T) {
+	require := require.New(t)
+
+	i := null.NewUint64(12345)
+
+	i.Null()
+	require.False(i.Valid)
+}
+
+func TestUint64SQLScanBounds(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	var max null.Uint64
+	err = max.Scan(uint64(math.MaxUint64))
+	require.NoError(err)
+	require.Equal(uint64(math.MaxUint64), max.Uint64)
+
+	var over null.Uint64
+	err = over.Scan("18446744073709551616")
+	require.Error(err)
+
+	var strOver null.Uint64
+	err = strOver.Scan("18446744073709551616")
+	require.Error(err)
+
+	var negative null.Uint64
+	err = negative.Scan(int64(-1))
+	require.Error(err)
+	require.Contains(err.Error(), "negative value")
+}
+
+func TestUint64IsNil(t *testing.T) {
+	require := require.New(t)
+
+	i := null.NewUint64(12345)
+	require.False(i.IsNil())
+
+	z := null.NewUint64(0)
+	require.False(z.IsNil())
+
+	nul := null.Uint64{}
+	require.True(nul.IsNil())
+}
+
+func TestUint64IsZero(t *testing.T) {
+	require := require.New(t)
+
+	i := null.NewUint64(12345)
+	require.False(i.IsZero())
+
+	z := null.NewUint64(0)
+	require.True(z.IsZero())
+
+	nul := null.Uint64{}
+	require.True(nul.IsZero())
+}
+
+func TestUint64SQLValue(t *testing.T) {
+	require := require.New(t)
+	var val driver.Value
+	var err error
+
+	i := null.NewUint64(12345)
+	val, err = i.Value()
+	require.NoError(err)
+	require.Equal(int64(12345), val)
+
+	z := null.NewUint64(0)
+	val, err = z.Value()
+	require.NoError(err)
+	require.Equal(int64(0), val)
+
+	nul := null.Uint64{}
+	val, err = nul.Value()
+	require.NoError(err)
+	require.Equal(nil, val)
+
+	big := null.NewUint64(uint64(math.MaxInt64))
+	val, err = big.Value()
+	require.NoError(err)
+	require.Equal(int64(math.MaxInt64), val)
+
+	bigger := null.NewUint64(uint64(math.MaxInt64) + 1)
+	_, err = bigger.Value()
+	require.Error(err)
+	require.Contains(err.Error(), "null.Uint64:")
+}
+
+func TestUint64SQLScan(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	var i null.Uint64
+	err = i.Scan(12345)
+	require.NoError(err)
+	require.True(i.Valid)
+	require.Equal(uint64(12345), i.Uint64)
+
+	var str null.Uint64
+	// NB. Scan will coerce strings, but UnmarshalJSON won't.
+	err = str.Scan("12345")
+	require.NoError(err)
+	require.True(str.Valid)
+	require.Equal(uint64(12345), str.Uint64)
+
+	var whole null.Uint64
+	// Whole floats can be scanned without loss of precision.
+	err = whole.Scan(float64(12345))
+	require.NoError(err)
+	require.True(whole.Valid)
+	require.Equal(uint64(12345), whole.Uint64)
+
+	var nul null.Uint64
+	err = nul.Scan(nil)
+	require.NoError(err)
+	require.False(nul.Valid)
+
+	var wrong null.Uint64
+	err = wrong.Scan("hello world")
+	require.Error(err)
+
+	var overflow null.Uint64
+	err = overflow.Scan("18446744073709551616")
+	require.Error(err)
+	require.Contains(err.Error(), "null.Uint64:")
+
+	var negative null.Uint64
+	err = negative.Scan(-12345)
+	require.Error(err)
+
+	var f null.Uint64
+	err = f.Scan(1.2345)
+	require.Error(err)
+
+	var b null.Uint64
+	err = b.Scan(true)
+	require.Error(err)
+}
+
+func TestUint64MarshalJSON(t *testing.T) {
+	require := require.New(t)
+	var data []byte
+	var err error
+
+	i := null.NewUint64(12345)
+	data, err = json.Marshal(i)
+	require.NoError(err)
+	require.EqualValues("12345", data)
+	data, err = json.Marshal(&i)
+	require.NoError(err)
+	require.EqualValues("12345", data)
+
+	z := null.NewUint64(0)
+	data, err = json.Marshal(z)
+	require.NoError(err)
+	require.EqualValues("0", data)
+	data, err = json.Marshal(&z)
+	require.NoError(err)
+	require.EqualValues("0", data)
+
+	nul := null.Uint64{}
+	data, err = json.Marshal(nul)
+	require.NoError(err)
+	require.EqualValues("null", data)
+	data, err = json.Marshal(&nul)
+	require.NoError(err)
+	require.EqualValues("null", data)
+}
+
+func TestUint64UnmarshalJSON(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	// Successful Valid Parses
+
+	var i null.Uint64
+	err = json.Unmarshal([]byte("12345"), &i)
+	require.NoError(err)
+	require.True(i.Valid)
+	require.Equal(uint64(12345), i.Uint64)
+
+	// Successful Null Parses
+
+	var nul null.Uint64
+	err = json.Unmarshal([]byte("null"), &nul)
+	require.NoError(err)
+	require.False(nul.Valid)
+
+	// Unsuccessful Parses
+	// TODO: make types for type mismatches on parsing, and check that the
+	// correct error type is being returned here.
+
+	var intStr null.Uint64
+	// Ints wrapped in quotes aren't ints.
+	err = json.Unmarshal([]byte(`"12345"`), &intStr)
+	require.Error(err)
+
+	var empty null.Uint64
+	err = json.Unmarshal([]byte(""), &empty)
+	require.Error(err)
+
+	var quotes null.Uint64
+	err = json.Unmarshal([]byte(`""`), &quotes)
+	require.Error(err)
+
+	var f null.Uint64
+	// Non-integer numbers should not be coerced to ints.
+	err = json.Unmarshal([]byte("1.2345"), &f)
+	require.Error(err)
+
+	var negative null.Uint64
+	err = json.Unmarshal([]byte("-12345"), &negative)
+	require.Error(err)
+
+	var invalid null.Uint64
+	err = invalid.UnmarshalJSON([]byte(":->"))
+	if _, ok := err.(*json.SyntaxError); !ok {
+		require.FailNowf(
+			"Unexpected Error Type",
+			"expected *json.SyntaxError, not %T", err)
+	}
+}
+
+func TestUint64UnmarshalJSONOverflow(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	// Max uint64 should decode successfully
+	var i null.Uint64
+	err = json.Unmarshal([]byte(strconv.FormatUint(math.MaxUint64, 10)), &i)
+	require.NoError(err)
+	require.Equal(uint64(math.MaxUint64), i.Uint64)
+
+	// Attempt to overflow
+	err = json.Unmarshal([]byte("18446744073709551616"), &i)
+	// Decoded values should overflow uint64
+	require.Error(err)
+}
+
+func TestUint64MarshalMapValue(t *testing.T) {
+	require := require.New(t)
+	type Wrapper struct{ Uint64 null.Uint64 }
+	var wrapper Wrapper
+	var data map[string]interface{}
+	var err error
+
+	wrapper = Wrapper{null.NewUint64(12345)}
+	data, err = maps.Marshal(wrapper)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{"Uint64": uint64(12345)}, data)
+	data, err = maps.Marshal(&wrapper)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{"Uint64": uint64(12345)}, data)
+
+	wrapper = Wrapper{null.NewUint64(0)}
+	data, err = maps.Marshal(wrapper)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{"Uint64": uint64(0)}, data)
+	data, err = maps.Marshal(&wrapper)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{"Uint64": uint64(0)}, data)
+
+	// Null Uint64s should be encoded as "nil"
+	wrapper = Wrapper{null.Uint64{}}
+	data, err = maps.Marshal(wrapper)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{"Uint64": nil}, data)
+	data, err = maps.Marshal(&wrapper)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{"Uint64": nil}, data)
+}
@@ -38,6 +38,16 @@ func NewUint8(i uint8) Uint8 {
 	}
 }
 
+// NewUint8FromPtr constructs and returns a new Uint8 based on the given *uint8
+// p. If p is nil the new Uint8 will be null. Otherwise a new, valid Uint8 will
+// be initialized with the value pointed to by p.
+func NewUint8FromPtr(p *uint8) Uint8 {
+	if p == nil {
+		return NullUint8()
+	}
+	return NewUint8(*p)
+}
+
 // Getters and Setters
 
 // ValueOrZero returns the value of i if it is valid; otherwise it returns the
@@ -49,6 +59,22 @@ func (i Uint8) ValueOrZero() uint8 {
 	return i.Uint8
 }
 
+// Ptr returns a pointer to a copy of the value of i if it is valid; otherwise
+// it returns nil.
+func (i Uint8) Ptr() *uint8 {
+	if !i.Valid {
+		return nil
+	}
+	v := i.Uint8
+	return &v
+}
+
+// Char returns the value of i as a rune, for when i holds a single byte
+// character, if it is valid; otherwise it returns the zero rune.
+func (i Uint8) Char() rune {
+	return rune(i.ValueOrZero())
+}
+
 // Set modifies the value stored in i, and guarantees it is valid.
 func (i *Uint8) Set(v uint8) {
 	i.Uint8 = v
@@ -67,6 +67,52 @@ func TestUint8Null(t *testing.T) {
 	require.False(i.Valid)
 }
 
+func TestUint8FromPtr(t *testing.T) {
+	require := require.New(t)
+
+	v := uint8(123)
+	i := null.NewUint8FromPtr(&v)
+	require.True(i.Valid)
+	require.Equal(uint8(123), i.Uint8)
+
+	// The new Uint8 holds a copy of the pointed-to value.
+	v = 231
+	require.Equal(uint8(123), i.Uint8)
+
+	nul := null.NewUint8FromPtr(nil)
+	require.False(nul.Valid)
+}
+
+func TestUint8Ptr(t *testing.T) {
+	require := require.New(t)
+
+	i := null.NewUint8(123)
+	p := i.Ptr()
+	require.NotNil(p)
+	require.Equal(uint8(123), *p)
+
+	// The returned pointer refers to a copy of the value.
+	*p = 231
+	require.Equal(uint8(123), i.Uint8)
+
+	// Round-trip through Ptr and NewUint8FromPtr.
+	require.Equal(i, null.NewUint8FromPtr(i.Ptr()))
+
+	nul := null.Uint8{}
+	require.Nil(nul.Ptr())
+	require.Equal(nul, null.NewUint8FromPtr(nul.Ptr()))
+}
+
+func TestUint8Char(t *testing.T) {
+	require := require.New(t)
+
+	i := null.NewUint8('a')
+	require.Equal('a', i.Char())
+
+	nul := null.NullUint8()
+	require.Equal(rune(0), nul.Char())
+}
+
 func TestUint8IsNil(t *testing.T) {
 	require := require.New(t)
 
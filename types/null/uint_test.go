@@ -0,0 +1,332 @@
+package null_test
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/pyrrho/encoding/maps"
+	"github.com/pyrrho/encoding/types/null"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUintCtors(t *testing.T) {
+	require := require.New(t)
+
+	// null.NullUint() returns a new null null.Uint.
+	// This is equivalent to null.Uint{}.
+	nul := null.NullUint()
+	require.False(nul.Valid)
+
+	empty := null.Uint{}
+	require.False(empty.Valid)
+
+	// null.NewUint constructs a new, valid null.Uint.
+	i := null.NewUint(12345)
+	require.True(i.Valid)
+	require.Equal(uint(12345), i.Uint)
+
+	z := null.NewUint(0)
+	require.True(z.Valid)
+	require.Equal(uint(0), z.Uint)
+}
+
+func TestUintValueOrZero(t *testing.T) {
+	require := require.New(t)
+
+	valid := null.NewUint(12345)
+	require.Equal(uint(12345), valid.ValueOrZero())
+
+	nul := null.Uint{}
+	require.Equal(uint(0), nul.ValueOrZero())
+}
+
+func TestUintSet(t *testing.T) {
+	require := require.New(t)
+
+	i := null.Uint{}
+	require.False(i.Valid)
+
+	i.Set(12345)
+	require.True(i.Valid)
+	require.Equal(uint(12345), i.Uint)
+
+	i.Set(0)
+	require.True(i.Valid)
+	require.Equal(uint(0), i.Uint)
+}
+
+func TestUintNull(t *testing.T) {
+	require := require.New(t)
+
+	i := null.NewUint(12345)
+
+	i.Null()
+	require.False(i.Valid)
+}
+
+func TestUintSQLScanBounds(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	var max null.Uint
+	err = max.Scan(uint64(math.MaxUint64))
+	require.NoError(err)
+	require.Equal(uint(math.MaxUint64), max.Uint)
+
+	var over null.Uint
+	err = over.Scan("18446744073709551616")
+	require.Error(err)
+
+	var strOver null.Uint
+	err = strOver.Scan("18446744073709551616")
+	require.Error(err)
+
+	var negative null.Uint
+	err = negative.Scan(int64(-1))
+	require.Error(err)
+	require.Contains(err.Error(), "negative value")
+}
+
+func TestUintIsNil(t *testing.T) {
+	require := require.New(t)
+
+	i := null.NewUint(12345)
+	require.False(i.IsNil())
+
+	z := null.NewUint(0)
+	require.False(z.IsNil())
+
+	nul := null.Uint{}
+	require.True(nul.IsNil())
+}
+
+func TestUintIsZero(t *testing.T) {
+	require := require.New(t)
+
+	i := null.NewUint(12345)
+	require.False(i.IsZero())
+
+	z := null.NewUint(0)
+	require.True(z.IsZero())
+
+	nul := null.Uint{}
+	require.True(nul.IsZero())
+}
+
+func TestUintSQLValue(t *testing.T) {
+	require := require.New(t)
+	var val driver.Value
+	var err error
+
+	i := null.NewUint(12345)
+	val, err = i.Value()
+	require.NoError(err)
+	require.Equal(int64(12345), val)
+
+	z := null.NewUint(0)
+	val, err = z.Value()
+	require.NoError(err)
+	require.Equal(int64(0), val)
+
+	nul := null.Uint{}
+	val, err = nul.Value()
+	require.NoError(err)
+	require.Equal(nil, val)
+
+	big := null.NewUint(uint(math.MaxInt64))
+	val, err = big.Value()
+	require.NoError(err)
+	require.Equal(int64(math.MaxInt64), val)
+
+	bigger := null.NewUint(uint(math.MaxInt64) + 1)
+	_, err = bigger.Value()
+	require.Error(err)
+	require.Contains(err.Error(), "null.Uint:")
+}
+
+func TestUintSQLScan(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	var i null.Uint
+	err = i.Scan(12345)
+	require.NoError(err)
+	require.True(i.Valid)
+	require.Equal(uint(12345), i.Uint)
+
+	var str null.Uint
+	// NB. Scan will coerce strings, but UnmarshalJSON won't.
+	err = str.Scan("12345")
+	require.NoError(err)
+	require.True(str.Valid)
+	require.Equal(uint(12345), str.Uint)
+
+	var whole null.Uint
+	// Whole floats can be scanned without loss of precision.
+	err = whole.Scan(float64(12345))
+	require.NoError(err)
+	require.True(whole.Valid)
+	require.Equal(uint(12345), whole.Uint)
+
+	var nul null.Uint
+	err = nul.Scan(nil)
+	require.NoError(err)
+	require.False(nul.Valid)
+
+	var wrong null.Uint
+	err = wrong.Scan("hello world")
+	require.Error(err)
+
+	var overflow null.Uint
+	err = overflow.Scan("18446744073709551616")
+	require.Error(err)
+	require.Contains(err.Error(), "null.Uint:")
+
+	var negative null.Uint
+	err = negative.Scan(-12345)
+	require.Error(err)
+
+	var f null.Uint
+	err = f.Scan(1.2345)
+	require.Error(err)
+
+	var b null.Uint
+	err = b.Scan(true)
+	require.Error(err)
+}
+
+func TestUintMarshalJSON(t *testing.T) {
+	require := require.New(t)
+	var data []byte
+	var err error
+
+	i := null.NewUint(12345)
+	data, err = json.Marshal(i)
+	require.NoError(err)
+	require.EqualValues("12345", data)
+	data, err = json.Marshal(&i)
+	require.NoError(err)
+	require.EqualValues("12345", data)
+
+	z := null.NewUint(0)
+	data, err = json.Marshal(z)
+	require.NoError(err)
+	require.EqualValues("0", data)
+	data, err = json.Marshal(&z)
+	require.NoError(err)
+	require.EqualValues("0", data)
+
+	nul := null.Uint{}
+	data, err = json.Marshal(nul)
+	require.NoError(err)
+	require.EqualValues("null", data)
+	data, err = json.Marshal(&nul)
+	require.NoError(err)
+	require.EqualValues("null", data)
+}
+
+func TestUintUnmarshalJSON(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	// Successful Valid Parses
+
+	var i null.Uint
+	err = json.Unmarshal([]byte("12345"), &i)
+	require.NoError(err)
+	require.True(i.Valid)
+	require.Equal(uint(12345), i.Uint)
+
+	// Successful Null Parses
+
+	var nul null.Uint
+	err = json.Unmarshal([]byte("null"), &nul)
+	require.NoError(err)
+	require.False(nul.Valid)
+
+	// Unsuccessful Parses
+	// TODO: make types for type mismatches on parsing, and check that the
+	// correct error type is being returned here.
+
+	var intStr null.Uint
+	// Ints wrapped in quotes aren't ints.
+	err = json.Unmarshal([]byte(`"12345"`), &intStr)
+	require.Error(err)
+
+	var empty null.Uint
+	err = json.Unmarshal([]byte(""), &empty)
+	require.Error(err)
+
+	var quotes null.Uint
+	err = json.Unmarshal([]byte(`""`), &quotes)
+	require.Error(err)
+
+	var f null.Uint
+	// Non-integer numbers should not be coerced to ints.
+	err = json.Unmarshal([]byte("1.2345"), &f)
+	require.Error(err)
+
+	var negative null.Uint
+	err = json.Unmarshal([]byte("-12345"), &negative)
+	require.Error(err)
+
+	var invalid null.Uint
+	err = invalid.UnmarshalJSON([]byte(":->"))
+	if _, ok := err.(*json.SyntaxError); !ok {
+		require.FailNowf(
+			"Unexpected Error Type",
+			"expected *json.SyntaxError, not %T", err)
+	}
+}
+
+func TestUintUnmarshalJSONOverflow(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	// Max uint should decode successfully
+	var i null.Uint
+	err = json.Unmarshal([]byte(strconv.FormatUint(math.MaxUint64, 10)), &i)
+	require.NoError(err)
+	require.Equal(uint(math.MaxUint64), i.Uint)
+
+	// Attempt to overflow
+	err = json.Unmarshal([]byte("18446744073709551616"), &i)
+	// Decoded values should overflow uint
+	require.Error(err)
+}
+
+func TestUintMarshalMapValue(t *testing.T) {
+	require := require.New(t)
+	type Wrapper struct{ Uint null.Uint }
+	var wrapper Wrapper
+	var data map[string]interface{}
+	var err error
+
+	wrapper = Wrapper{null.NewUint(12345)}
+	data, err = maps.Marshal(wrapper)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{"Uint": uint(12345)}, data)
+	data, err = maps.Marshal(&wrapper)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{"Uint": uint(12345)}, data)
+
+	wrapper = Wrapper{null.NewUint(0)}
+	data, err = maps.Marshal(wrapper)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{"Uint": uint(0)}, data)
+	data, err = maps.Marshal(&wrapper)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{"Uint": uint(0)}, data)
+
+	// Null Uints should be encoded as "nil"
+	wrapper = Wrapper{null.Uint{}}
+	data, err = maps.Marshal(wrapper)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{"Uint": nil}, data)
+	data, err = maps.Marshal(&wrapper)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{"Uint": nil}, data)
+}
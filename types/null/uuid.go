@@ -0,0 +1,222 @@
+package null
+
+import (
+	"database/sql/driver"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+)
+
+// UUID is a nullable, 16 byte universally unique identifier. It implements all
+// of the pyrrho/encoding/types interfaces detailed in the package comments.
+// JSON and map interactions use the canonical, hyphenated string form, eg.
+// "123e4567-e89b-12d3-a456-426614174000". Value will likewise return the
+// canonical string form, while Scan will accept either that form or 16 raw
+// bytes.
+//
+// If the UUID is valid and contains all zeros (the nil UUID), it will be
+// considered non-null, and of zero value.
+type UUID struct {
+	UUID  [16]byte
+	Valid bool
+}
+
+// Constructors
+
+// NullUUID constructs and returns a new null UUID.
+func NullUUID() UUID {
+	return UUID{
+		UUID:  [16]byte{},
+		Valid: false,
+	}
+}
+
+// NewUUID constructs and returns a new, valid UUID initialized with the value
+// of the given u.
+func NewUUID(u [16]byte) UUID {
+	return UUID{
+		UUID:  u,
+		Valid: true,
+	}
+}
+
+// NewUUIDStr parses the given canonical, hyphenated string, s, and returns a
+// new, valid UUID initialized with the result. Hex digits may be of either
+// case. If s is the empty string, the new UUID will be null.
+func NewUUIDStr(s string) (UUID, error) {
+	if len(s) == 0 {
+		return NullUUID(), nil
+	}
+	u, err := parseUUID(s)
+	if err != nil {
+		return UUID{}, err
+	}
+	return UUID{
+		UUID:  u,
+		Valid: true,
+	}, nil
+}
+
+// parseUUID parses the 8-4-4-4-12 hyphenated hex form of a UUID.
+func parseUUID(s string) ([16]byte, error) {
+	var u [16]byte
+	if len(s) != 36 || s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+		return u, fmt.Errorf("null.UUID: invalid UUID string %q", s)
+	}
+	digits := s[0:8] + s[9:13] + s[14:18] + s[19:23] + s[24:36]
+	if _, err := hex.Decode(u[:], []byte(digits)); err != nil {
+		return [16]byte{}, fmt.Errorf("null.UUID: invalid UUID string %q", s)
+	}
+	return u, nil
+}
+
+// Getters and Setters
+
+// ValueOrZero returns the value of u if it is valid; otherwise it returns the
+// zero value for a [16]byte (the nil UUID).
+func (u UUID) ValueOrZero() [16]byte {
+	if !u.Valid {
+		return [16]byte{}
+	}
+	return u.UUID
+}
+
+// Set modifies the value stored in u, and guarantees it is valid.
+func (u *UUID) Set(v [16]byte) {
+	u.UUID = v
+	u.Valid = true
+}
+
+// Null marks u as null with no meaningful value.
+func (u *UUID) Null() {
+	u.UUID = [16]byte{}
+	u.Valid = false
+}
+
+// String returns the canonical, lower case, hyphenated string form of u if it
+// is valid, or the null token otherwise; see SetNullToken.
+func (u UUID) String() string {
+	if !u.Valid {
+		return nullToken
+	}
+	var buf [36]byte
+	hex.Encode(buf[0:8], u.UUID[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], u.UUID[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], u.UUID[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], u.UUID[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:36], u.UUID[10:16])
+	return string(buf[:])
+}
+
+// Interfaces
+
+// IsNil implements the pyrrho/encoding IsNiler interface. It will return true
+// if u is null.
+func (u UUID) IsNil() bool {
+	return !u.Valid
+}
+
+// IsZero implements the pyrrho/encoding IsZeroer interface. It will return true
+// if u is null or if its value is the nil UUID.
+func (u UUID) IsZero() bool {
+	return !u.Valid || u.UUID == [16]byte{}
+}
+
+// Value implements the database/sql/driver Valuer interface. It will return the
+// canonical string form of u if valid, or nil otherwise.
+func (u UUID) Value() (driver.Value, error) {
+	if !u.Valid {
+		return nil, nil
+	}
+	return u.String(), nil
+}
+
+// Scan implements the database/sql Scanner interface. It will receive a value
+// from an SQL database and assign it to u, so long as the provided data is a
+// []byte of 16 raw bytes, a string or []byte holding the canonical string form,
+// or nil. All other types will result in an error.
+func (u *UUID) Scan(src interface{}) error {
+	if u == nil {
+		return fmt.Errorf("null.UUID: Scan called on nil pointer")
+	}
+	var s string
+	switch val := src.(type) {
+	case []byte:
+		if len(val) == 16 {
+			copy(u.UUID[:], val)
+			u.Valid = true
+			return nil
+		}
+		s = string(val)
+	case string:
+		s = val
+	case nil:
+		u.UUID = [16]byte{}
+		u.Valid = false
+		return nil
+	default:
+		return fmt.Errorf("null.UUID: cannot scan type %T (%v)", val, src)
+	}
+	tmp, err := parseUUID(s)
+	if err != nil {
+		return err
+	}
+	u.UUID = tmp
+	u.Valid = true
+	return nil
+}
+
+// MarshalJSON implements the encoding/json Marshaler interface. It will encode
+// u into its quoted canonical string form if valid, or 'null' otherwise.
+func (u UUID) MarshalJSON() ([]byte, error) {
+	if !u.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(u.String())
+}
+
+// UnmarshalJSON implements the encoding/json Unmarshaler interface. It will
+// decode a given []byte into u so long as the provided []byte is a JSON string
+// holding the canonical string form of a UUID. Empty strings and the 'null'
+// keyword will both decode into a null UUID.
+//
+// If the decode fails, the value of u will be unchanged.
+func (u *UUID) UnmarshalJSON(data []byte) error {
+	if u == nil {
+		return fmt.Errorf("null.UUID: UnmarshalJSON called on nil pointer")
+	}
+	var j interface{}
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+	switch val := j.(type) {
+	case string:
+		tmp, err := NewUUIDStr(val)
+		if err != nil {
+			return err
+		}
+		*u = tmp
+		return nil
+	case nil:
+		u.UUID = [16]byte{}
+		u.Valid = false
+		return nil
+	default:
+		return fmt.Errorf("null.UUID: cannot unmarshal JSON of type %T (%v)",
+			val, data)
+	}
+}
+
+// MarshalMapValue implements the pyrrho/encoding/maps Marshaler interface. It
+// will encode u into its canonical string form for use in a
+// map[string]interface{} if valid, or return nil otherwise.
+func (u UUID) MarshalMapValue() (interface{}, error) {
+	if u.Valid {
+		return u.String(), nil
+	}
+	return nil, nil
+}
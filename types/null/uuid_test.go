@@ -0,0 +1,264 @@
+package null_test
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"testing"
+
+	"github.com/pyrrho/encoding/maps"
+	"github.com/pyrrho/encoding/types/null"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	uuidString = "123e4567-e89b-12d3-a456-426614174000"
+	uuidJSON   = []byte(`"123e4567-e89b-12d3-a456-426614174000"`)
+	uuidValue  = [16]byte{
+		0x12, 0x3e, 0x45, 0x67, 0xe8, 0x9b, 0x12, 0xd3,
+		0xa4, 0x56, 0x42, 0x66, 0x14, 0x17, 0x40, 0x00,
+	}
+)
+
+func TestUUIDCtors(t *testing.T) {
+	require := require.New(t)
+
+	// null.NullUUID() returns a new null null.UUID.
+	// This is equivalent to null.UUID{}.
+	nul := null.NullUUID()
+	require.False(nul.Valid)
+
+	empty := null.UUID{}
+	require.False(empty.Valid)
+
+	// null.NewUUID constructs a new, valid null.UUID.
+	u := null.NewUUID(uuidValue)
+	require.True(u.Valid)
+	require.Equal(uuidValue, u.UUID)
+
+	// null.NewUUIDStr parses the hyphenated form, in either case ...
+	s, err := null.NewUUIDStr(uuidString)
+	require.NoError(err)
+	require.True(s.Valid)
+	require.Equal(uuidValue, s.UUID)
+
+	s, err = null.NewUUIDStr("123E4567-E89B-12D3-A456-426614174000")
+	require.NoError(err)
+	require.Equal(uuidValue, s.UUID)
+
+	// ... treating empty strings as null ...
+	s, err = null.NewUUIDStr("")
+	require.NoError(err)
+	require.False(s.Valid)
+
+	// ... and rejecting anything malformed.
+	for _, bad := range []string{
+		"123e4567e89b12d3a456426614174000",
+		"{123e4567-e89b-12d3-a456-426614174000}",
+		"123e4567-e89b-12d3-a456-42661417400",
+		"123e4567-e89b-12d3-a456-4266141740000",
+		"123e4567-e89b-12d3-a456_426614174000",
+		"123e4567-e89b-12d3-a456-42661417400g",
+		"not-a-uuid",
+	} {
+		_, err = null.NewUUIDStr(bad)
+		require.Error(err, bad)
+		require.Contains(err.Error(), "null.UUID:")
+	}
+}
+
+func TestUUIDValueOrZero(t *testing.T) {
+	require := require.New(t)
+
+	u := null.NewUUID(uuidValue)
+	require.Equal(uuidValue, u.ValueOrZero())
+
+	nul := null.UUID{}
+	require.Equal([16]byte{}, nul.ValueOrZero())
+}
+
+func TestUUIDSet(t *testing.T) {
+	require := require.New(t)
+
+	u := null.UUID{}
+	require.False(u.Valid)
+
+	u.Set(uuidValue)
+	require.True(u.Valid)
+	require.Equal(uuidValue, u.UUID)
+}
+
+func TestUUIDNull(t *testing.T) {
+	require := require.New(t)
+
+	u := null.NewUUID(uuidValue)
+
+	u.Null()
+	require.False(u.Valid)
+	require.Equal([16]byte{}, u.UUID)
+}
+
+func TestUUIDString(t *testing.T) {
+	require := require.New(t)
+
+	require.Equal(uuidString, null.NewUUID(uuidValue).String())
+	require.Equal("00000000-0000-0000-0000-000000000000", null.NewUUID([16]byte{}).String())
+	require.Equal("", null.UUID{}.String())
+}
+
+func TestUUIDIsNil(t *testing.T) {
+	require := require.New(t)
+
+	u := null.NewUUID(uuidValue)
+	require.False(u.IsNil())
+
+	z := null.NewUUID([16]byte{})
+	require.False(z.IsNil())
+
+	nul := null.UUID{}
+	require.True(nul.IsNil())
+}
+
+func TestUUIDIsZero(t *testing.T) {
+	require := require.New(t)
+
+	u := null.NewUUID(uuidValue)
+	require.False(u.IsZero())
+
+	z := null.NewUUID([16]byte{})
+	require.True(z.IsZero())
+
+	nul := null.UUID{}
+	require.True(nul.IsZero())
+}
+
+func TestUUIDSQLValue(t *testing.T) {
+	require := require.New(t)
+	var val driver.Value
+	var err error
+
+	u := null.NewUUID(uuidValue)
+	val, err = u.Value()
+	require.NoError(err)
+	require.Equal(uuidString, val)
+
+	nul := null.UUID{}
+	val, err = nul.Value()
+	require.NoError(err)
+	require.Equal(nil, val)
+}
+
+func TestUUIDSQLScan(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	var raw null.UUID
+	err = raw.Scan(uuidValue[:])
+	require.NoError(err)
+	require.True(raw.Valid)
+	require.Equal(uuidValue, raw.UUID)
+
+	var text null.UUID
+	err = text.Scan([]byte(uuidString))
+	require.NoError(err)
+	require.True(text.Valid)
+	require.Equal(uuidValue, text.UUID)
+
+	var str null.UUID
+	err = str.Scan(uuidString)
+	require.NoError(err)
+	require.True(str.Valid)
+	require.Equal(uuidValue, str.UUID)
+
+	nul := null.NewUUID(uuidValue)
+	err = nul.Scan(nil)
+	require.NoError(err)
+	require.False(nul.Valid)
+
+	var short null.UUID
+	err = short.Scan(uuidValue[:15])
+	require.Error(err)
+	require.Contains(err.Error(), "null.UUID:")
+
+	var malformed null.UUID
+	err = malformed.Scan("not-a-uuid")
+	require.Error(err)
+	require.Contains(err.Error(), "null.UUID:")
+	require.False(malformed.Valid)
+
+	var wrong null.UUID
+	err = wrong.Scan(int64(12345))
+	require.Error(err)
+}
+
+func TestUUIDMarshalJSON(t *testing.T) {
+	require := require.New(t)
+	var data []byte
+	var err error
+
+	u := null.NewUUID(uuidValue)
+	data, err = json.Marshal(u)
+	require.NoError(err)
+	require.EqualValues(uuidJSON, data)
+	data, err = json.Marshal(&u)
+	require.NoError(err)
+	require.EqualValues(uuidJSON, data)
+
+	nul := null.UUID{}
+	data, err = json.Marshal(nul)
+	require.NoError(err)
+	require.EqualValues("null", data)
+	data, err = json.Marshal(&nul)
+	require.NoError(err)
+	require.EqualValues("null", data)
+}
+
+func TestUUIDUnmarshalJSON(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	var u null.UUID
+	err = json.Unmarshal(uuidJSON, &u)
+	require.NoError(err)
+	require.True(u.Valid)
+	require.Equal(uuidValue, u.UUID)
+
+	var nul null.UUID
+	err = json.Unmarshal([]byte("null"), &nul)
+	require.NoError(err)
+	require.False(nul.Valid)
+
+	var empty null.UUID
+	err = json.Unmarshal([]byte(`""`), &empty)
+	require.NoError(err)
+	require.False(empty.Valid)
+
+	// Failed decodes leave the value unchanged.
+	malformed := null.NewUUID(uuidValue)
+	err = json.Unmarshal([]byte(`"not-a-uuid"`), &malformed)
+	require.Error(err)
+	require.Contains(err.Error(), "null.UUID:")
+	require.Equal(null.NewUUID(uuidValue), malformed)
+
+	var number null.UUID
+	err = json.Unmarshal([]byte("12345"), &number)
+	require.Error(err)
+}
+
+func TestUUIDMarshalMapValue(t *testing.T) {
+	require := require.New(t)
+	type Wrapper struct{ UUID null.UUID }
+	var wrapper Wrapper
+	var data map[string]interface{}
+	var err error
+
+	wrapper = Wrapper{null.NewUUID(uuidValue)}
+	data, err = maps.Marshal(wrapper)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{"UUID": uuidString}, data)
+
+	// Null UUIDs should be encoded as "nil"
+	wrapper = Wrapper{null.UUID{}}
+	data, err = maps.Marshal(wrapper)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{"UUID": nil}, data)
+}
@@ -0,0 +1,197 @@
+package null
+
+import (
+	"fmt"
+	"time"
+)
+
+// This file implements the MarshalYAML and UnmarshalYAML contract shared by
+// gopkg.in/yaml.v2 and gopkg.in/yaml.v3 for the core null types. Neither
+// interface references the yaml packages, so there is no dependency on either.
+// Null values are marshaled as YAML null, mirroring the JSON behavior of each
+// type.
+//
+// NB. The yaml packages do not call UnmarshalYAML for YAML null, and leave the
+// destination untouched instead. A YAML null will therefore only result in a
+// null value when unmarshaled into a zero-valued -- and so, already null --
+// destination.
+
+// MarshalYAML implements the gopkg.in/yaml Marshaler interface. It will encode
+// b as a YAML bool if valid, or null otherwise.
+func (b Bool) MarshalYAML() (interface{}, error) {
+	if !b.Valid {
+		return nil, nil
+	}
+	return b.Bool, nil
+}
+
+// UnmarshalYAML implements the gopkg.in/yaml Unmarshaler interface. It will
+// decode a YAML bool into b, or YAML null into a null Bool.
+//
+// If the decode fails, the value of b will be unchanged.
+func (b *Bool) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	if b == nil {
+		return fmt.Errorf("null.Bool: UnmarshalYAML called on nil pointer")
+	}
+	var v *bool
+	if err := unmarshal(&v); err != nil {
+		return err
+	}
+	if v == nil {
+		b.Null()
+		return nil
+	}
+	b.Set(*v)
+	return nil
+}
+
+// MarshalYAML implements the gopkg.in/yaml Marshaler interface. It will encode
+// i as a YAML int if valid, or null otherwise.
+func (i Int64) MarshalYAML() (interface{}, error) {
+	if !i.Valid {
+		return nil, nil
+	}
+	return i.Int64, nil
+}
+
+// UnmarshalYAML implements the gopkg.in/yaml Unmarshaler interface. It will
+// decode a YAML int into i, or YAML null into a null Int64.
+//
+// If the decode fails, the value of i will be unchanged.
+func (i *Int64) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	if i == nil {
+		return fmt.Errorf("null.Int64: UnmarshalYAML called on nil pointer")
+	}
+	var v *int64
+	if err := unmarshal(&v); err != nil {
+		return err
+	}
+	if v == nil {
+		i.Null()
+		return nil
+	}
+	i.Set(*v)
+	return nil
+}
+
+// MarshalYAML implements the gopkg.in/yaml Marshaler interface. It will encode
+// f as a YAML float if valid, or null otherwise.
+func (f Float64) MarshalYAML() (interface{}, error) {
+	if !f.Valid {
+		return nil, nil
+	}
+	return f.Float64, nil
+}
+
+// UnmarshalYAML implements the gopkg.in/yaml Unmarshaler interface. It will
+// decode a YAML float or int into f, or YAML null into a null Float64.
+//
+// If the decode fails, the value of f will be unchanged.
+func (f *Float64) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	if f == nil {
+		return fmt.Errorf("null.Float64: UnmarshalYAML called on nil pointer")
+	}
+	var v *float64
+	if err := unmarshal(&v); err != nil {
+		return err
+	}
+	if v == nil {
+		f.Null()
+		return nil
+	}
+	f.Set(*v)
+	return nil
+}
+
+// MarshalYAML implements the gopkg.in/yaml Marshaler interface. It will encode
+// s as a YAML string if valid, or null otherwise.
+func (s String) MarshalYAML() (interface{}, error) {
+	if !s.Valid {
+		return nil, nil
+	}
+	return s.String, nil
+}
+
+// UnmarshalYAML implements the gopkg.in/yaml Unmarshaler interface. It will
+// decode a YAML string into s, or YAML null into a null String. An empty
+// string will result in a valid-but-empty String.
+//
+// If the decode fails, the value of s will be unchanged.
+func (s *String) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	if s == nil {
+		return fmt.Errorf("null.String: UnmarshalYAML called on nil pointer")
+	}
+	var v *string
+	if err := unmarshal(&v); err != nil {
+		return err
+	}
+	if v == nil {
+		s.Null()
+		return nil
+	}
+	s.Set(*v)
+	return nil
+}
+
+// MarshalYAML implements the gopkg.in/yaml Marshaler interface. It will encode
+// t as a YAML timestamp if valid, or null otherwise.
+func (t Time) MarshalYAML() (interface{}, error) {
+	if !t.Valid {
+		return nil, nil
+	}
+	return t.Time, nil
+}
+
+// UnmarshalYAML implements the gopkg.in/yaml Unmarshaler interface. It will
+// decode a YAML timestamp into t, or YAML null into a null Time.
+//
+// If the decode fails, the value of t will be unchanged.
+func (t *Time) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	if t == nil {
+		return fmt.Errorf("null.Time: UnmarshalYAML called on nil pointer")
+	}
+	var v *time.Time
+	if err := unmarshal(&v); err != nil {
+		return err
+	}
+	if v == nil {
+		t.Null()
+		return nil
+	}
+	t.Set(*v)
+	return nil
+}
+
+// MarshalYAML implements the gopkg.in/yaml Marshaler interface. It will encode
+// b as a base64 -- or hex; see Mode -- YAML string if valid, or null otherwise.
+func (b ByteSlice) MarshalYAML() (interface{}, error) {
+	if !b.Valid {
+		return nil, nil
+	}
+	return string(encodeByteSlice(b.ByteSlice, b.mode())), nil
+}
+
+// UnmarshalYAML implements the gopkg.in/yaml Unmarshaler interface. It will
+// decode a base64 -- or hex; see Mode -- YAML string into b, or YAML null into
+// a null ByteSlice. An empty string will result in a valid-but-empty ByteSlice.
+//
+// If the decode fails, the value of b will be unchanged.
+func (b *ByteSlice) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	if b == nil {
+		return fmt.Errorf("null.ByteSlice: UnmarshalYAML called on nil pointer")
+	}
+	var v *string
+	if err := unmarshal(&v); err != nil {
+		return err
+	}
+	if v == nil {
+		b.Null()
+		return nil
+	}
+	bs, err := decodeByteSlice([]byte(*v), b.mode())
+	if err != nil {
+		return err
+	}
+	b.Set(bs)
+	return nil
+}
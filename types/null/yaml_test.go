@@ -0,0 +1,107 @@
+package null_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pyrrho/encoding/types/null"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+type YAMLDoc struct {
+	Bool      null.Bool      `yaml:"bool"`
+	Int64     null.Int64     `yaml:"int64"`
+	Float64   null.Float64   `yaml:"float64"`
+	String    null.String    `yaml:"string"`
+	Time      null.Time      `yaml:"time"`
+	ByteSlice null.ByteSlice `yaml:"byte_slice"`
+}
+
+func TestYAMLRoundTrip(t *testing.T) {
+	require := require.New(t)
+
+	valid := YAMLDoc{
+		Bool:      null.NewBool(false),
+		Int64:     null.NewInt64(-42),
+		Float64:   null.NewFloat64(1.5),
+		String:    null.NewString(""),
+		Time:      null.NewTime(time.Date(2017, 4, 1, 12, 30, 0, 0, time.UTC)),
+		ByteSlice: null.NewByteSlice([]byte("hello")),
+	}
+	data, err := yaml.Marshal(valid)
+	require.NoError(err)
+	require.Equal(`bool: false
+int64: -42
+float64: 1.5
+string: ""
+time: 2017-04-01T12:30:00Z
+byte_slice: aGVsbG8=
+`, string(data))
+
+	var out YAMLDoc
+	err = yaml.Unmarshal(data, &out)
+	require.NoError(err)
+	require.Equal(valid.Bool, out.Bool)
+	require.Equal(valid.Int64, out.Int64)
+	require.Equal(valid.Float64, out.Float64)
+	require.Equal(valid.String, out.String)
+	require.True(out.Time.Valid)
+	require.True(valid.Time.Time.Equal(out.Time.Time))
+	require.Equal(valid.ByteSlice, out.ByteSlice)
+
+	var nulls YAMLDoc
+	data, err = yaml.Marshal(nulls)
+	require.NoError(err)
+	require.Equal(`bool: null
+int64: null
+float64: null
+string: null
+time: null
+byte_slice: null
+`, string(data))
+
+	out = YAMLDoc{}
+	err = yaml.Unmarshal(data, &out)
+	require.NoError(err)
+	require.False(out.Bool.Valid)
+	require.False(out.Int64.Valid)
+	require.False(out.Float64.Valid)
+	require.False(out.String.Valid)
+	require.False(out.Time.Valid)
+	require.False(out.ByteSlice.Valid)
+
+	// yaml.v3 doesn't call UnmarshalYAML for null, so it can't null out
+	// already valid values.
+	out = valid
+	err = yaml.Unmarshal(data, &out)
+	require.NoError(err)
+	require.Equal(valid.Int64, out.Int64)
+}
+
+func TestYAMLUnmarshalErrors(t *testing.T) {
+	require := require.New(t)
+
+	var out YAMLDoc
+	require.Error(yaml.Unmarshal([]byte(`int64: foo`), &out))
+	require.Error(yaml.Unmarshal([]byte(`bool: [1]`), &out))
+	require.Error(yaml.Unmarshal([]byte(`byte_slice: "!!"`), &out))
+	require.False(out.Int64.Valid)
+	require.False(out.ByteSlice.Valid)
+}
+
+func TestYAMLByteSliceMode(t *testing.T) {
+	require := require.New(t)
+
+	data, err := yaml.Marshal(null.NewByteSliceHex([]byte("hello")))
+	require.NoError(err)
+	require.Equal("68656c6c6f\n", string(data))
+
+	out := null.NewByteSliceMode(nil, null.ByteSliceHex)
+	require.NoError(yaml.Unmarshal(data, &out))
+	require.Equal(null.NewByteSliceHex([]byte("hello")), out)
+
+	data, err = yaml.Marshal(null.NewByteSliceMode([]byte("hello"), null.ByteSlicePostgresHex))
+	require.NoError(err)
+	require.Equal("\\x68656c6c6f\n", string(data))
+}
@@ -1,10 +1,15 @@
 package types
 
 import (
+	"bytes"
 	"database/sql/driver"
 	"encoding/json"
 	"fmt"
+	"io"
 	"reflect"
+	"sort"
+	"strconv"
+	"strings"
 )
 
 // RawJSON is an alternative to the json.RawMessage type. RawJSON implements all
@@ -29,6 +34,20 @@ func NewJSONStr(s string) RawJSON {
 	return RawJSON(s)
 }
 
+// ReadJSON will read all of r, and return a new RawJSON object initialized with
+// its contents. If the read fails, or if the contents are not valid JSON, an
+// error will be returned.
+func ReadJSON(r io.Reader) (RawJSON, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if !json.Valid(b) {
+		return nil, fmt.Errorf("types.RawJSON: cannot read invalid JSON")
+	}
+	return RawJSON(b), nil
+}
+
 // Set will copy the contents of v into this RawJSON.
 func (j *RawJSON) Set(v []byte) {
 	// NB. This will re-use the array allocated for *j, if possible, filling it
@@ -43,6 +62,54 @@ func (j *RawJSON) SetStr(v string) {
 	*j = append((*j)[0:0], v...)
 }
 
+// SetChecked will copy the contents of v into j, as Set does, if v is valid
+// JSON. Otherwise an error will be returned, and j will be unchanged.
+func (j *RawJSON) SetChecked(v []byte) error {
+	if !json.Valid(v) {
+		return fmt.Errorf("types.RawJSON: cannot set invalid JSON")
+	}
+	j.Set(v)
+	return nil
+}
+
+// Valid returns true if j is valid JSON. Scan and UnmarshalJSON do not validate
+// their input, so Valid may be used to check j before it reaches Value or
+// MarshalJSON.
+func (j RawJSON) Valid() bool {
+	return json.Valid(j)
+}
+
+// Compact strips insignificant whitespace from j, in place. If j is not valid
+// JSON an error will be returned, and j will be unchanged.
+//
+// Unlike Set, Compact will not write to the array backing j; the compacted JSON
+// is written to a new array, so slices that share memory with j are unaffected.
+func (j *RawJSON) Compact() error {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, *j); err != nil {
+		return err
+	}
+	*j = buf.Bytes()
+	return nil
+}
+
+// Indent reformats j, in place, such that each element of an object or array
+// begins on a new line beginning with prefix, followed by one or more copies of
+// indent according to the nesting depth. If j is not valid JSON an error will
+// be returned, and j will be unchanged. Note that a prefix that is not
+// whitespace will leave j holding invalid JSON; such output is best kept to
+// logs.
+//
+// As with Compact, the reformatted JSON is written to a new array.
+func (j *RawJSON) Indent(prefix, indent string) error {
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, *j, prefix, indent); err != nil {
+		return err
+	}
+	*j = buf.Bytes()
+	return nil
+}
+
 // IsNil implements the pyrrho/encoding IsNiler interface. It will return true
 // if j has a length of zero.
 func (j RawJSON) IsNil() bool {
@@ -52,14 +119,46 @@ func (j RawJSON) IsNil() bool {
 // IsZero implements the pyrrho/encoding IsZeroer interface. It will return true
 // if j has a length of zero, or if the contained JSON is a zero value. If the
 // parsing the contined JSON results in an error, IsZero will return false.
+//
+// IsZero is called for every omitZero field when marshaling to a map, so the
+// zero-ness of objects, arrays, strings, booleans, and null is decided by
+// inspecting the ends of the document, rather than parsing it in full. Only
+// numbers are passed through ValueIsZero.
 func (j RawJSON) IsZero() bool {
 	if len(j) == 0 {
 		return true
 	}
+	t := bytes.Trim(j, jsonWhitespace)
+	if len(t) == 0 {
+		return false
+	}
+	switch t[0] {
+	case '{', '[':
+		// A non-empty container is either invalid or non-zero; either way,
+		// not zero.
+		end := byte('}')
+		if t[0] == '[' {
+			end = ']'
+		}
+		return len(t) >= 2 && t[len(t)-1] == end &&
+			len(bytes.Trim(t[1:len(t)-1], jsonWhitespace)) == 0
+	case '"':
+		return len(t) == 2 && t[1] == '"'
+	case 'n':
+		return string(t) == "null"
+	case 'f':
+		return string(t) == "false"
+	case 't':
+		return false
+	}
 	b, _ := j.ValueIsZero()
 	return b
 }
 
+// jsonWhitespace is the set of insignificant whitespace characters that may
+// surround any JSON token.
+const jsonWhitespace = " \t\r\n"
+
 // ValueIsZero will return true if the contained JSON is a zero value. If the
 // contained JSON is invalid, ValueIsZero will return false and the resulting
 // JSON parsing error.
@@ -180,3 +279,651 @@ func (j RawJSON) MarshalMapValue() (interface{}, error) {
 	}
 	return iface, nil
 }
+
+// MatchesShape checks that j is a JSON object holding each of the keys in
+// shape, and that the value of each of those keys is of the JSON type named by
+// shape; one of "string", "number", "boolean", "object", "array", or "null".
+// Keys in j that are not present in shape are ignored. The returned error will
+// describe the first missing or mismatched key, in sorted key order.
+//
+// This is a cheap, top-level-only alternative to full JSON Schema validation.
+func (j RawJSON) MatchesShape(shape map[string]string) error {
+	var obj map[string]interface{}
+	if err := json.Unmarshal(j, &obj); err != nil {
+		return err
+	}
+	if obj == nil {
+		return fmt.Errorf("types.RawJSON: expected a JSON object, got null")
+	}
+	keys := make([]string, 0, len(shape))
+	for k := range shape {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		want := shape[k]
+		switch want {
+		case "string", "number", "boolean", "object", "array", "null":
+		default:
+			return fmt.Errorf("types.RawJSON: unknown JSON type %q for key %q", want, k)
+		}
+		v, ok := obj[k]
+		if !ok {
+			return fmt.Errorf("types.RawJSON: missing key %q", k)
+		}
+		if got := jsonTypeName(v); got != want {
+			return fmt.Errorf("types.RawJSON: key %q is of type %s, expected %s", k, got, want)
+		}
+	}
+	return nil
+}
+
+// jsonTypeName returns the name of the JSON type of v, a value produced by
+// unmarshaling JSON into an interface{}.
+func jsonTypeName(v interface{}) string {
+	switch v.(type) {
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "boolean"
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case nil:
+		return "null"
+	default:
+		return fmt.Sprintf("%T", v)
+	}
+}
+
+// Keys returns the top-level keys of j, in the order they appear in the
+// document, without decoding their values. If j is not a JSON object, an error
+// will be returned.
+func (j RawJSON) Keys() ([]string, error) {
+	if !json.Valid(j) {
+		return nil, fmt.Errorf("types.RawJSON: cannot read keys from invalid JSON")
+	}
+	if kind := jsonKind(j); kind != "object" {
+		return nil, fmt.Errorf("types.RawJSON: expected a JSON object, got %s", kind)
+	}
+	dec := json.NewDecoder(bytes.NewReader(j))
+	// Consume the opening '{'.
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	keys := []string{}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		var skip json.RawMessage
+		if err := dec.Decode(&skip); err != nil {
+			return nil, err
+		}
+		keys = append(keys, tok.(string))
+	}
+	return keys, nil
+}
+
+// Values returns the elements of j, without decoding them. Each element is a
+// copy, and will not share memory with j. If j is not a JSON array, an error
+// will be returned.
+func (j RawJSON) Values() ([]RawJSON, error) {
+	if !json.Valid(j) {
+		return nil, fmt.Errorf("types.RawJSON: cannot read values from invalid JSON")
+	}
+	if kind := jsonKind(j); kind != "array" {
+		return nil, fmt.Errorf("types.RawJSON: expected a JSON array, got %s", kind)
+	}
+	vals := []RawJSON{}
+	if err := json.Unmarshal(j, &vals); err != nil {
+		return nil, err
+	}
+	return vals, nil
+}
+
+// Size returns the length of j, in bytes.
+func (j RawJSON) Size() int {
+	return len(j)
+}
+
+// The approximate sizes, in bytes on a 64-bit platform, of the parts of the
+// interface{} tree produced by decoding JSON with encoding/json. See
+// DecodedSize.
+const (
+	decodedInterfaceSize = 16 // an interface{} header
+	decodedStringSize    = 16 // a string header, excluding its bytes
+	decodedFloatSize     = 8  // a boxed float64
+	decodedMapSize       = 48 // a map header
+	decodedMapEntrySize  = 8  // per-entry bucket overhead, excluding the key and value
+	decodedSliceSize     = 24 // a slice header
+)
+
+// DecodedSize returns an estimate of the number of bytes of memory that
+// decoding j into an interface{} -- as json.Unmarshal would -- will occupy,
+// counting the headers of each map, slice, string, and interface along with
+// the contents of each string. The estimate is computed from a stream of j's
+// tokens, without building the decoded value, so callers may use it to decide
+// whether a document is better processed as a stream; eg. with
+// SplitJSONArray. The estimate excludes allocator rounding and the spare
+// capacity of maps and slices, so it is a lower bound rather than an exact
+// figure. If j is not valid JSON, an error will be returned.
+func (j RawJSON) DecodedSize() (int, error) {
+	if !json.Valid(j) {
+		return 0, fmt.Errorf("types.RawJSON: cannot estimate the size of invalid JSON")
+	}
+	type frame struct {
+		object    bool
+		expectKey bool
+	}
+	var stack []frame
+	size := 0
+	dec := json.NewDecoder(bytes.NewReader(j))
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return size, nil
+		} else if err != nil {
+			return 0, err
+		}
+		if d, ok := tok.(json.Delim); ok && (d == '}' || d == ']') {
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		if n := len(stack); n > 0 && stack[n-1].object {
+			if stack[n-1].expectKey {
+				// The key, and the interface{} holding its value.
+				size += decodedMapEntrySize + decodedStringSize + len(tok.(string)) + decodedInterfaceSize
+				stack[n-1].expectKey = false
+				continue
+			}
+			stack[n-1].expectKey = true
+		} else {
+			// A slice element, or the root value.
+			size += decodedInterfaceSize
+		}
+		switch x := tok.(type) {
+		case json.Delim:
+			if x == '{' {
+				size += decodedMapSize
+				stack = append(stack, frame{object: true, expectKey: true})
+			} else {
+				size += decodedSliceSize
+				stack = append(stack, frame{})
+			}
+		case string:
+			size += decodedStringSize + len(x)
+		case float64:
+			size += decodedFloatSize
+		}
+	}
+}
+
+// Equal returns true if j and other hold byte-for-byte identical documents.
+// This is cheap, but sensitive to formatting; documents that differ only in
+// whitespace or the order of object keys are not Equal. Use EqualJSON to
+// compare documents semantically.
+func (j RawJSON) Equal(other RawJSON) bool {
+	return bytes.Equal(j, other)
+}
+
+// EqualJSON returns true if j and other hold structurally equal JSON; that is,
+// if they are equal after decoding, regardless of insignificant whitespace or
+// the order of object keys. If either is not valid JSON, an error will be
+// returned. Use Equal if formatting matters, or to avoid the cost of decoding.
+func (j RawJSON) EqualJSON(other RawJSON) (bool, error) {
+	var l, r interface{}
+	if err := json.Unmarshal(j, &l); err != nil {
+		return false, err
+	}
+	if err := json.Unmarshal(other, &r); err != nil {
+		return false, err
+	}
+	return reflect.DeepEqual(l, r), nil
+}
+
+// IsObject returns true if j is a valid JSON object. Invalid JSON is not an
+// object.
+func (j RawJSON) IsObject() bool {
+	return json.Valid(j) && jsonKind(j) == "object"
+}
+
+// IsArray returns true if j is a valid JSON array. Invalid JSON is not an
+// array.
+func (j RawJSON) IsArray() bool {
+	return json.Valid(j) && jsonKind(j) == "array"
+}
+
+// IsScalar returns true if j is a valid JSON string, number, or boolean. The
+// 'null' keyword, and invalid JSON, are not scalars.
+func (j RawJSON) IsScalar() bool {
+	if !json.Valid(j) {
+		return false
+	}
+	switch jsonKind(j) {
+	case "string", "number", "boolean":
+		return true
+	}
+	return false
+}
+
+// IsNull returns true if j is the JSON 'null' keyword. Unlike IsNil, IsNull
+// will return false for a zero-length j, as it is not valid JSON.
+func (j RawJSON) IsNull() bool {
+	return json.Valid(j) && jsonKind(j) == "null"
+}
+
+// jsonKind returns the name of the JSON type of the valid JSON document j,
+// judged by its first non-whitespace byte.
+func jsonKind(j []byte) string {
+	t := bytes.TrimLeft(j, jsonWhitespace)
+	if len(t) == 0 {
+		return "nothing"
+	}
+	switch t[0] {
+	case '{':
+		return "object"
+	case '[':
+		return "array"
+	case '"':
+		return "string"
+	case 't', 'f':
+		return "boolean"
+	case 'n':
+		return "null"
+	default:
+		return "number"
+	}
+}
+
+// Get returns a copy of the value at the given path within j. Paths are made of
+// dot-separated object keys, and bracketed array indices; eg.
+// "user.addresses[0].zip". A key of decimal digits also indexes into an array,
+// so "user.addresses.0.zip" is equivalent. An empty path refers to the whole of
+// j. If the path does not resolve -- because a key is missing, an index is out
+// of range, or a key or index is applied to a value of the wrong type -- an
+// error will be returned. j will not be modified.
+//
+// This path syntax is shared by GetInto, Redact, and Transform. Keys that
+// contain a '.' or a '[' cannot be reached by it; see GetSegments.
+func (j RawJSON) Get(path string) (RawJSON, error) {
+	segs, err := parseJSONPath(path)
+	if err != nil {
+		return nil, err
+	}
+	return getJSONPath(j, path, segs)
+}
+
+// GetSegments is Get, but with the path given as a slice of object keys rather
+// than as a string; eg. []string{"a.b", "c"} refers to the key "c" of the
+// object at the key "a.b". As in Get, a key of decimal digits indexes into an
+// array.
+func (j RawJSON) GetSegments(segments []string) (RawJSON, error) {
+	return getJSONPath(j, strings.Join(segments, "."), keyPathSegments(segments))
+}
+
+// getJSONPath returns a copy of the value at segs within j. path is the
+// unparsed form of segs, for use in error messages.
+func getJSONPath(j RawJSON, path string, segs []jsonPathSegment) (RawJSON, error) {
+	if !json.Valid(j) {
+		return nil, fmt.Errorf("types.RawJSON: cannot get a value from invalid JSON")
+	}
+	cur := j
+	for _, seg := range segs {
+		kind := jsonKind(cur)
+		idx, isIndex := seg.arrayIndex()
+		switch {
+		case kind == "object" && !seg.isIndex:
+			var obj map[string]RawJSON
+			if err := json.Unmarshal(cur, &obj); err != nil {
+				return nil, err
+			}
+			next, ok := obj[seg.key]
+			if !ok {
+				return nil, fmt.Errorf("types.RawJSON: no value at path %q; missing key %q", path, seg.key)
+			}
+			cur = next
+		case kind == "array" && isIndex:
+			var arr []RawJSON
+			if err := json.Unmarshal(cur, &arr); err != nil {
+				return nil, err
+			}
+			if idx >= len(arr) {
+				return nil, fmt.Errorf("types.RawJSON: no value at path %q; index %d out of range", path, idx)
+			}
+			cur = arr[idx]
+		case seg.isIndex:
+			return nil, fmt.Errorf("types.RawJSON: no value at path %q; cannot index into %s", path, kind)
+		default:
+			return nil, fmt.Errorf("types.RawJSON: no value at path %q; cannot read key %q from %s", path, seg.key, kind)
+		}
+	}
+	return NewJSON(cur), nil
+}
+
+// jsonPathSegment is a single step of a path parsed by parseJSONPath; either an
+// object key, or an array index.
+type jsonPathSegment struct {
+	key     string
+	index   int
+	isIndex bool
+}
+
+// arrayIndex returns the array index s refers to, and true; either its
+// bracketed index, or its key if that key is a non-negative decimal integer.
+func (s jsonPathSegment) arrayIndex() (int, bool) {
+	if s.isIndex {
+		return s.index, true
+	}
+	if i, err := strconv.Atoi(s.key); err == nil && i >= 0 {
+		return i, true
+	}
+	return 0, false
+}
+
+// parseJSONPath parses the path syntax accepted by Get.
+func parseJSONPath(path string) ([]jsonPathSegment, error) {
+	var segs []jsonPathSegment
+	if path == "" {
+		return segs, nil
+	}
+	for i, part := range strings.Split(path, ".") {
+		key := part
+		if b := strings.IndexByte(part, '['); b >= 0 {
+			key = part[:b]
+			part = part[b:]
+		} else {
+			part = ""
+		}
+		// A key may only be omitted before the first index of the path.
+		if key != "" || part == "" || i > 0 {
+			if key == "" || strings.ContainsAny(key, "]") {
+				return nil, fmt.Errorf("types.RawJSON: malformed path %q", path)
+			}
+			segs = append(segs, jsonPathSegment{key: key})
+		}
+		for part != "" {
+			end := strings.IndexByte(part, ']')
+			if part[0] != '[' || end < 0 {
+				return nil, fmt.Errorf("types.RawJSON: malformed path %q", path)
+			}
+			idx, err := strconv.Atoi(part[1:end])
+			if err != nil || idx < 0 {
+				return nil, fmt.Errorf("types.RawJSON: malformed path %q", path)
+			}
+			segs = append(segs, jsonPathSegment{index: idx, isIndex: true})
+			part = part[end+1:]
+		}
+	}
+	return segs, nil
+}
+
+// keyPathSegments returns the path made of the given object keys, unparsed.
+func keyPathSegments(keys []string) []jsonPathSegment {
+	segs := make([]jsonPathSegment, len(keys))
+	for i, k := range keys {
+		segs[i] = jsonPathSegment{key: k}
+	}
+	return segs
+}
+
+// GetInto locates the value at the given path within j -- eg. "data.items[0]"
+// -- and unmarshals it into v, as json.Unmarshal would. Paths are in the syntax
+// accepted by Get. If there is no value at path, an error will be returned, and
+// v will be unchanged.
+//
+// Keys that contain a '.' or a '[' cannot be reached by path; see
+// GetIntoSegments.
+func (j RawJSON) GetInto(path string, v interface{}) error {
+	cur, err := j.Get(path)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(cur, v)
+}
+
+// GetIntoSegments is GetInto, but with the path given as a slice of object keys
+// rather than as a string, as in GetSegments.
+func (j RawJSON) GetIntoSegments(segments []string, v interface{}) error {
+	cur, err := j.GetSegments(segments)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(cur, v)
+}
+
+// Redact returns a copy of j in which the value at each of the given paths --
+// eg. "user.password", or "users[0].password" -- has been replaced with the
+// string "[REDACTED]". Paths are in the syntax accepted by Get; malformed paths
+// are an error, while paths that are not present in j are ignored. Objects and
+// arrays along a redacted path are re-encoded without insignificant whitespace,
+// but keep their order; the rest of j is left as-is.
+//
+// Keys that contain a '.' or a '[' cannot be reached by path; see
+// RedactSegments.
+func (j RawJSON) Redact(paths ...string) (RawJSON, error) {
+	segs := make([][]jsonPathSegment, len(paths))
+	for i, p := range paths {
+		var err error
+		if segs[i], err = parseJSONPath(p); err != nil {
+			return nil, err
+		}
+	}
+	return redactJSONPaths(j, segs)
+}
+
+// RedactSegments is Redact, but with each path given as a slice of object keys
+// rather than as a string, as in GetSegments.
+func (j RawJSON) RedactSegments(paths ...[]string) (RawJSON, error) {
+	segs := make([][]jsonPathSegment, len(paths))
+	for i, p := range paths {
+		segs[i] = keyPathSegments(p)
+	}
+	return redactJSONPaths(j, segs)
+}
+
+// redactJSONPaths returns a copy of j with the value at each of paths replaced.
+func redactJSONPaths(j RawJSON, paths [][]jsonPathSegment) (RawJSON, error) {
+	if !json.Valid(j) {
+		return nil, fmt.Errorf("types.RawJSON: cannot redact invalid JSON")
+	}
+	ret := NewJSON(j)
+	for _, p := range paths {
+		var err error
+		ret, _, err = redactJSON(ret, p)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return ret, nil
+}
+
+// redactJSON returns j with the value at path replaced, and true; or j itself,
+// and false, if path is not present in j.
+func redactJSON(j RawJSON, path []jsonPathSegment) (RawJSON, bool, error) {
+	if len(path) == 0 {
+		return j, false, nil
+	}
+	kind := jsonKind(j)
+	idx, isIndex := path[0].arrayIndex()
+	isObject := kind == "object" && !path[0].isIndex
+	if !isObject && !(kind == "array" && isIndex) {
+		return j, false, nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(j))
+	// Consume the opening '{' or '['.
+	if _, err := dec.Token(); err != nil {
+		return nil, false, err
+	}
+	var buf bytes.Buffer
+	if isObject {
+		buf.WriteByte('{')
+	} else {
+		buf.WriteByte('[')
+	}
+	redacted := false
+	for i := 0; dec.More(); i++ {
+		var key string
+		if isObject {
+			tok, err := dec.Token()
+			if err != nil {
+				return nil, false, err
+			}
+			key = tok.(string)
+		}
+		var val RawJSON
+		if err := dec.Decode(&val); err != nil {
+			return nil, false, err
+		}
+		if (isObject && key == path[0].key) || (!isObject && i == idx) {
+			if len(path) == 1 {
+				val = RawJSON(`"[REDACTED]"`)
+				redacted = true
+			} else {
+				var (
+					ok  bool
+					err error
+				)
+				if val, ok, err = redactJSON(val, path[1:]); err != nil {
+					return nil, false, err
+				}
+				redacted = redacted || ok
+			}
+		}
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+		if isObject {
+			k, err := json.Marshal(key)
+			if err != nil {
+				return nil, false, err
+			}
+			buf.Write(k)
+			buf.WriteByte(':')
+		}
+		buf.Write(val)
+	}
+	if !redacted {
+		return j, false, nil
+	}
+	if isObject {
+		buf.WriteByte('}')
+	} else {
+		buf.WriteByte(']')
+	}
+	return RawJSON(buf.Bytes()), true, nil
+}
+
+// Transform decodes j, calls fn once for each leaf value -- each string,
+// number, boolean, and null -- and returns a new RawJSON with each leaf
+// replaced by the value fn returns. fn is given the path of the leaf, in the
+// syntax accepted by Get, and the leaf's decoded value; a string, json.Number,
+// bool, or nil. fn may return any value json.Marshal accepts. Empty objects and
+// arrays are not leaves, and are kept as they are.
+//
+// The result is re-encoded without insignificant whitespace, and with object
+// keys in sorted order. Leaves are visited in that same order. If fn returns an
+// error, Transform stops and returns that error. j will not be modified.
+func (j RawJSON) Transform(fn func(path string, value interface{}) (interface{}, error)) (RawJSON, error) {
+	if !json.Valid(j) {
+		return nil, fmt.Errorf("types.RawJSON: cannot transform invalid JSON")
+	}
+	dec := json.NewDecoder(bytes.NewReader(j))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	v, err := transformJSON("", v, fn)
+	if err != nil {
+		return nil, err
+	}
+	ret, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return RawJSON(ret), nil
+}
+
+// transformJSON applies fn to each leaf of the decoded JSON value v, found at
+// path, in place.
+func transformJSON(path string, v interface{}, fn func(string, interface{}) (interface{}, error)) (interface{}, error) {
+	var err error
+	switch val := v.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(val))
+		for k := range val {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			p := k
+			if path != "" {
+				p = path + "." + k
+			}
+			if val[k], err = transformJSON(p, val[k], fn); err != nil {
+				return nil, err
+			}
+		}
+		return val, nil
+	case []interface{}:
+		for i, elem := range val {
+			p := path + "[" + strconv.Itoa(i) + "]"
+			if val[i], err = transformJSON(p, elem, fn); err != nil {
+				return nil, err
+			}
+		}
+		return val, nil
+	default:
+		return fn(path, val)
+	}
+}
+
+// CoalesceJSON returns the first of the given docs that is neither nil (of
+// zero length) nor the JSON 'null' keyword. If no such document exists, nil is
+// returned. The returned RawJSON is not a copy.
+func CoalesceJSON(docs ...RawJSON) RawJSON {
+	for _, doc := range docs {
+		if len(doc) == 0 || bytes.Equal(bytes.TrimSpace(doc), []byte("null")) {
+			continue
+		}
+		return doc
+	}
+	return nil
+}
+
+// SplitJSONArray reads a single JSON array from r, and calls fn once for each
+// element of that array, in order. Elements are decoded one at a time, so only
+// a single element need be held in memory at once. Each RawJSON passed to fn is
+// a newly allocated object that fn is free to retain.
+//
+// If r does not contain a JSON array, or if fn returns an error, iteration will
+// stop and that error will be returned.
+func SplitJSONArray(r io.Reader, fn func(RawJSON) error) error {
+	dec := json.NewDecoder(r)
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if d, ok := tok.(json.Delim); !ok || d != '[' {
+		return fmt.Errorf("types.RawJSON: expected the start of a JSON array, got %v", tok)
+	}
+	for dec.More() {
+		var elem RawJSON
+		if err := dec.Decode(&elem); err != nil {
+			return err
+		}
+		if err := fn(elem); err != nil {
+			return err
+		}
+	}
+	// Consume the closing bracket so a truncated array will be reported.
+	if _, err := dec.Token(); err != nil {
+		return err
+	}
+	return nil
+}
@@ -1,8 +1,11 @@
 package types_test
 
 import (
+	"bytes"
 	"database/sql/driver"
 	"encoding/json"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/pyrrho/encoding/maps"
@@ -90,6 +93,77 @@ func TestSurprises(t *testing.T) {
 	require.NotNil(m) // Note that it won't nil an initialized RawJSON.
 }
 
+func TestRawJSONValid(t *testing.T) {
+	require := require.New(t)
+
+	require.True(types.NewJSONStr(`{"a":1}`).Valid())
+	require.True(types.NewJSONStr(`null`).Valid())
+	require.False(types.NewJSONStr(`:->`).Valid())
+	require.False(types.RawJSON(nil).Valid())
+
+	// Scan does not validate, but Valid can catch what it lets through.
+	var j types.RawJSON
+	require.NoError(j.Scan(`:->`))
+	require.False(j.Valid())
+}
+
+func TestRawJSONSetChecked(t *testing.T) {
+	require := require.New(t)
+
+	j := types.NewJSONStr(`{"a":1}`)
+	require.NoError(j.SetChecked([]byte(`[1, 2]`)))
+	require.Equal(types.NewJSONStr(`[1, 2]`), j)
+
+	err := j.SetChecked([]byte(`:->`))
+	require.Error(err)
+	require.Equal(types.NewJSONStr(`[1, 2]`), j)
+
+	err = j.SetChecked(nil)
+	require.Error(err)
+	require.Equal(types.NewJSONStr(`[1, 2]`), j)
+}
+
+func TestRawJSONCompact(t *testing.T) {
+	require := require.New(t)
+
+	ba := []byte(` { "a" : [1, 2, 3], "b": {"c": "d e"} } `)
+	j := types.RawJSON(ba) // Deliberately shares memory with ba.
+	require.NoError(j.Compact())
+	require.Equal(types.NewJSONStr(`{"a":[1,2,3],"b":{"c":"d e"}}`), j)
+	// The original array was not written to.
+	require.Equal(` { "a" : [1, 2, 3], "b": {"c": "d e"} } `, string(ba))
+
+	// Compaction is idempotent.
+	compacted := types.NewJSON(j)
+	require.NoError(j.Compact())
+	require.Equal(compacted, j)
+
+	bad := types.NewJSONStr(`{"a": `)
+	require.Error(bad.Compact())
+	require.Equal(types.NewJSONStr(`{"a": `), bad)
+}
+
+func TestRawJSONIndent(t *testing.T) {
+	require := require.New(t)
+
+	j := types.NewJSONStr(`{"a":[1,2],"b":{}}`)
+	require.NoError(j.Indent("", "  "))
+	require.Equal("{\n  \"a\": [\n    1,\n    2\n  ],\n  \"b\": {}\n}", string(j))
+
+	// Indented JSON compacts back to its original form.
+	require.NoError(j.Compact())
+	require.Equal(types.NewJSONStr(`{"a":[1,2],"b":{}}`), j)
+
+	// A prefix that isn't whitespace will leave j holding invalid JSON.
+	require.NoError(j.Indent("> ", "\t"))
+	require.Equal("{\n> \t\"a\": [\n> \t\t1,\n> \t\t2\n> \t],\n> \t\"b\": {}\n> }", string(j))
+	require.False(j.IsObject())
+
+	bad := types.NewJSONStr(`[1, 2`)
+	require.Error(bad.Indent("", "  "))
+	require.Equal(types.NewJSONStr(`[1, 2`), bad)
+}
+
 func TestRawJSONIsNil(t *testing.T) {
 	require := require.New(t)
 
@@ -164,6 +238,55 @@ func TestRawJSONIsZero(t *testing.T) {
 
 	nil_ := types.RawJSON(nil)
 	require.True(nil_.IsZero())
+
+	// Insignificant whitespace is ignored ...
+	require.True(types.RawJSON(" { \n } ").IsZero())
+	require.True(types.RawJSON("\t[ ]\r\n").IsZero())
+	require.True(types.RawJSON(" null ").IsZero())
+	require.True(types.RawJSON(" 0 ").IsZero())
+	require.False(types.RawJSON(" ").IsZero())
+
+	// ... and malformed JSON is never zero.
+	require.False(types.RawJSON("{").IsZero())
+	require.False(types.RawJSON("{]").IsZero())
+	require.False(types.RawJSON("[]]").IsZero())
+	require.False(types.RawJSON(`"`).IsZero())
+	require.False(types.RawJSON("nul").IsZero())
+	require.False(types.RawJSON("falsey").IsZero())
+	require.False(types.RawJSON("0.0.0").IsZero())
+}
+
+func TestRawJSONOmitZero(t *testing.T) {
+	require := require.New(t)
+
+	type Wrapper struct {
+		Obj      types.RawJSON `map:",omitZero"`
+		Arr      types.RawJSON `map:",omitZero"`
+		Str      types.RawJSON `map:",omitZero"`
+		Nul      types.RawJSON `map:",omitZero"`
+		Num      types.RawJSON `map:",omitZero"`
+		Empty    types.RawJSON `map:",omitZero"`
+		FullObj  types.RawJSON `map:",omitZero"`
+		FullArr  types.RawJSON `map:",omitZero"`
+		Untagged types.RawJSON
+	}
+	data, err := maps.Marshal(Wrapper{
+		Obj:      types.NewJSONStr(`{}`),
+		Arr:      types.NewJSONStr(`[]`),
+		Str:      types.NewJSONStr(`""`),
+		Nul:      types.NewJSONStr(`null`),
+		Num:      types.NewJSONStr(`0`),
+		Empty:    types.RawJSON{},
+		FullObj:  types.NewJSONStr(`{"foo": 42}`),
+		FullArr:  types.NewJSONStr(`[{}]`),
+		Untagged: types.NewJSONStr(`{}`),
+	})
+	require.NoError(err)
+	require.Equal(map[string]interface{}{
+		"FullObj":  map[string]interface{}{"foo": 42.0},
+		"FullArr":  []interface{}{map[string]interface{}{}},
+		"Untagged": map[string]interface{}{},
+	}, data)
 }
 
 func TestRawJSONValueIsZero(t *testing.T) {
@@ -449,3 +572,606 @@ func TestRawJSONMarshalMapValue(t *testing.T) {
 	// This error should include information on the malformed object.
 	require.Contains(err.Error(), "invalid character 'b'")
 }
+
+func TestRawJSONEqual(t *testing.T) {
+	require := require.New(t)
+
+	require.True(types.NewJSONStr(`{"a":1}`).Equal(types.NewJSONStr(`{"a":1}`)))
+	require.True(types.RawJSON(nil).Equal(types.RawJSON{}))
+	require.False(types.NewJSONStr(`{"a":1}`).Equal(types.NewJSONStr(`{"a":2}`)))
+	// Formatting matters to Equal, ...
+	require.False(types.NewJSONStr(`{"a":1}`).Equal(types.NewJSONStr(`{ "a": 1 }`)))
+	require.False(types.NewJSONStr(`{"a":1,"b":2}`).Equal(types.NewJSONStr(`{"b":2,"a":1}`)))
+	// ... but not to EqualJSON.
+	equal, err := types.NewJSONStr(`{"a":1}`).EqualJSON(types.NewJSONStr(`{ "a": 1 }`))
+	require.NoError(err)
+	require.True(equal)
+	// Equal doesn't validate.
+	require.True(types.NewJSONStr(`{"a":`).Equal(types.NewJSONStr(`{"a":`)))
+}
+
+func TestRawJSONEqualJSON(t *testing.T) {
+	require := require.New(t)
+
+	for _, tc := range []struct {
+		l, r  string
+		equal bool
+	}{
+		{`{"a":1,"b":2}`, `{"b":2,"a":1}`, true},
+		{`{"a":1}`, `{"a":2}`, false},
+		{` [1, {"a": [true, null]}] `, `[1,{"a":[true,null]}]`, true},
+		{`[1, 2]`, `[2, 1]`, false},
+		{`1.0`, `1`, true},
+		{`"1"`, `1`, false},
+		{`{"a":1}`, `{"a":1,"b":2}`, false},
+	} {
+		equal, err := types.NewJSONStr(tc.l).EqualJSON(types.NewJSONStr(tc.r))
+		require.NoError(err)
+		require.Equal(tc.equal, equal, tc.l+" vs "+tc.r)
+	}
+
+	_, err := types.NewJSONStr(`{"a":`).EqualJSON(types.NewJSONStr(`{}`))
+	require.Error(err)
+	_, err = types.NewJSONStr(`{}`).EqualJSON(types.RawJSON(nil))
+	require.Error(err)
+}
+
+func TestRawJSONKindHelpers(t *testing.T) {
+	require := require.New(t)
+
+	for _, tc := range []struct {
+		doc                             string
+		object, array, scalar, nullJSON bool
+	}{
+		{`{"a": 1}`, true, false, false, false},
+		{` {} `, true, false, false, false},
+		{`[1, 2]`, false, true, false, false},
+		{`"string"`, false, false, true, false},
+		{`-1.5e3`, false, false, true, false},
+		{`true`, false, false, true, false},
+		{`false`, false, false, true, false},
+		{` null `, false, false, false, true},
+		// Invalid JSON is none of the above.
+		{``, false, false, false, false},
+		{`{"a":`, false, false, false, false},
+		{`[1, 2`, false, false, false, false},
+		{`nul`, false, false, false, false},
+		{`"unterminated`, false, false, false, false},
+	} {
+		j := types.NewJSONStr(tc.doc)
+		require.Equal(tc.object, j.IsObject(), tc.doc)
+		require.Equal(tc.array, j.IsArray(), tc.doc)
+		require.Equal(tc.scalar, j.IsScalar(), tc.doc)
+		require.Equal(tc.nullJSON, j.IsNull(), tc.doc)
+	}
+}
+
+func TestCoalesceJSON(t *testing.T) {
+	require := require.New(t)
+
+	require.EqualValues(`{"foo":"bar"}`, types.CoalesceJSON(
+		nil,
+		types.RawJSON{},
+		types.NewJSONStr("null"),
+		types.NewJSONStr(" null\n"),
+		types.NewJSONStr(`{"foo":"bar"}`),
+		types.NewJSONStr(`{"foo":"baz"}`),
+	))
+
+	// Zero-values are not null.
+	require.EqualValues(`0`, types.CoalesceJSON(
+		types.NewJSONStr("null"),
+		types.NewJSONStr("0"),
+		types.NewJSONStr("1"),
+	))
+	require.EqualValues(`""`, types.CoalesceJSON(nil, types.NewJSONStr(`""`)))
+
+	// If nothing qualifies, the result is nil.
+	require.Nil(types.CoalesceJSON())
+	require.Nil(types.CoalesceJSON(nil, types.NewJSONStr("null")))
+}
+
+func TestSplitJSONArray(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	var sb strings.Builder
+	sb.WriteString("[")
+	for i := 0; i < 1000; i++ {
+		if i > 0 {
+			sb.WriteString(",")
+		}
+		sb.WriteString(`{"index":`)
+		sb.WriteString(strconv.Itoa(i))
+		sb.WriteString("}")
+	}
+	sb.WriteString("]")
+
+	count := 0
+	err = types.SplitJSONArray(strings.NewReader(sb.String()), func(j types.RawJSON) error {
+		require.EqualValues(`{"index":`+strconv.Itoa(count)+"}", j)
+		count++
+		return nil
+	})
+	require.NoError(err)
+	require.Equal(1000, count)
+
+	// Empty arrays never call fn.
+	err = types.SplitJSONArray(strings.NewReader("[]"), func(j types.RawJSON) error {
+		require.FailNow("fn should not be called for an empty array")
+		return nil
+	})
+	require.NoError(err)
+
+	// Errors returned from fn stop iteration, and are returned.
+	count = 0
+	err = types.SplitJSONArray(strings.NewReader(`[1, 2, 3]`), func(j types.RawJSON) error {
+		count++
+		if count == 2 {
+			return json.Unmarshal([]byte(":->"), &j)
+		}
+		return nil
+	})
+	require.Error(err)
+	require.Equal(2, count)
+
+	// Non-arrays and truncated arrays are errors.
+	err = types.SplitJSONArray(strings.NewReader(`{"foo":"bar"}`), func(j types.RawJSON) error {
+		return nil
+	})
+	require.Error(err)
+	require.Contains(err.Error(), "RawJSON:")
+	err = types.SplitJSONArray(strings.NewReader(`[1, 2`), func(j types.RawJSON) error {
+		return nil
+	})
+	require.Error(err)
+}
+
+func TestRawJSONMatchesShape(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	doc := types.NewJSONStr(`{
+		"name": "Tom",
+		"lives": 9,
+		"isGood": false,
+		"owner": {"name": "Jerry"},
+		"toys": ["yarn"],
+		"collar": null,
+		"extra": "ignored"
+	}`)
+
+	err = doc.MatchesShape(map[string]string{
+		"name":   "string",
+		"lives":  "number",
+		"isGood": "boolean",
+		"owner":  "object",
+		"toys":   "array",
+		"collar": "null",
+	})
+	require.NoError(err)
+
+	// An empty shape matches any object.
+	err = doc.MatchesShape(map[string]string{})
+	require.NoError(err)
+
+	// Mismatched types ...
+	err = doc.MatchesShape(map[string]string{
+		"name":  "string",
+		"lives": "string",
+	})
+	require.Error(err)
+	require.Contains(err.Error(), `"lives"`)
+	require.Contains(err.Error(), "number")
+
+	// ... missing keys ...
+	err = doc.MatchesShape(map[string]string{"whiskers": "number"})
+	require.Error(err)
+	require.Contains(err.Error(), `"whiskers"`)
+
+	// ... and unknown type names are all errors.
+	err = doc.MatchesShape(map[string]string{"name": "text"})
+	require.Error(err)
+
+	// Only objects have a shape.
+	err = types.NewJSONStr(`["name"]`).MatchesShape(map[string]string{})
+	require.Error(err)
+	err = types.NewJSONStr(`null`).MatchesShape(map[string]string{})
+	require.Error(err)
+	err = types.NewJSONStr(`{"name":`).MatchesShape(map[string]string{})
+	require.Error(err)
+}
+
+func TestRawJSONKeys(t *testing.T) {
+	require := require.New(t)
+
+	doc := types.NewJSONStr(`{"name": "Tom", "owner": {"name": "Jerry"}, "lives": 9}`)
+	keys, err := doc.Keys()
+	require.NoError(err)
+	// Keys are returned in document order, and nested keys are not included.
+	require.Equal([]string{"name", "owner", "lives"}, keys)
+
+	keys, err = types.NewJSONStr(` {} `).Keys()
+	require.NoError(err)
+	require.Equal([]string{}, keys)
+
+	// Only objects have keys.
+	_, err = types.NewJSONStr(`["name"]`).Keys()
+	require.Error(err)
+	require.Contains(err.Error(), "array")
+	_, err = types.NewJSONStr(`null`).Keys()
+	require.Error(err)
+	_, err = types.NewJSONStr(`{"name":`).Keys()
+	require.Error(err)
+}
+
+func TestRawJSONValues(t *testing.T) {
+	require := require.New(t)
+
+	doc := types.NewJSONStr(`[1, "two", {"three": 3}, [4], null]`)
+	vals, err := doc.Values()
+	require.NoError(err)
+	require.Equal([]types.RawJSON{
+		types.NewJSONStr(`1`),
+		types.NewJSONStr(`"two"`),
+		types.NewJSONStr(`{"three": 3}`),
+		types.NewJSONStr(`[4]`),
+		types.NewJSONStr(`null`),
+	}, vals)
+
+	vals, err = types.NewJSONStr(`[]`).Values()
+	require.NoError(err)
+	require.Equal([]types.RawJSON{}, vals)
+
+	// Only arrays have values.
+	_, err = types.NewJSONStr(`{"name": "Tom"}`).Values()
+	require.Error(err)
+	require.Contains(err.Error(), "object")
+	_, err = types.NewJSONStr(`"string"`).Values()
+	require.Error(err)
+	_, err = types.NewJSONStr(`[1,`).Values()
+	require.Error(err)
+}
+
+func TestRawJSONGet(t *testing.T) {
+	require := require.New(t)
+
+	doc := types.NewJSONStr(`{
+		"user": {
+			"name": "Tom",
+			"addresses": [{"zip": "12345"}, {"zip": "54321"}],
+			"matrix": [[1, 2], [3, 4]]
+		}
+	}`)
+	orig := types.NewJSON(doc)
+
+	for path, expected := range map[string]string{
+		"user.name":             `"Tom"`,
+		"user.addresses[0].zip": `"12345"`,
+		"user.addresses[1]":     `{"zip": "54321"}`,
+		"user.matrix[1][0]":     `3`,
+		"user.matrix[0]":        `[1, 2]`,
+		"":                      string(doc),
+		"user.addresses[1].zip": `"54321"`,
+	} {
+		v, err := doc.Get(path)
+		require.NoError(err, path)
+		require.Equal(types.NewJSONStr(expected), v, path)
+	}
+
+	arr, err := types.NewJSONStr(`[{"a": 1}]`).Get("[0].a")
+	require.NoError(err)
+	require.Equal(types.NewJSONStr(`1`), arr)
+
+	// Missing keys, out of range indices, and type mismatches are errors.
+	for _, path := range []string{
+		"user.missing",
+		"user.addresses[2]",
+		"user.name[0]",       // indexing into a string
+		"user.name.first",    // reading a key from a string
+		"user.addresses.zip", // reading a key from an array
+		"user[0]",            // indexing into an object
+	} {
+		_, err := doc.Get(path)
+		require.Error(err, path)
+		require.Contains(err.Error(), path)
+	}
+
+	// Malformed paths are errors.
+	for _, path := range []string{"user..name", "user.", ".user", "user[", "user[x]", "user[-1]", "user[0]x", "user.[0]"} {
+		_, err := doc.Get(path)
+		require.Error(err, path)
+		require.Contains(err.Error(), "malformed")
+	}
+
+	_, err = types.NewJSONStr(`{"a":`).Get("a")
+	require.Error(err)
+
+	// The returned value is a copy, and doc is unchanged.
+	v, err := doc.Get("user.name")
+	require.NoError(err)
+	v[1] = 'J'
+	require.Equal(orig, doc)
+}
+
+func TestRawJSONGetInto(t *testing.T) {
+	require := require.New(t)
+
+	doc := types.NewJSONStr(`{
+		"data": {"count": 3, "items": ["a", "b", "c"], "owner": {"name": "Tom"}}
+	}`)
+
+	var count int
+	require.NoError(doc.GetInto("data.count", &count))
+	require.Equal(3, count)
+
+	var items []string
+	require.NoError(doc.GetInto("data.items", &items))
+	require.Equal([]string{"a", "b", "c"}, items)
+
+	var item string
+	require.NoError(doc.GetInto("data.items.1", &item))
+	require.Equal("b", item)
+
+	var owner struct{ Name string }
+	require.NoError(doc.GetInto("data.owner", &owner))
+	require.Equal("Tom", owner.Name)
+
+	var whole map[string]interface{}
+	require.NoError(doc.GetInto("", &whole))
+	require.Contains(whole, "data")
+
+	// Paths share Get's syntax.
+	require.NoError(doc.GetInto("data.items[2]", &item))
+	require.Equal("c", item)
+	nested := types.NewJSONStr(`{"a": {"items": [{"x": 7}]}}`)
+	require.NoError(nested.GetInto("a.items[0].x", &count))
+	require.Equal(7, count)
+
+	// Missing paths are errors, and leave v unchanged.
+	count = 42
+	for _, path := range []string{"data.missing", "data.count.deeper", "data.items.3", "data.items.x", "nothing"} {
+		err := doc.GetInto(path, &count)
+		require.Error(err, path)
+		require.Contains(err.Error(), path)
+	}
+	require.Equal(42, count)
+
+	// Type mismatches are reported by json.Unmarshal.
+	require.Error(doc.GetInto("data.items", &count))
+	require.Error(types.NewJSONStr(`{"data":`).GetInto("data", &count))
+}
+
+func TestRawJSONRedact(t *testing.T) {
+	require := require.New(t)
+
+	doc := types.NewJSONStr(`{
+		"user": {"name": "Tom", "password": "hunter2", "tags": [1, 2]},
+		"password": "top-level",
+		"lives": 9
+	}`)
+	redacted, err := doc.Redact("user.password")
+	require.NoError(err)
+	require.JSONEq(`{
+		"user": {"name": "Tom", "password": "[REDACTED]", "tags": [1, 2]},
+		"password": "top-level",
+		"lives": 9
+	}`, string(redacted))
+	// Key order is preserved.
+	keys, err := redacted.Keys()
+	require.NoError(err)
+	require.Equal([]string{"user", "password", "lives"}, keys)
+	// j is not modified.
+	require.Contains(string(doc), "hunter2")
+
+	// Whole subtrees may be redacted, and missing paths are ignored.
+	redacted, err = doc.Redact("user", "password", "user.missing", "lives.none", "nothing")
+	require.NoError(err)
+	require.JSONEq(`{
+		"user": "[REDACTED]",
+		"password": "[REDACTED]",
+		"lives": 9
+	}`, string(redacted))
+
+	redacted, err = doc.Redact("missing.path")
+	require.NoError(err)
+	require.Equal(doc, redacted)
+
+	_, err = types.NewJSONStr(`{"user":`).Redact("user")
+	require.Error(err)
+
+	// Paths share Get's syntax, and may index into arrays.
+	arr := types.NewJSONStr(`{"a": {"items": [{"x": "secret", "y": 1}, {"x": "other"}]}}`)
+	for _, path := range []string{"a.items[0].x", "a.items.0.x"} {
+		redacted, err = arr.Redact(path)
+		require.NoError(err, path)
+		require.JSONEq(`{"a": {"items": [{"x": "[REDACTED]", "y": 1}, {"x": "other"}]}}`, string(redacted), path)
+	}
+	redacted, err = arr.Redact("a.items[2].x", "a[0]")
+	require.NoError(err)
+	require.Equal(arr, redacted)
+
+	// Malformed paths are errors, rather than being silently ignored.
+	_, err = arr.Redact("a.items[x]")
+	require.Error(err)
+	require.Contains(err.Error(), "malformed")
+}
+
+func TestRawJSONPathSegments(t *testing.T) {
+	require := require.New(t)
+
+	doc := types.NewJSONStr(`{"a.b": {"c": 1, "d": "secret"}, "a": {"b": {"c": 2}}}`)
+
+	// Dotted paths descend through "a" and "b" ...
+	var c int
+	require.NoError(doc.GetInto("a.b.c", &c))
+	require.Equal(2, c)
+
+	// ... while segments may name keys containing dots.
+	require.NoError(doc.GetIntoSegments([]string{"a.b", "c"}, &c))
+	require.Equal(1, c)
+	err := doc.GetIntoSegments([]string{"a.b", "missing"}, &c)
+	require.Error(err)
+	require.Contains(err.Error(), "a.b.missing")
+
+	var whole map[string]interface{}
+	require.NoError(doc.GetIntoSegments(nil, &whole))
+	require.Len(whole, 2)
+
+	v, err := doc.GetSegments([]string{"a.b", "d"})
+	require.NoError(err)
+	require.Equal(types.NewJSONStr(`"secret"`), v)
+	v, err = doc.GetSegments([]string{"a", "b"})
+	require.NoError(err)
+	require.Equal(types.NewJSONStr(`{"c": 2}`), v)
+	_, err = doc.GetSegments([]string{"a.b", "missing"})
+	require.Error(err)
+	require.Contains(err.Error(), "a.b.missing")
+
+	// Segments of decimal digits index into arrays, and segments are never
+	// parsed; brackets are part of the key.
+	arr := types.NewJSONStr(`{"list": [10, 20], "list[0]": "key"}`)
+	v, err = arr.GetSegments([]string{"list", "1"})
+	require.NoError(err)
+	require.Equal(types.NewJSONStr(`20`), v)
+	v, err = arr.GetSegments([]string{"list[0]"})
+	require.NoError(err)
+	require.Equal(types.NewJSONStr(`"key"`), v)
+
+	redacted, err := doc.RedactSegments([]string{"a.b", "d"})
+	require.NoError(err)
+	require.JSONEq(`{"a.b": {"c": 1, "d": "[REDACTED]"}, "a": {"b": {"c": 2}}}`, string(redacted))
+
+	// The equivalent dotted path misses the key entirely.
+	redacted, err = doc.Redact("a.b.d")
+	require.NoError(err)
+	require.Equal(doc, redacted)
+}
+
+func TestRawJSONTransform(t *testing.T) {
+	require := require.New(t)
+
+	doc := types.NewJSONStr(`{
+		"Name": "Ada",
+		"tags": ["ONE", "Two", 3],
+		"nested": {"Deep": [{"v": "MiXeD"}], "ok": true, "none": null, "big": 12345678901234567890},
+		"empty": {}
+	}`)
+
+	var paths []string
+	lowered, err := doc.Transform(func(path string, v interface{}) (interface{}, error) {
+		paths = append(paths, path)
+		if s, ok := v.(string); ok {
+			return strings.ToLower(s), nil
+		}
+		return v, nil
+	})
+	require.NoError(err)
+	require.Equal(`{"Name":"ada","empty":{},"nested":{"Deep":[{"v":"mixed"}],"big":12345678901234567890,"none":null,"ok":true},"tags":["one","two",3]}`,
+		string(lowered))
+	require.Equal([]string{
+		"Name",
+		"nested.Deep[0].v",
+		"nested.big",
+		"nested.none",
+		"nested.ok",
+		"tags[0]",
+		"tags[1]",
+		"tags[2]",
+	}, paths)
+
+	// Paths are accepted by Get.
+	v, err := lowered.Get(paths[1])
+	require.NoError(err)
+	require.Equal(`"mixed"`, string(v))
+
+	// Scalar documents are a single leaf at the empty path.
+	doubled, err := types.NewJSONStr(`21`).Transform(func(path string, v interface{}) (interface{}, error) {
+		require.Equal("", path)
+		n, err := v.(json.Number).Int64()
+		return n * 2, err
+	})
+	require.NoError(err)
+	require.Equal(`42`, string(doubled))
+
+	// Errors from fn are returned as is.
+	_, err = doc.Transform(func(path string, v interface{}) (interface{}, error) {
+		return nil, strconv.ErrRange
+	})
+	require.Equal(strconv.ErrRange, err)
+
+	_, err = types.NewJSONStr(`{"a":`).Transform(func(path string, v interface{}) (interface{}, error) {
+		return v, nil
+	})
+	require.Error(err)
+}
+
+func TestRawJSONSize(t *testing.T) {
+	require := require.New(t)
+
+	require.Equal(0, types.RawJSON(nil).Size())
+	require.Equal(7, types.NewJSONStr(`{"a":1}`).Size())
+}
+
+func TestRawJSONDecodedSize(t *testing.T) {
+	require := require.New(t)
+
+	for _, c := range []struct {
+		doc      string
+		expected int
+	}{
+		// An interface{} holding nil.
+		{`null`, 16},
+		{`true`, 16},
+		// The interface{}, and a boxed float64.
+		{`1.5`, 16 + 8},
+		// The interface{}, and a 3-byte string.
+		{`"abc"`, 16 + 16 + 3},
+		// The interface{}, the slice, and two elements.
+		{`[1, "a"]`, 16 + 24 + (16 + 8) + (16 + 16 + 1)},
+		// The interface{}, the map, and one entry with a 3-byte key.
+		{`{"key": null}`, 16 + 48 + (8 + 16 + 3 + 16)},
+		{`{"a": {"b": []}}`, 16 + 48 + (8 + 16 + 1 + 16) + 48 + (8 + 16 + 1 + 16) + 24},
+	} {
+		size, err := types.NewJSONStr(c.doc).DecodedSize()
+		require.NoError(err, c.doc)
+		require.Equal(c.expected, size, c.doc)
+	}
+
+	// The estimate grows with the document.
+	var buf bytes.Buffer
+	prev := 0
+	buf.WriteString(`[]`)
+	for i := 0; i < 100; i++ {
+		buf.Truncate(buf.Len() - 1)
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.WriteString(`{"id":` + strconv.Itoa(i) + `,"name":"item","tags":["a","b"]}]`)
+		size, err := types.NewJSON(buf.Bytes()).DecodedSize()
+		require.NoError(err)
+		require.True(size > prev, "size did not grow with the document")
+		require.True(size > buf.Len(), "decoded size should exceed the encoded size")
+		prev = size
+	}
+
+	_, err := types.NewJSONStr(`{"a":`).DecodedSize()
+	require.Error(err)
+}
+
+func TestReadJSON(t *testing.T) {
+	require := require.New(t)
+
+	j, err := types.ReadJSON(bytes.NewReader([]byte(`{"foo": [1, 2, 3]}`)))
+	require.NoError(err)
+	require.Equal(types.NewJSONStr(`{"foo": [1, 2, 3]}`), j)
+
+	// Empty input is not valid JSON ...
+	_, err = types.ReadJSON(bytes.NewReader(nil))
+	require.Error(err)
+
+	// ... nor is anything malformed.
+	_, err = types.ReadJSON(bytes.NewReader([]byte(`{"foo":`)))
+	require.Error(err)
+	require.Contains(err.Error(), "types.RawJSON:")
+}
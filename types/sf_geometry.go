@@ -0,0 +1,156 @@
+package types
+
+import (
+	"database/sql/driver"
+	"fmt"
+
+	"github.com/twpayne/go-geom"
+	"github.com/twpayne/go-geom/encoding/geojson"
+)
+
+// SFGeometry is a Simple Feature Geometry of any type, named for the OpenGIS
+// specification that backs WKB, WKT, and GeoJSON representations of geospatial
+// data. An SFGeometry may hold a point, a polygon, a collection, or any other
+// geometry go-geom supports, and is intended for columns whose geometry type
+// is not known at compile time. The type of the held geometry is preserved
+// through all of the conversions below, and may be inspected with Geometry,
+// AsPoint, or AsPolygon.
+//
+// This type is built on top of the go-geom geom.T interface, implementing all
+// of the pyrrho/encoding/types interfaces detailed in the package comments.
+// Database interactions (Value and Scan) will convert to and from a WKB (Well
+// Known Binary) representation. JSON interactions (MarshalJSON and
+// UnmarshalJSON) will convert to and from a GeoJSON representation.
+type SFGeometry struct {
+	g geom.T
+}
+
+// Constructors
+
+// NewSFGeometry constructs and returns a new SFGeometry object holding the
+// given geometry g. The geometries contained by the other SF types may be
+// passed by address; eg. &p.Point for an SFPoint p.
+func NewSFGeometry(g geom.T) SFGeometry {
+	return SFGeometry{g}
+}
+
+// Getters and Setters
+
+// Geometry returns the geometry held by g, or nil if g is uninitialized.
+func (g SFGeometry) Geometry() geom.T {
+	return g.g
+}
+
+// AsPoint returns the geometry held by g as an SFPoint, and true, if it is a
+// point; otherwise it returns a nil SFPoint, and false. The returned SFPoint
+// shares memory with g.
+func (g SFGeometry) AsPoint() (SFPoint, bool) {
+	if p, ok := g.g.(*geom.Point); ok && p != nil {
+		return SFPoint{*p}, true
+	}
+	return SFPoint{}, false
+}
+
+// AsPolygon returns the geometry held by g as an SFPolygon, and true, if it is
+// a polygon; otherwise it returns a nil SFPolygon, and false. The returned
+// SFPolygon shares memory with g.
+func (g SFGeometry) AsPolygon() (SFPolygon, bool) {
+	if p, ok := g.g.(*geom.Polygon); ok && p != nil {
+		return SFPolygon{*p}, true
+	}
+	return SFPolygon{}, false
+}
+
+// SRID returns the spatial reference identifier of g, or 0 if none has been
+// set or g is uninitialized.
+func (g SFGeometry) SRID() int {
+	if g.g == nil {
+		return 0
+	}
+	return g.g.SRID()
+}
+
+// Interfaces
+
+// IsNil implements the pyrrho/encoding IsNiler interface. It will return true
+// if g contains no meaningful data; if it holds no geometry, as is the case
+// for a zero-initialized SFGeometry, or if it holds an empty one.
+func (g SFGeometry) IsNil() bool {
+	if g.g == nil {
+		return true
+	}
+	if c, ok := g.g.(*geom.GeometryCollection); ok {
+		return c.NumGeoms() == 0
+	}
+	return len(g.g.FlatCoords()) == 0
+}
+
+// IsZero implements the pyrrho/encoding IsZeroer interface. It will return true
+// if g.IsNil() returns true, or if every coordinate of the held geometry is of
+// the zero-value.
+func (g SFGeometry) IsZero() bool {
+	return g.g == nil || geometryIsZero(g.g)
+}
+
+// Value implements the database/sql/driver Valuer interface. It will return the
+// value of g as a driver.Value; by default a WKB encoded []byte. See
+// GeometryValueOutput for the alternatives. If g holds no geometry, an error
+// will be returned.
+func (g SFGeometry) Value() (driver.Value, error) {
+	if g.g == nil {
+		return nil, fmt.Errorf("types.SFGeometry: cannot encode an uninitialized SFGeometry")
+	}
+	return geometryValue(g.g)
+}
+
+// Scan implements the database/sql Scanner interface. It expects to receive a
+// WKB or EWKB encoded []byte describing any geometry from an SQL database, and
+// will assign that geometry to g. GeoJSON text is also accepted if
+// GeometryValueOutput is GeometryValueGeoJSON. If the incoming value is not
+// well formed, an error will be returned. SQL NULL values, and empty data,
+// cannot be scanned into an SFGeometry.
+func (g *SFGeometry) Scan(src interface{}) error {
+	if g == nil {
+		return fmt.Errorf("types.SFGeometry: Scan called on nil pointer")
+	}
+	t, err := scanGeometry("types.SFGeometry", src)
+	if err != nil {
+		return err
+	}
+	g.g = t
+	return nil
+}
+
+// MarshalJSON implements the encoding/json Marshaler interface. It will return
+// the GeoJSON encoded representation of g. If g is nil, an error will be
+// returned, or 'null' if MarshalNilGeometryAsNull is set.
+func (g SFGeometry) MarshalJSON() ([]byte, error) {
+	if g.IsNil() {
+		if MarshalNilGeometryAsNull {
+			return []byte("null"), nil
+		}
+		return nil, fmt.Errorf("types.SFGeometry: cannot marshal an uninitialized SFGeometry")
+	}
+	return marshalGeoJSON(g.g)
+}
+
+// UnmarshalJSON implements the encoding/json Unmarshaler interface. It expects
+// to receive a valid GeoJSON Geometry of any type, and will assign the value
+// of that data to g.
+func (g *SFGeometry) UnmarshalJSON(data []byte) error {
+	if g == nil {
+		return fmt.Errorf("types.SFGeometry: UnmarshalJSON called on nil pointer")
+	}
+	var gt geom.T
+	if err := geojson.Unmarshal(data, &gt); err != nil {
+		return err
+	}
+	g.g = gt
+	return nil
+}
+
+// MarshalMapValue implements the pyrrho/encoding/maps Marshaler interface. It
+// will return g wrapped in an interface{} for use in a map[string]interface{}.
+func (g SFGeometry) MarshalMapValue() (interface{}, error) {
+	return g, nil
+}
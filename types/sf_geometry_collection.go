@@ -0,0 +1,163 @@
+package types
+
+import (
+	"database/sql/driver"
+	"fmt"
+
+	"github.com/twpayne/go-geom"
+	"github.com/twpayne/go-geom/encoding/geojson"
+)
+
+// SFGeometryCollection is a Simple Feature GeometryCollection, named for the
+// OpenGIS specification that backs WKB, WKT, and GeoJSON representations of
+// geospatial data. An SFGeometryCollection represents an ordered collection of
+// heterogeneous geometries -- eg. a mix of points, line strings, and polygons
+// -- in a given coordinate system. The type of each member geometry is
+// preserved through all of the conversions below.
+//
+// This type is built on top of the go-geom geom.GeometryCollection type,
+// implementing all of the pyrrho/encoding/types interfaces detailed in the
+// package comments. Database interactions (Value and Scan) will convert to and
+// from a WKB (Well Known Binary) representation. JSON interactions
+// (MarshalJSON and UnmarshalJSON) will convert to and from a GeoJSON
+// representation.
+type SFGeometryCollection struct {
+	geom.GeometryCollection
+}
+
+// Constructors
+
+// NewSFGeometryCollection constructs and returns a new SFGeometryCollection
+// object initialized with the given geom.GeometryCollection gc.
+func NewSFGeometryCollection(gc geom.GeometryCollection) SFGeometryCollection {
+	return SFGeometryCollection{gc}
+}
+
+// NewSFGeometryCollectionOf constructs and returns a new SFGeometryCollection
+// object holding the given geometries, in order. The geometries contained by
+// the other SF types may be passed by address; eg. &p.Point for an SFPoint p.
+func NewSFGeometryCollectionOf(gs ...geom.T) SFGeometryCollection {
+	gc := geom.NewGeometryCollection()
+	if err := gc.Push(gs...); err != nil {
+		panic(err)
+	}
+	return SFGeometryCollection{*gc}
+}
+
+// Getters and Setters
+
+// SRID returns the spatial reference identifier of gc, or 0 if none has been
+// set.
+func (gc SFGeometryCollection) SRID() int {
+	return gc.GeometryCollection.SRID()
+}
+
+// SetSRID sets the spatial reference identifier of gc. A non-zero SRID will be
+// preserved through Value and Scan by way of EWKB; see GeometryValueOutput.
+func (gc *SFGeometryCollection) SetSRID(srid int) {
+	gc.GeometryCollection.SetSRID(srid)
+}
+
+// Interfaces
+
+// IsNil implements the pyrrho/encoding IsNiler interface. It will return true
+// if gc contains no geometries, as is the case for a zero-initialized
+// SFGeometryCollection.
+func (gc SFGeometryCollection) IsNil() bool {
+	return gc.NumGeoms() == 0
+}
+
+// IsZero implements the pyrrho/encoding IsZeroer interface. It will return true
+// if gc.IsNil() returns true, or if every coordinate of every contained
+// geometry is of the zero-value.
+func (gc SFGeometryCollection) IsZero() bool {
+	return geometryIsZero(&gc.GeometryCollection)
+}
+
+// geometryIsZero returns true if every coordinate of g is of the zero-value,
+// descending into nested GeometryCollections, which do not have coordinates of
+// their own.
+func geometryIsZero(g geom.T) bool {
+	if c, ok := g.(*geom.GeometryCollection); ok {
+		for _, m := range c.Geoms() {
+			if !geometryIsZero(m) {
+				return false
+			}
+		}
+		return true
+	}
+	for _, f := range g.FlatCoords() {
+		if f != 0.0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Value implements the database/sql/driver Valuer interface. It will return the
+// value of gc as a driver.Value; by default a WKB encoded []byte. See
+// GeometryValueOutput for the alternatives.
+func (gc SFGeometryCollection) Value() (driver.Value, error) {
+	return geometryValue(&gc.GeometryCollection)
+}
+
+// Scan implements the database/sql Scanner interface. It expects to receive a
+// WKB or EWKB encoded []byte describing a GeometryCollection from an SQL
+// database, and will assign that value to gc. GeoJSON text is also accepted if
+// GeometryValueOutput is GeometryValueGeoJSON. If the incoming value is not
+// well formed, or if it does not describe a GeometryCollection, an error will
+// be returned. SQL NULL values, and empty data, cannot be scanned into an
+// SFGeometryCollection; use null.SFGeometryCollection for nullable columns.
+func (gc *SFGeometryCollection) Scan(src interface{}) error {
+	if gc == nil {
+		return fmt.Errorf("types.SFGeometryCollection: Scan called on nil pointer")
+	}
+	g, err := scanGeometry("types.SFGeometryCollection", src)
+	if err != nil {
+		return err
+	}
+	t, ok := g.(*geom.GeometryCollection)
+	if !ok {
+		return fmt.Errorf("types.SFGeometryCollection: scan did not return a *geom.GeometryCollection (got a %T)", g)
+	}
+	gc.GeometryCollection = *t
+	return nil
+}
+
+// MarshalJSON implements the encoding/json Marshaler interface. It will return
+// the GeoJSON encoded representation of gc. If gc is nil, an error will be
+// returned, or 'null' if MarshalNilGeometryAsNull is set.
+func (gc SFGeometryCollection) MarshalJSON() ([]byte, error) {
+	if gc.IsNil() {
+		if MarshalNilGeometryAsNull {
+			return []byte("null"), nil
+		}
+		return nil, fmt.Errorf("types.SFGeometryCollection: cannot marshal an uninitialized SFGeometryCollection")
+	}
+	return marshalGeoJSON(&gc.GeometryCollection)
+}
+
+// UnmarshalJSON implements the encoding/json Unmarshaler interface. It expects
+// to receive a valid GeoJSON Geometry of the type GeometryCollection, and will
+// assign the value of that data to gc.
+func (gc *SFGeometryCollection) UnmarshalJSON(data []byte) error {
+	if gc == nil {
+		return fmt.Errorf("types.SFGeometryCollection: UnmarshalJSON called on nil pointer")
+	}
+	var gt geom.T
+	if err := geojson.Unmarshal(data, &gt); err != nil {
+		return err
+	}
+	t, ok := gt.(*geom.GeometryCollection)
+	if !ok {
+		return fmt.Errorf("types.SFGeometryCollection: cannot unmarshal GeoJSON geometry of type %T", gt)
+	}
+	gc.GeometryCollection = *t
+	return nil
+}
+
+// MarshalMapValue implements the pyrrho/encoding/maps Marshaler interface. It
+// will return gc wrapped in an interface{} for use in a map[string]interface{}.
+func (gc SFGeometryCollection) MarshalMapValue() (interface{}, error) {
+	return gc, nil
+}
@@ -0,0 +1,225 @@
+package types_test
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"testing"
+
+	"github.com/pyrrho/encoding/maps"
+	"github.com/pyrrho/encoding/types"
+	"github.com/stretchr/testify/require"
+	"github.com/twpayne/go-geom"
+)
+
+var (
+	// These are both OpenGIS Simple Feature representations of the same XY
+	// GeometryCollection; a Point followed by a Polygon.
+	testGeometryCollectionGeoJSON = []byte(`{"type":"GeometryCollection","geometries":[{"type":"Point","coordinates":[1.2,2.3]},{"type":"Polygon","coordinates":[[[30,10],[40,40],[20,40],[10,20],[30,10]]]}]}`)
+	testGeometryCollectionWKB     = []byte{
+		0x01, 0x07, 0x00, 0x00, 0x00, 0x02, 0x00, 0x00,
+		0x00, 0x01, 0x01, 0x00, 0x00, 0x00, 0x33, 0x33,
+		0x33, 0x33, 0x33, 0x33, 0xf3, 0x3f, 0x66, 0x66,
+		0x66, 0x66, 0x66, 0x66, 0x02, 0x40, 0x01, 0x03,
+		0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x05,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x3e, 0x40, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x24, 0x40, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x44, 0x40, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x44, 0x40, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x34, 0x40, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x44, 0x40, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x24, 0x40, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x34, 0x40, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x3e, 0x40, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x24, 0x40,
+	}
+	testGeometryCollectionPoint   = types.NewSFPointXY(1.2, 2.3)
+	testGeometryCollectionPolygon = types.NewSFPolygonXY([][2]float64{
+		{30, 10},
+		{40, 40},
+		{20, 40},
+		{10, 20},
+		{30, 10},
+	})
+)
+
+// requireGeometryCollectionMembers asserts that gc holds exactly the test Point
+// followed by the test Polygon, with their geometry types intact.
+func requireGeometryCollectionMembers(require *require.Assertions, gc types.SFGeometryCollection) {
+	require.Equal(2, gc.NumGeoms())
+
+	p, ok := gc.Geom(0).(*geom.Point)
+	require.True(ok, "first member should be a *geom.Point")
+	require.Equal(testGeometryCollectionPoint.Coords(), p.Coords())
+
+	poly, ok := gc.Geom(1).(*geom.Polygon)
+	require.True(ok, "second member should be a *geom.Polygon")
+	require.Equal(testGeometryCollectionPolygon.Coords(), poly.Coords())
+}
+
+func TestSFGeometryCollectionCtors(t *testing.T) {
+	require := require.New(t)
+
+	gca := types.NewSFGeometryCollectionOf(
+		&testGeometryCollectionPoint.Point,
+		&testGeometryCollectionPolygon.Polygon)
+	requireGeometryCollectionMembers(require, gca)
+
+	gcb := types.NewSFGeometryCollection(gca.GeometryCollection)
+	requireGeometryCollectionMembers(require, gcb)
+}
+
+func TestSFGeometryCollectionIsNil(t *testing.T) {
+	require := require.New(t)
+
+	gc := types.NewSFGeometryCollectionOf(
+		&testGeometryCollectionPoint.Point,
+		&testGeometryCollectionPolygon.Polygon)
+	require.False(gc.IsNil())
+
+	zero := types.NewSFPointXY(0.0, 0.0)
+	malformed := types.NewSFGeometryCollectionOf(&zero.Point)
+	require.False(malformed.IsNil())
+
+	nul := types.NewSFGeometryCollectionOf()
+	require.True(nul.IsNil())
+
+	empty := types.SFGeometryCollection{}
+	require.True(empty.IsNil())
+}
+
+func TestSFGeometryCollectionIsZero(t *testing.T) {
+	require := require.New(t)
+
+	gc := types.NewSFGeometryCollectionOf(
+		&testGeometryCollectionPoint.Point,
+		&testGeometryCollectionPolygon.Polygon)
+	require.False(gc.IsZero())
+
+	zero := types.NewSFPointXY(0.0, 0.0)
+	malformed := types.NewSFGeometryCollectionOf(&zero.Point)
+	require.True(malformed.IsZero())
+
+	// Nested collections are inspected as well.
+	nested := types.NewSFGeometryCollectionOf(
+		&malformed.GeometryCollection,
+		&gc.GeometryCollection)
+	require.False(nested.IsZero())
+
+	nul := types.NewSFGeometryCollectionOf()
+	require.True(nul.IsZero())
+
+	empty := types.SFGeometryCollection{}
+	require.True(empty.IsZero())
+}
+
+func TestSFGeometryCollectionSQLValue(t *testing.T) {
+	require := require.New(t)
+	var val driver.Value
+	var err error
+
+	gc := types.NewSFGeometryCollectionOf(
+		&testGeometryCollectionPoint.Point,
+		&testGeometryCollectionPolygon.Polygon)
+	val, err = gc.Value()
+	require.NoError(err)
+	require.EqualValues(testGeometryCollectionWKB, val)
+}
+
+func TestSFGeometryCollectionSQLScan(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	var gc types.SFGeometryCollection
+	err = gc.Scan(driver.Value(testGeometryCollectionWKB))
+	require.NoError(err)
+	requireGeometryCollectionMembers(require, gc)
+
+	var bad types.SFGeometryCollection
+	err = bad.Scan(driver.Value(nil))
+	require.Error(err)
+
+	// A WKB Polygon is not a GeometryCollection.
+	var polygon types.SFGeometryCollection
+	err = polygon.Scan(driver.Value(testPolygonWKB))
+	require.Error(err)
+}
+
+func TestSFGeometryCollectionMarshalJSON(t *testing.T) {
+	require := require.New(t)
+	var data []byte
+	var err error
+
+	gc := types.NewSFGeometryCollectionOf(
+		&testGeometryCollectionPoint.Point,
+		&testGeometryCollectionPolygon.Polygon)
+	data, err = json.Marshal(gc)
+	require.NoError(err)
+	require.EqualValues(testGeometryCollectionGeoJSON, data)
+	data, err = json.Marshal(&gc)
+	require.NoError(err)
+	require.EqualValues(testGeometryCollectionGeoJSON, data)
+
+	bad := types.SFGeometryCollection{}
+	_, err = json.Marshal(bad)
+	require.Error(err)
+	_, err = json.Marshal(&bad)
+	require.Error(err)
+}
+
+func TestSFGeometryCollectionUnmarshalJSON(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	var gc types.SFGeometryCollection
+	err = json.Unmarshal(testGeometryCollectionGeoJSON, &gc)
+	require.NoError(err)
+	requireGeometryCollectionMembers(require, gc)
+
+	// A GeoJSON Polygon is not a GeometryCollection.
+	var polygon types.SFGeometryCollection
+	err = json.Unmarshal(testPolygonGeoJSON, &polygon)
+	require.Error(err)
+}
+
+func TestSFGeometryCollectionJSONRoundTrip(t *testing.T) {
+	require := require.New(t)
+
+	in := types.NewSFGeometryCollectionOf(
+		&testGeometryCollectionPoint.Point,
+		&testGeometryCollectionPolygon.Polygon)
+	data, err := json.Marshal(in)
+	require.NoError(err)
+
+	var out types.SFGeometryCollection
+	err = json.Unmarshal(data, &out)
+	require.NoError(err)
+	requireGeometryCollectionMembers(require, out)
+
+	// And back through WKB.
+	val, err := out.Value()
+	require.NoError(err)
+	var scanned types.SFGeometryCollection
+	err = scanned.Scan(val)
+	require.NoError(err)
+	requireGeometryCollectionMembers(require, scanned)
+}
+
+func TestSFGeometryCollectionMarshsalMapValue(t *testing.T) {
+	require := require.New(t)
+	type Wrapper struct{ GeometryCollection types.SFGeometryCollection }
+	var wrapper Wrapper
+	var data map[string]interface{}
+	var err error
+
+	gc := types.NewSFGeometryCollectionOf(
+		&testGeometryCollectionPoint.Point,
+		&testGeometryCollectionPolygon.Polygon)
+	wrapper = Wrapper{gc}
+	data, err = maps.Marshal(wrapper)
+	require.NoError(err)
+	require.Equal(gc, data["GeometryCollection"])
+	data, err = maps.Marshal(&wrapper)
+	require.NoError(err)
+	require.Equal(gc, data["GeometryCollection"])
+}
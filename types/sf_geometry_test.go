@@ -0,0 +1,157 @@
+package types_test
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"testing"
+
+	"github.com/pyrrho/encoding/maps"
+	"github.com/pyrrho/encoding/types"
+	"github.com/stretchr/testify/require"
+	"github.com/twpayne/go-geom"
+)
+
+func TestSFGeometryCtors(t *testing.T) {
+	require := require.New(t)
+
+	p := types.NewSFPointXY(1.2, 2.3)
+	g := types.NewSFGeometry(&p.Point)
+	require.Equal(&p.Point, g.Geometry())
+
+	var nul types.SFGeometry
+	require.Nil(nul.Geometry())
+}
+
+func TestSFGeometryAs(t *testing.T) {
+	require := require.New(t)
+
+	pt := types.NewSFPointXY(1.2, 2.3)
+	poly := types.NewSFPolygonXY(testPolygonExternal, testPolygonInternal)
+
+	g := types.NewSFGeometry(&pt.Point)
+	asPoint, ok := g.AsPoint()
+	require.True(ok)
+	require.Equal(pt, asPoint)
+	_, ok = g.AsPolygon()
+	require.False(ok)
+
+	g = types.NewSFGeometry(&poly.Polygon)
+	asPolygon, ok := g.AsPolygon()
+	require.True(ok)
+	require.Equal(poly, asPolygon)
+	_, ok = g.AsPoint()
+	require.False(ok)
+
+	_, ok = types.SFGeometry{}.AsPoint()
+	require.False(ok)
+}
+
+func TestSFGeometryIsNil(t *testing.T) {
+	require := require.New(t)
+
+	pt := types.NewSFPointXY(1.2, 2.3)
+	require.False(types.NewSFGeometry(&pt.Point).IsNil())
+	require.True(types.SFGeometry{}.IsNil())
+	require.True(types.NewSFGeometry(geom.NewPolygon(geom.XY)).IsNil())
+	require.True(types.NewSFGeometry(geom.NewGeometryCollection()).IsNil())
+}
+
+func TestSFGeometryIsZero(t *testing.T) {
+	require := require.New(t)
+
+	pt := types.NewSFPointXY(1.2, 2.3)
+	require.False(types.NewSFGeometry(&pt.Point).IsZero())
+	zero := types.NewSFPointXY(0, 0)
+	require.True(types.NewSFGeometry(&zero.Point).IsZero())
+	require.True(types.SFGeometry{}.IsZero())
+}
+
+func TestSFGeometrySQLScan(t *testing.T) {
+	require := require.New(t)
+
+	// Points and Polygons scan into the same type.
+	var g types.SFGeometry
+	err := g.Scan(testPointWKB)
+	require.NoError(err)
+	require.IsType(&geom.Point{}, g.Geometry())
+	p, ok := g.AsPoint()
+	require.True(ok)
+	require.Equal(types.NewSFPointXY(1.2, 2.3), p)
+
+	err = g.Scan(testPolygonWKB)
+	require.NoError(err)
+	require.IsType(&geom.Polygon{}, g.Geometry())
+	poly, ok := g.AsPolygon()
+	require.True(ok)
+	require.Equal(types.NewSFPolygonXY(testPolygonExternal, testPolygonInternal), poly)
+
+	err = g.Scan(testGeometryCollectionWKB)
+	require.NoError(err)
+	require.IsType(&geom.GeometryCollection{}, g.Geometry())
+
+	// The SRIDs of EWKB geometries are kept.
+	err = g.Scan(testPointEWKB4326)
+	require.NoError(err)
+	require.Equal(4326, g.SRID())
+
+	err = g.Scan([]byte{0x01, 0x02})
+	require.Error(err)
+	err = g.Scan(42)
+	require.Error(err)
+}
+
+func TestSFGeometrySQLValue(t *testing.T) {
+	require := require.New(t)
+	var val driver.Value
+	var err error
+
+	pt := types.NewSFPointXY(1.2, 2.3)
+	val, err = types.NewSFGeometry(&pt.Point).Value()
+	require.NoError(err)
+	require.Equal(testPointWKB, val)
+
+	poly := types.NewSFPolygonXY(testPolygonExternal, testPolygonInternal)
+	val, err = types.NewSFGeometry(&poly.Polygon).Value()
+	require.NoError(err)
+	require.Equal(testPolygonWKB, val)
+
+	_, err = types.SFGeometry{}.Value()
+	require.Error(err)
+}
+
+func TestSFGeometryJSON(t *testing.T) {
+	require := require.New(t)
+
+	for _, data := range [][]byte{
+		testPointGeoJSON,
+		testPolygonGeoJSON,
+		testGeometryCollectionGeoJSON,
+	} {
+		var g types.SFGeometry
+		err := json.Unmarshal(data, &g)
+		require.NoError(err)
+		actual, err := json.Marshal(g)
+		require.NoError(err)
+		require.JSONEq(string(data), string(actual))
+	}
+
+	var g types.SFGeometry
+	require.NoError(json.Unmarshal(testPolygonGeoJSON, &g))
+	_, ok := g.AsPolygon()
+	require.True(ok)
+
+	_, err := json.Marshal(types.SFGeometry{})
+	require.Error(err)
+	err = json.Unmarshal([]byte(`{"type":"Blob"}`), &g)
+	require.Error(err)
+}
+
+func TestSFGeometryMarshalMapValue(t *testing.T) {
+	require := require.New(t)
+
+	pt := types.NewSFPointXY(1.2, 2.3)
+	type Wrapper struct{ Geometry types.SFGeometry }
+	data, err := maps.Marshal(Wrapper{types.NewSFGeometry(&pt.Point)})
+	require.NoError(err)
+	require.Equal(map[string]interface{}{"Geometry": types.NewSFGeometry(&pt.Point)}, data)
+}
@@ -0,0 +1,188 @@
+package types
+
+import (
+	"database/sql/driver"
+	"fmt"
+
+	"github.com/twpayne/go-geom"
+	"github.com/twpayne/go-geom/encoding/geojson"
+)
+
+// SFMultiPolygon is a Simple Feature MultiPolygon, named for the OpenGIS
+// specification that backs WKB, WKT, and GeoJSON representations of geospatial
+// data. An SFMultiPolygon represents a collection of polygons -- each made up of
+// one external polygon and zero or more internal polygons, as described by
+// SFPolygon -- in a given coordinate system. This allows a single value to
+// describe disjoint shapes, such as a country made up of a mainland and a
+// number of islands.
+//
+// This type is built on top of the go-geom geom.MultiPolygon type, implementing
+// all of the pyrrho/encoding/types interfaces detailed in the package comments.
+// Database interactions (Value and Scan) will convert to and from a WKB (Well
+// Known Binary) representation. JSON interactions (MarshalJSON and
+// UnmarshalJSON) will convert to and from a GeoJSON representation.
+type SFMultiPolygon struct {
+	geom.MultiPolygon
+}
+
+// Constructors
+
+// NewSFMultiPolygon constructs and returns a new SFMultiPolygon object
+// initialized with the given geom.MultiPolygon mp.
+func NewSFMultiPolygon(mp geom.MultiPolygon) SFMultiPolygon {
+	return SFMultiPolygon{mp}
+}
+
+// NewSFMultiPolygonXY constructs and returns a new SFMultiPolygon object with
+// longitude and latitude components initialized with the given polygons. Each
+// polygon is a slice of shapes; the first is the external shape, and any that
+// follow are internal shapes.
+func NewSFMultiPolygonXY(polygons ...[][][2]float64) SFMultiPolygon {
+	coords := make([][][]geom.Coord, len(polygons))
+	for k, polygon := range polygons {
+		coords[k] = make([][]geom.Coord, len(polygon))
+		for j, shape := range polygon {
+			coords[k][j] = make([]geom.Coord, len(shape))
+			for i := range shape {
+				coords[k][j][i] = append(geom.Coord(nil), shape[i][:]...)
+			}
+		}
+	}
+
+	mp, err := geom.NewMultiPolygon(geom.XY).SetCoords(coords)
+	if err != nil {
+		panic(err)
+	}
+	return SFMultiPolygon{*mp}
+}
+
+// NewSFMultiPolygonXYZ constructs and returns a new SFMultiPolygon object with
+// longitude, latitude, and altitude components initialized with the given
+// polygons. Each polygon is a slice of shapes; the first is the external shape,
+// and any that follow are internal shapes.
+func NewSFMultiPolygonXYZ(polygons ...[][][3]float64) SFMultiPolygon {
+	coords := make([][][]geom.Coord, len(polygons))
+	for k, polygon := range polygons {
+		coords[k] = make([][]geom.Coord, len(polygon))
+		for j, shape := range polygon {
+			coords[k][j] = make([]geom.Coord, len(shape))
+			for i := range shape {
+				coords[k][j][i] = append(geom.Coord(nil), shape[i][:]...)
+			}
+		}
+	}
+
+	mp, err := geom.NewMultiPolygon(geom.XYZ).SetCoords(coords)
+	if err != nil {
+		panic(err)
+	}
+	return SFMultiPolygon{*mp}
+}
+
+// Getters and Setters
+
+// SRID returns the spatial reference identifier of mp, or 0 if none has been
+// set.
+func (mp SFMultiPolygon) SRID() int {
+	return mp.MultiPolygon.SRID()
+}
+
+// SetSRID sets the spatial reference identifier of mp. A non-zero SRID will be
+// preserved through Value and Scan by way of EWKB; see GeometryValueOutput.
+func (mp *SFMultiPolygon) SetSRID(srid int) {
+	mp.MultiPolygon.SetSRID(srid)
+}
+
+// Interfaces
+
+// IsNil implements the pyrrho/encoding IsNiler interface. It will return true
+// if mp contains no meaningful data. More specifically, if this SFMultiPolygon
+// has been zero-initialized, or if it has been explicitly initialized with no
+// layout or no polygons;
+//
+//	var mp types.SFMultiPolygon
+//	var mp := types.SFMultiPolygon{}
+//	var mp := types.NewSFMultiPolygon(geom.MultiPolygon{})
+//	var mp := types.NewSFMultiPolygonXY()
+func (mp SFMultiPolygon) IsNil() bool {
+	return mp.FlatCoords() == nil || mp.Layout() == geom.NoLayout
+}
+
+// IsZero implements the pyrrho/encoding IsZeroer interface. It will return true
+// if mp.IsNil() returns true, or if the contained data is of the zero-value.
+func (mp SFMultiPolygon) IsZero() bool {
+	for _, f := range mp.FlatCoords() {
+		if f != 0.0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Value implements the database/sql/driver Valuer interface. It will return the
+// value of mp as a driver.Value; by default a WKB encoded []byte. See
+// GeometryValueOutput for the alternatives.
+func (mp SFMultiPolygon) Value() (driver.Value, error) {
+	return geometryValue(&mp.MultiPolygon)
+}
+
+// Scan implements the database/sql Scanner interface. It expects to receive a
+// WKB or EWKB encoded []byte describing a MultiPolygon from an SQL database,
+// and will assign that value to mp. GeoJSON text is also accepted if
+// GeometryValueOutput is GeometryValueGeoJSON. If the incoming value is not
+// well formed, or if it does not describe a MultiPolygon, an error will be
+// returned. SQL NULL values, and empty data, cannot be scanned into an
+// SFMultiPolygon; use null.SFMultiPolygon for nullable columns.
+func (mp *SFMultiPolygon) Scan(src interface{}) error {
+	if mp == nil {
+		return fmt.Errorf("types.SFMultiPolygon: Scan called on nil pointer")
+	}
+	g, err := scanGeometry("types.SFMultiPolygon", src)
+	if err != nil {
+		return err
+	}
+	t, ok := g.(*geom.MultiPolygon)
+	if !ok {
+		return fmt.Errorf("types.SFMultiPolygon: scan did not return a *geom.MultiPolygon (got a %T)", g)
+	}
+	mp.MultiPolygon.Swap(t)
+	return nil
+}
+
+// MarshalJSON implements the encoding/json Marshaler interface. It will return
+// the GeoJSON encoded representation of mp. If mp is nil, an error will be
+// returned, or 'null' if MarshalNilGeometryAsNull is set.
+func (mp SFMultiPolygon) MarshalJSON() ([]byte, error) {
+	if mp.IsNil() {
+		if MarshalNilGeometryAsNull {
+			return []byte("null"), nil
+		}
+		return nil, fmt.Errorf("types.SFMultiPolygon: cannot marshal an uninitialized SFMultiPolygon")
+	}
+	return marshalGeoJSON(&mp.MultiPolygon)
+}
+
+// UnmarshalJSON implements the encoding/json Unmarshaler interface. It expects
+// to receive a valid GeoJSON Geometry of the type MultiPolygon, and will assign
+// the value of that data to mp.
+func (mp *SFMultiPolygon) UnmarshalJSON(data []byte) error {
+	if mp == nil {
+		return fmt.Errorf("types.SFMultiPolygon: UnmarshalJSON called on nil pointer")
+	}
+	var gt geom.T
+	if err := geojson.Unmarshal(data, &gt); err != nil {
+		return err
+	}
+	t, ok := gt.(*geom.MultiPolygon)
+	if !ok {
+		return fmt.Errorf("types.SFMultiPolygon: cannot unmarshal GeoJSON geometry of type %T", gt)
+	}
+	mp.MultiPolygon.Swap(t)
+	return nil
+}
+
+// MarshalMapValue implements the pyrrho/encoding/maps Marshaler interface. It
+// will return mp wrapped in an interface{} for use in a map[string]interface{}.
+func (mp SFMultiPolygon) MarshalMapValue() (interface{}, error) {
+	return mp, nil
+}
@@ -0,0 +1,274 @@
+package types_test
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"testing"
+
+	"github.com/pyrrho/encoding/maps"
+	"github.com/pyrrho/encoding/types"
+	"github.com/stretchr/testify/require"
+	"github.com/twpayne/go-geom"
+)
+
+var (
+	// These are all OpenGIS Simple Feature representations of the same
+	// two-polygon XY MultiPolygon; the second polygon has a hole.
+	testMultiPolygonWKT     = []byte("MULTIPOLYGON(((40 40,20 45,45 30,40 40)),((20 35,10 30,10 10,30 5,45 20,20 35),(30 20,20 15,20 25,30 20)))")
+	testMultiPolygonGeoJSON = []byte(`{"type":"MultiPolygon","coordinates":[[[[40,40],[20,45],[45,30],[40,40]]],[[[20,35],[10,30],[10,10],[30,5],[45,20],[20,35]],[[30,20],[20,15],[20,25],[30,20]]]]}`)
+	testMultiPolygonWKB     = []byte{
+		0x01, 0x06, 0x00, 0x00, 0x00, 0x02, 0x00, 0x00,
+		0x00, 0x01, 0x03, 0x00, 0x00, 0x00, 0x01, 0x00,
+		0x00, 0x00, 0x04, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x44, 0x40, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x44, 0x40, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x34, 0x40, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x80, 0x46, 0x40, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x80, 0x46, 0x40, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x3e, 0x40, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x44, 0x40, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x44, 0x40, 0x01, 0x03,
+		0x00, 0x00, 0x00, 0x02, 0x00, 0x00, 0x00, 0x06,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x34, 0x40, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x80, 0x41, 0x40, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x24, 0x40, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x3e, 0x40, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x24, 0x40, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x24, 0x40, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x3e, 0x40, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x14, 0x40, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x80, 0x46, 0x40, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x34, 0x40, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x34, 0x40, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x80, 0x41, 0x40, 0x04, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x3e, 0x40, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x34, 0x40, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x34, 0x40, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x2e, 0x40, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x34, 0x40, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x39, 0x40, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x3e, 0x40, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x34, 0x40,
+	}
+	testMultiPolygonFirst = [][][2]float64{
+		{
+			{40, 40},
+			{20, 45},
+			{45, 30},
+			{40, 40},
+		},
+	}
+	testMultiPolygonSecond = [][][2]float64{
+		{
+			{20, 35},
+			{10, 30},
+			{10, 10},
+			{30, 5},
+			{45, 20},
+			{20, 35},
+		},
+		{
+			{30, 20},
+			{20, 15},
+			{20, 25},
+			{30, 20},
+		},
+	}
+	testMultiPolygonCoords = [][][]geom.Coord{
+		{
+			{
+				{40, 40},
+				{20, 45},
+				{45, 30},
+				{40, 40},
+			},
+		},
+		{
+			{
+				{20, 35},
+				{10, 30},
+				{10, 10},
+				{30, 5},
+				{45, 20},
+				{20, 35},
+			},
+			{
+				{30, 20},
+				{20, 15},
+				{20, 25},
+				{30, 20},
+			},
+		},
+	}
+	testMultiPolygonGoGeom = *geom.NewMultiPolygon(geom.XY).MustSetCoords(testMultiPolygonCoords)
+)
+
+func TestSFMultiPolygonCtors(t *testing.T) {
+	require := require.New(t)
+
+	// types.SFMultiPolygon is a wrapper around go-geom's MultiPolygon class.
+	// As such, construction typically uses their conventions.
+	pa := types.NewSFMultiPolygon(*geom.NewMultiPolygon(geom.XY).MustSetCoords(testMultiPolygonCoords))
+	require.Equal(testMultiPolygonCoords, pa.Coords())
+	require.Equal(testMultiPolygonGoGeom, pa.MultiPolygon)
+	require.Equal(2, pa.NumPolygons())
+
+	// We have some helpers to make it easier, though.
+	pb := types.NewSFMultiPolygonXY(testMultiPolygonFirst, testMultiPolygonSecond)
+	require.Equal(testMultiPolygonGoGeom, pb.MultiPolygon)
+
+	pc := types.NewSFMultiPolygonXYZ(
+		[][][3]float64{{
+			{40, 40, 1},
+			{20, 45, 2},
+			{45, 30, 3},
+			{40, 40, 1},
+		}},
+		[][][3]float64{{
+			{20, 35, 1},
+			{10, 30, 2},
+			{10, 10, 3},
+			{20, 35, 1},
+		}})
+	require.Equal(
+		*geom.NewMultiPolygon(geom.XYZ).MustSetCoords(
+			[][][]geom.Coord{
+				{{
+					{40, 40, 1},
+					{20, 45, 2},
+					{45, 30, 3},
+					{40, 40, 1},
+				}},
+				{{
+					{20, 35, 1},
+					{10, 30, 2},
+					{10, 10, 3},
+					{20, 35, 1},
+				}},
+			}),
+		pc.MultiPolygon)
+}
+
+func TestSFMultiPolygonIsNil(t *testing.T) {
+	require := require.New(t)
+
+	mp := types.NewSFMultiPolygonXY(testMultiPolygonFirst, testMultiPolygonSecond)
+	require.False(mp.IsNil())
+
+	malformed := types.NewSFMultiPolygonXY([][][2]float64{{
+		{0.0, 0.0},
+		{0.0, 0.0},
+		{0.0, 0.0},
+		{0.0, 0.0},
+	}})
+	require.False(malformed.IsNil())
+
+	nul := types.NewSFMultiPolygonXY()
+	require.True(nul.IsNil())
+
+	empty := types.SFMultiPolygon{}
+	require.True(empty.IsNil())
+}
+
+func TestSFMultiPolygonIsZero(t *testing.T) {
+	require := require.New(t)
+
+	mp := types.NewSFMultiPolygonXY(testMultiPolygonFirst, testMultiPolygonSecond)
+	require.False(mp.IsZero())
+
+	malformed := types.NewSFMultiPolygonXY([][][2]float64{{
+		{0.0, 0.0},
+		{0.0, 0.0},
+		{0.0, 0.0},
+		{0.0, 0.0},
+	}})
+	require.True(malformed.IsZero())
+
+	nul := types.NewSFMultiPolygonXY()
+	require.True(nul.IsZero())
+
+	empty := types.SFMultiPolygon{}
+	require.True(empty.IsZero())
+}
+
+func TestSFMultiPolygonSQLValue(t *testing.T) {
+	require := require.New(t)
+	var val driver.Value
+	var err error
+
+	mp := types.NewSFMultiPolygonXY(testMultiPolygonFirst, testMultiPolygonSecond)
+	val, err = mp.Value()
+	require.NoError(err)
+	require.EqualValues(testMultiPolygonWKB, val)
+}
+
+func TestSFMultiPolygonSQLScan(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	var mp types.SFMultiPolygon
+	err = mp.Scan(driver.Value(testMultiPolygonWKB))
+	require.NoError(err)
+	require.Equal(testMultiPolygonCoords, mp.Coords())
+
+	var bad types.SFMultiPolygon
+	err = bad.Scan(driver.Value(nil))
+	require.Error(err)
+
+	// A WKB Polygon is not a MultiPolygon.
+	var polygon types.SFMultiPolygon
+	err = polygon.Scan(driver.Value(testPolygonWKB))
+	require.Error(err)
+}
+
+func TestSFMultiPolygonMarshalJSON(t *testing.T) {
+	require := require.New(t)
+	var data []byte
+	var err error
+
+	mp := types.NewSFMultiPolygonXY(testMultiPolygonFirst, testMultiPolygonSecond)
+	data, err = json.Marshal(mp)
+	require.NoError(err)
+	require.EqualValues(testMultiPolygonGeoJSON, data)
+	data, err = json.Marshal(&mp)
+	require.NoError(err)
+	require.EqualValues(testMultiPolygonGeoJSON, data)
+
+	bad := types.SFMultiPolygon{}
+	_, err = json.Marshal(bad)
+	require.Error(err)
+	_, err = json.Marshal(&bad)
+	require.Error(err)
+}
+
+func TestSFMultiPolygonUnmarshalJSON(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	var mp types.SFMultiPolygon
+	err = json.Unmarshal(testMultiPolygonGeoJSON, &mp)
+	require.NoError(err)
+	require.Equal(testMultiPolygonCoords, mp.Coords())
+
+	// A GeoJSON Polygon is not a MultiPolygon.
+	var polygon types.SFMultiPolygon
+	err = json.Unmarshal(testPolygonGeoJSON, &polygon)
+	require.Error(err)
+}
+
+func TestSFMultiPolygonMarshsalMapValue(t *testing.T) {
+	require := require.New(t)
+	type Wrapper struct{ MultiPolygon types.SFMultiPolygon }
+	var wrapper Wrapper
+	var data map[string]interface{}
+	var err error
+
+	wrapper = Wrapper{types.NewSFMultiPolygonXY(testMultiPolygonFirst, testMultiPolygonSecond)}
+	data, err = maps.Marshal(wrapper)
+	require.NoError(err)
+	require.Equal(types.NewSFMultiPolygon(testMultiPolygonGoGeom), data["MultiPolygon"])
+	data, err = maps.Marshal(&wrapper)
+	require.NoError(err)
+	require.Equal(types.NewSFMultiPolygon(testMultiPolygonGoGeom), data["MultiPolygon"])
+}
@@ -1,13 +1,13 @@
 package types
 
 import (
-	"bytes"
 	"database/sql/driver"
 	"fmt"
+	"math"
 
 	"github.com/twpayne/go-geom"
 	"github.com/twpayne/go-geom/encoding/geojson"
-	"github.com/twpayne/go-geom/encoding/wkb"
+	"github.com/twpayne/go-geom/encoding/wkt"
 )
 
 // SFPoint is a Simple Feature Point, named for the OpenGIS specification that
@@ -52,7 +52,7 @@ func NewSFPointXYZ(x float64, y float64, z float64) SFPoint {
 	return SFPoint{*p}
 }
 
-// Getters
+// Getters and Setters
 
 // Lng returns the longitude (northing, first) component of this SFPoint.
 func (p SFPoint) Lng() float64 {
@@ -70,6 +70,69 @@ func (p SFPoint) Alt() float64 {
 	return p.Z()
 }
 
+// SRID returns the spatial reference identifier of p, or 0 if none has been
+// set.
+func (p SFPoint) SRID() int {
+	return p.Point.SRID()
+}
+
+// SetSRID sets the spatial reference identifier of p. A non-zero SRID will be
+// preserved through Value and Scan by way of EWKB; see GeometryValueOutput.
+func (p *SFPoint) SetSRID(srid int) {
+	p.Point.SetSRID(srid)
+}
+
+// Spatial Queries
+
+// DistanceTo returns the planar (Euclidean) distance between p and other, in
+// the units of their coordinate system. The altitudes of XYZ SFPoints are
+// included. An error will be returned if either SFPoint is nil, or if their
+// layouts differ.
+func (p SFPoint) DistanceTo(other SFPoint) (float64, error) {
+	if err := p.checkComparable(other); err != nil {
+		return 0, err
+	}
+	var sum float64
+	for i, c := range p.FlatCoords() {
+		d := c - other.FlatCoords()[i]
+		sum += d * d
+	}
+	return math.Sqrt(sum), nil
+}
+
+// HaversineDistanceTo returns the great-circle distance, in meters, between p
+// and other, treating their coordinates as degrees of longitude and latitude on
+// a spherical Earth of mean radius EarthRadius. Altitudes are ignored. An error
+// will be returned if either SFPoint is nil, or if their layouts differ.
+func (p SFPoint) HaversineDistanceTo(other SFPoint) (float64, error) {
+	if err := p.checkComparable(other); err != nil {
+		return 0, err
+	}
+	rad := math.Pi / 180
+	lat1, lat2 := p.Lat()*rad, other.Lat()*rad
+	dLat := lat2 - lat1
+	dLng := (other.Lng() - p.Lng()) * rad
+	h := math.Pow(math.Sin(dLat/2), 2) +
+		math.Cos(lat1)*math.Cos(lat2)*math.Pow(math.Sin(dLng/2), 2)
+	return 2 * EarthRadius * math.Asin(math.Min(1, math.Sqrt(h))), nil
+}
+
+// EarthRadius is the mean radius of the Earth, in meters, used by
+// HaversineDistanceTo.
+const EarthRadius = 6371008.8
+
+// checkComparable returns an error if p or other is nil, or if their layouts
+// differ.
+func (p SFPoint) checkComparable(other SFPoint) error {
+	if p.IsNil() || other.IsNil() {
+		return fmt.Errorf("types.SFPoint: cannot compare nil points")
+	}
+	if p.Layout() != other.Layout() {
+		return fmt.Errorf("types.SFPoint: cannot compare points of layouts %v and %v", p.Layout(), other.Layout())
+	}
+	return nil
+}
+
 // Interfaces
 
 // IsNil implements the pyrrho/encoding IsNiler interface. It will return true
@@ -96,46 +159,46 @@ func (p SFPoint) IsZero() bool {
 }
 
 // Value implements the database/sql/driver Valuer interface. It will return the
-// value of p as a driver.Value; specifically a WKB encoded []byte.
+// value of p as a driver.Value; by default a WKB encoded []byte. See
+// GeometryValueOutput for the alternatives.
 func (p SFPoint) Value() (driver.Value, error) {
-	b := &bytes.Buffer{}
-	if err := wkb.Write(b, wkb.NDR, &p.Point); err != nil {
-		return nil, err
-	}
-	return b.Bytes(), nil
+	return geometryValue(&p.Point)
 }
 
 // Scan implements the database/sql Scanner interface. It expects to receive a
-// WKB encoded []byte describing a Point from an SQL database, and will assign
-// that value to p. If the incoming []byte is not a well formed WKB, or if that
-// WKB value does not describe a Point, an error will be returned.
+// WKB or EWKB encoded []byte describing a Point from an SQL database, and will
+// assign that value to p. GeoJSON text is also accepted if GeometryValueOutput
+// is GeometryValueGeoJSON. If the incoming value is not well formed, or if it
+// does not describe a Point, an error will be returned. SQL NULL values, and
+// empty data, cannot be scanned into an SFPoint; use null.SFPoint for nullable
+// columns.
 func (p *SFPoint) Scan(src interface{}) error {
 	if p == nil {
-		return fmt.Errorf("types.SFPoint: Scan called on nil SFLpointer")
-	}
-	b, ok := src.([]byte)
-	if !ok {
-		return fmt.Errorf("types.SFPoint: cannot scan type %T (%v)", src, src)
+		return fmt.Errorf("types.SFPoint: Scan called on nil pointer")
 	}
-	g, err := wkb.Unmarshal(b)
+	g, err := scanGeometry("types.SFPoint", src)
 	if err != nil {
 		return err
 	}
 	t, ok := g.(*geom.Point)
 	if !ok {
-		return fmt.Errorf("types.SFPoint: scan did not return a *geom.Point (got a %T)", t)
+		return fmt.Errorf("types.SFPoint: scan did not return a *geom.Point (got a %T)", g)
 	}
 	p.Point.Swap(t)
 	return nil
 }
 
 // MarshalJSON implements the encoding/json Marshaler interface. It will return
-// the GeoJSON encoded representation of p.
+// the GeoJSON encoded representation of p. If p is nil, an error will be
+// returned, or 'null' if MarshalNilGeometryAsNull is set.
 func (p SFPoint) MarshalJSON() ([]byte, error) {
 	if p.IsNil() {
+		if MarshalNilGeometryAsNull {
+			return []byte("null"), nil
+		}
 		return nil, fmt.Errorf("types.SFPoint: cannot unmarshal an uninitialized SFPoint")
 	}
-	return geojson.Marshal(&p.Point)
+	return marshalGeoJSON(&p.Point)
 }
 
 // UnmarshalJSON implements the encoding/json Unmarshaler interface. It expects
@@ -143,13 +206,19 @@ func (p SFPoint) MarshalJSON() ([]byte, error) {
 // the value of that data to p.
 func (p *SFPoint) UnmarshalJSON(data []byte) error {
 	if p == nil {
-		return fmt.Errorf("types.SFPoint: UnmarshalJSON called on nil SFLpointer")
+		return fmt.Errorf("types.SFPoint: UnmarshalJSON called on nil pointer")
 	}
 	var gt geom.T
 	if err := geojson.Unmarshal(data, &gt); err != nil {
 		return err
 	}
-	p.Point.Swap(gt.(*geom.Point))
+	// NB. geojson.Unmarshal infers the layout from the number of components
+	// in each position, so [lng, lat, alt] positions produce an XYZ Point.
+	t, ok := gt.(*geom.Point)
+	if !ok {
+		return fmt.Errorf("types.SFPoint: cannot unmarshal GeoJSON geometry of type %T", gt)
+	}
+	p.Point.Swap(t)
 	return nil
 }
 
@@ -158,3 +227,35 @@ func (p *SFPoint) UnmarshalJSON(data []byte) error {
 func (p SFPoint) MarshalMapValue() (interface{}, error) {
 	return p, nil
 }
+
+// MarshalWKT returns the WKT (Well Known Text) representation of p; eg.
+// "POINT (1.2 2.3)". If p is nil, an error will be returned.
+func (p SFPoint) MarshalWKT() ([]byte, error) {
+	if p.IsNil() {
+		return nil, fmt.Errorf("types.SFPoint: cannot marshal an uninitialized SFPoint as WKT")
+	}
+	s, err := wkt.Marshal(&p.Point)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(s), nil
+}
+
+// UnmarshalWKT expects to receive a WKT (Well Known Text) Point, and will
+// assign the value of that data to p. If data is not well formed, or if it does
+// not describe a Point, an error will be returned and p will be unchanged.
+func (p *SFPoint) UnmarshalWKT(data []byte) error {
+	if p == nil {
+		return fmt.Errorf("types.SFPoint: UnmarshalWKT called on nil pointer")
+	}
+	gt, err := wkt.Unmarshal(string(data))
+	if err != nil {
+		return err
+	}
+	t, ok := gt.(*geom.Point)
+	if !ok {
+		return fmt.Errorf("types.SFPoint: cannot unmarshal WKT geometry of type %T", gt)
+	}
+	p.Point.Swap(t)
+	return nil
+}
@@ -23,6 +23,13 @@ var (
 		0x33, 0x33, 0x33, 0xf3, 0x3f, 0x66, 0x66, 0x66,
 		0x66, 0x66, 0x66, 0x02, 0x40,
 	}
+	// The same Point as EWKB, carrying SRID 4326 (WGS 84).
+	testPointEWKB4326 = []byte{
+		0x01, 0x01, 0x00, 0x00, 0x20, 0xe6, 0x10, 0x00,
+		0x00, 0x33, 0x33, 0x33, 0x33, 0x33, 0x33, 0xf3,
+		0x3f, 0x66, 0x66, 0x66, 0x66, 0x66, 0x66, 0x02,
+		0x40,
+	}
 )
 
 func TestSFPointCtors(t *testing.T) {
@@ -101,6 +108,115 @@ func TestSFPointSQLScan(t *testing.T) {
 	require.Error(err)
 }
 
+func TestSFPointSQLValueModes(t *testing.T) {
+	require := require.New(t)
+	defer func() { types.GeometryValueOutput = types.GeometryValueWKB }()
+
+	for _, mode := range []types.GeometryValueMode{
+		types.GeometryValueWKB,
+		types.GeometryValueEWKB,
+		types.GeometryValueGeoJSON,
+	} {
+		types.GeometryValueOutput = mode
+		for _, in := range []types.SFPoint{
+			types.NewSFPointXY(1.2, 2.3),
+			types.NewSFPointXYZ(1.2, 2.3, 3.4),
+		} {
+			val, err := in.Value()
+			require.NoError(err)
+
+			var out types.SFPoint
+			err = out.Scan(val)
+			require.NoError(err)
+			require.Equal(in.Layout(), out.Layout())
+			require.Equal(in.Coords(), out.Coords())
+		}
+	}
+
+	// WKB mode produces a []byte, and rejects GeoJSON text.
+	types.GeometryValueOutput = types.GeometryValueWKB
+	p := types.NewSFPointXY(1.2, 2.3)
+	val, err := p.Value()
+	require.NoError(err)
+	require.EqualValues(testPointWKB, val)
+
+	var bad types.SFPoint
+	err = bad.Scan(string(testPointGeoJSON))
+	require.Error(err)
+	err = bad.Scan(testPointGeoJSON)
+	require.Error(err)
+
+	// EWKB sets the Z flag in the high bits of the geometry type, rather than
+	// using the ISO 1000-series type codes.
+	types.GeometryValueOutput = types.GeometryValueEWKB
+	pz := types.NewSFPointXYZ(1.2, 2.3, 3.4)
+	val, err = pz.Value()
+	require.NoError(err)
+	require.EqualValues([]byte{0x01, 0x01, 0x00, 0x00, 0x80}, val.([]byte)[:5])
+
+	// Scan accepts either binary encoding, regardless of the mode.
+	var fromWKB types.SFPoint
+	err = fromWKB.Scan(testPointWKB)
+	require.NoError(err)
+	require.Equal(p.Coords(), fromWKB.Coords())
+
+	types.GeometryValueOutput = types.GeometryValueWKB
+	var fromEWKB types.SFPoint
+	err = fromEWKB.Scan(val)
+	require.NoError(err)
+	require.Equal(pz.Coords(), fromEWKB.Coords())
+
+	// GeoJSON mode produces a string, and accepts either a string or a
+	// []byte of GeoJSON text, alongside WKB.
+	types.GeometryValueOutput = types.GeometryValueGeoJSON
+	val, err = p.Value()
+	require.NoError(err)
+	require.Equal(string(testPointGeoJSON), val)
+
+	var fromText types.SFPoint
+	err = fromText.Scan(testPointGeoJSON)
+	require.NoError(err)
+	require.Equal(p.Coords(), fromText.Coords())
+	err = fromText.Scan(testPointWKB)
+	require.NoError(err)
+	require.Equal(p.Coords(), fromText.Coords())
+
+	// A GeoJSON Polygon is still not a Point.
+	err = fromText.Scan(string(testPolygonGeoJSON))
+	require.Error(err)
+}
+
+func TestSFPointSRID(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	p := types.NewSFPointXY(1.2, 2.3)
+	require.Equal(0, p.SRID())
+
+	// An SRID of 0 is written as plain WKB ...
+	val, err := p.Value()
+	require.NoError(err)
+	require.EqualValues(testPointWKB, val)
+
+	// ... but a non-zero SRID requires EWKB.
+	p.SetSRID(4326)
+	require.Equal(4326, p.SRID())
+	val, err = p.Value()
+	require.NoError(err)
+	require.EqualValues(testPointEWKB4326, val)
+
+	var s types.SFPoint
+	err = s.Scan(testPointEWKB4326)
+	require.NoError(err)
+	require.Equal(4326, s.SRID())
+	require.Equal(p.Coords(), s.Coords())
+
+	// Scanning plain WKB resets the SRID.
+	err = s.Scan(testPointWKB)
+	require.NoError(err)
+	require.Equal(0, s.SRID())
+}
+
 func TestSFPointMarshalJSON(t *testing.T) {
 	require := require.New(t)
 	var data []byte
@@ -121,6 +237,43 @@ func TestSFPointMarshalJSON(t *testing.T) {
 	require.Error(err)
 }
 
+func TestSFPointMarshalJSONPrecision(t *testing.T) {
+	require := require.New(t)
+	var data []byte
+	var err error
+
+	types.GeoJSONPrecision = 3
+	defer func() { types.GeoJSONPrecision = -1 }()
+
+	p := types.NewSFPointXYZ(1.23456, 2.3, 3.4999)
+	data, err = json.Marshal(p)
+	require.NoError(err)
+	require.EqualValues(`{"type":"Point","coordinates":[1.235,2.3,3.5]}`, data)
+}
+
+func TestSFPointMarshalJSONNilAsNull(t *testing.T) {
+	require := require.New(t)
+	var data []byte
+	var err error
+
+	types.MarshalNilGeometryAsNull = true
+	defer func() { types.MarshalNilGeometryAsNull = false }()
+
+	nul := types.SFPoint{}
+	data, err = json.Marshal(nul)
+	require.NoError(err)
+	require.EqualValues("null", data)
+	data, err = json.Marshal(&nul)
+	require.NoError(err)
+	require.EqualValues("null", data)
+
+	// Initialized geometries are unaffected.
+	p := types.NewSFPointXY(1.2, 2.3)
+	data, err = json.Marshal(p)
+	require.NoError(err)
+	require.EqualValues(testPointGeoJSON, data)
+}
+
 func TestSFPointUnmarshalJSON(t *testing.T) {
 	require := require.New(t)
 	var err error
@@ -132,6 +285,36 @@ func TestSFPointUnmarshalJSON(t *testing.T) {
 	require.Equal(2.3, p.Lat())
 }
 
+func TestSFPointJSONRoundTripXYZ(t *testing.T) {
+	require := require.New(t)
+
+	// GeoJSON positions are [lng, lat, alt]; the altitude must survive a
+	// round-trip, and the layout must not fall back to XY.
+	in := types.NewSFPointXYZ(1.2, 2.3, 3.4)
+	data, err := json.Marshal(in)
+	require.NoError(err)
+	require.EqualValues(`{"type":"Point","coordinates":[1.2,2.3,3.4]}`, data)
+
+	var out types.SFPoint
+	err = json.Unmarshal(data, &out)
+	require.NoError(err)
+	require.Equal(geom.XYZ, out.Layout())
+	require.Equal(1.2, out.Lng())
+	require.Equal(2.3, out.Lat())
+	require.Equal(3.4, out.Alt())
+	require.Equal(in, out)
+
+	// Unmarshaling an XY point into a previously-XYZ SFPoint replaces the
+	// layout.
+	err = json.Unmarshal(testPointGeoJSON, &out)
+	require.NoError(err)
+	require.Equal(geom.XY, out.Layout())
+
+	// Geometries other than Points are errors, not panics.
+	err = json.Unmarshal([]byte(`{"type":"LineString","coordinates":[[1,2],[3,4]]}`), &out)
+	require.Error(err)
+}
+
 func TestSFPointMarshsalMapValue(t *testing.T) {
 	require := require.New(t)
 	type Wrapper struct{ Point types.SFPoint }
@@ -147,3 +330,92 @@ func TestSFPointMarshsalMapValue(t *testing.T) {
 	require.NoError(err)
 	require.Equal(types.NewSFPointXY(1.2, 2.3), data["Point"])
 }
+
+func TestSFPointDistanceTo(t *testing.T) {
+	require := require.New(t)
+
+	d, err := types.NewSFPointXY(0, 0).DistanceTo(types.NewSFPointXY(3, 4))
+	require.NoError(err)
+	require.Equal(5.0, d)
+
+	d, err = types.NewSFPointXYZ(1, 2, 3).DistanceTo(types.NewSFPointXYZ(1, 2, -3))
+	require.NoError(err)
+	require.Equal(6.0, d)
+
+	_, err = types.NewSFPointXY(0, 0).DistanceTo(types.NewSFPointXYZ(0, 0, 0))
+	require.Error(err)
+	_, err = types.NewSFPointXY(0, 0).DistanceTo(types.SFPoint{})
+	require.Error(err)
+}
+
+func TestSFPointHaversineDistanceTo(t *testing.T) {
+	require := require.New(t)
+
+	// A degree of latitude is about 111.2km.
+	d, err := types.NewSFPointXY(0, 0).HaversineDistanceTo(types.NewSFPointXY(0, 1))
+	require.NoError(err)
+	require.InDelta(111195, d, 1)
+
+	// London to Paris is about 343.6km.
+	london := types.NewSFPointXY(-0.1278, 51.5074)
+	paris := types.NewSFPointXY(2.3522, 48.8566)
+	d, err = london.HaversineDistanceTo(paris)
+	require.NoError(err)
+	require.InDelta(343.6e3, d, 500)
+
+	// Altitudes are ignored.
+	d, err = types.NewSFPointXYZ(10, 10, 0).HaversineDistanceTo(types.NewSFPointXYZ(10, 10, 1000))
+	require.NoError(err)
+	require.Equal(0.0, d)
+
+	_, err = london.HaversineDistanceTo(types.NewSFPointXYZ(2.3522, 48.8566, 0))
+	require.Error(err)
+}
+
+func TestSFPointWKT(t *testing.T) {
+	require := require.New(t)
+
+	var p types.SFPoint
+	err := p.UnmarshalWKT(testPointWKT)
+	require.NoError(err)
+	require.Equal(types.NewSFPointXY(1.2, 2.3), p)
+
+	data, err := p.MarshalWKT()
+	require.NoError(err)
+	require.EqualValues("POINT (1.2 2.3)", data)
+
+	var rt types.SFPoint
+	require.NoError(rt.UnmarshalWKT(data))
+	require.Equal(p, rt)
+
+	xyz := types.NewSFPointXYZ(1, 2, 3)
+	data, err = xyz.MarshalWKT()
+	require.NoError(err)
+	rt = types.SFPoint{}
+	require.NoError(rt.UnmarshalWKT(data))
+	require.Equal(xyz, rt)
+
+	// Failed unmarshals leave p unchanged.
+	require.Error(p.UnmarshalWKT([]byte("POINT(1.2")))
+	require.Error(p.UnmarshalWKT(testPolygonWKT))
+	require.Equal(types.NewSFPointXY(1.2, 2.3), p)
+
+	_, err = types.SFPoint{}.MarshalWKT()
+	require.Error(err)
+}
+
+func TestSFPointScanNull(t *testing.T) {
+	require := require.New(t)
+
+	// SFPoints cannot be null, so NULL and empty data are errors, and leave the
+	// SFPoint unchanged.
+	for _, src := range []interface{}{nil, []byte(nil), []byte{}, ""} {
+		p := types.NewSFPointXY(1.2, 2.3)
+		err := p.Scan(src)
+		require.Error(err)
+		require.Equal(
+			"types.SFPoint: cannot scan NULL or empty data; use the pyrrho/encoding/types/null equivalent for nullable columns",
+			err.Error())
+		require.Equal(types.NewSFPointXY(1.2, 2.3), p)
+	}
+}
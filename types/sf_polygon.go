@@ -1,13 +1,12 @@
 package types
 
 import (
-	"bytes"
 	"database/sql/driver"
 	"fmt"
 
 	"github.com/twpayne/go-geom"
 	"github.com/twpayne/go-geom/encoding/geojson"
-	"github.com/twpayne/go-geom/encoding/wkb"
+	"github.com/twpayne/go-geom/encoding/wkt"
 )
 
 // SFPolygon is a Simple Feature Polygon, named for the OpenGIS specification
@@ -87,6 +86,60 @@ func NewSFPolygonXYZ(external [][3]float64, internals ...[][3]float64) SFPolygon
 	return SFPolygon{*p}
 }
 
+// Getters and Setters
+
+// SRID returns the spatial reference identifier of p, or 0 if none has been
+// set.
+func (p SFPolygon) SRID() int {
+	return p.Polygon.SRID()
+}
+
+// SetSRID sets the spatial reference identifier of p. A non-zero SRID will be
+// preserved through Value and Scan by way of EWKB; see GeometryValueOutput.
+func (p *SFPolygon) SetSRID(srid int) {
+	p.Polygon.SetSRID(srid)
+}
+
+// Spatial Queries
+
+// Contains reports whether point lies within p; within p's external ring, and
+// outside all of its internal rings (holes). Only the longitude and latitude
+// components are considered, and the result for points lying exactly on a
+// ring is unspecified. An error will be returned if either p or point is nil,
+// or if their layouts differ.
+func (p SFPolygon) Contains(point SFPoint) (bool, error) {
+	if p.IsNil() || point.IsNil() {
+		return false, fmt.Errorf("types.SFPolygon: cannot test containment with a nil geometry")
+	}
+	if p.Layout() != point.Layout() {
+		return false, fmt.Errorf("types.SFPolygon: cannot test containment of a %v point in a %v polygon", point.Layout(), p.Layout())
+	}
+	x, y := point.Lng(), point.Lat()
+	for i := 0; i < p.NumLinearRings(); i++ {
+		in := ringContains(p.LinearRing(i).Coords(), x, y)
+		// Inside the external ring, and outside every internal ring.
+		if in != (i == 0) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// ringContains reports whether (x, y) lies within ring, by casting a ray from
+// the point in the direction of increasing x and counting the edges it
+// crosses.
+func ringContains(ring []geom.Coord, x, y float64) bool {
+	in := false
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		xi, yi := ring[i][0], ring[i][1]
+		xj, yj := ring[j][0], ring[j][1]
+		if (yi > y) != (yj > y) && x < (xj-xi)*(y-yi)/(yj-yi)+xi {
+			in = !in
+		}
+	}
+	return in
+}
+
 // Interfaces
 
 // IsNil implements the pyrrho/encoding IsNiler interface. It will return true
@@ -114,46 +167,46 @@ func (p SFPolygon) IsZero() bool {
 }
 
 // Value implements the database/sql/driver Valuer interface. It will return the
-// value of p as a driver.Value; specifically a WKB encoded []byte.
+// value of p as a driver.Value; by default a WKB encoded []byte. See
+// GeometryValueOutput for the alternatives.
 func (p SFPolygon) Value() (driver.Value, error) {
-	b := &bytes.Buffer{}
-	if err := wkb.Write(b, wkb.NDR, &p.Polygon); err != nil {
-		return nil, err
-	}
-	return b.Bytes(), nil
+	return geometryValue(&p.Polygon)
 }
 
 // Scan implements the database/sql Scanner interface. It expects to receive a
-// WKB encoded []byte describing a Polygon from an SQL database, and will assign
-// that value to p. If the incoming []byte is not a well formed WKB, or if that
-// WKB value does not describe a Polygon, an error will be returned.
+// WKB or EWKB encoded []byte describing a Polygon from an SQL database, and
+// will assign that value to p. GeoJSON text is also accepted if
+// GeometryValueOutput is GeometryValueGeoJSON. If the incoming value is not
+// well formed, or if it does not describe a Polygon, an error will be returned.
+// SQL NULL values, and empty data, cannot be scanned into an SFPolygon; use
+// null.SFPolygon for nullable columns.
 func (p *SFPolygon) Scan(src interface{}) error {
 	if p == nil {
-		return fmt.Errorf("types.SFPolygon: Scan called on nil SFLPolygoner")
-	}
-	b, ok := src.([]byte)
-	if !ok {
-		return fmt.Errorf("types.SFPolygon: cannot scan type %T (%v)", src, src)
+		return fmt.Errorf("types.SFPolygon: Scan called on nil pointer")
 	}
-	g, err := wkb.Unmarshal(b)
+	g, err := scanGeometry("types.SFPolygon", src)
 	if err != nil {
 		return err
 	}
 	t, ok := g.(*geom.Polygon)
 	if !ok {
-		return fmt.Errorf("types.SFPolygon: scan did not return a *geom.Polygon (got a %T)", t)
+		return fmt.Errorf("types.SFPolygon: scan did not return a *geom.Polygon (got a %T)", g)
 	}
 	p.Polygon.Swap(t)
 	return nil
 }
 
 // MarshalJSON implements the encoding/json Marshaler interface. It will return
-// the GeoJSON encoded representation of p.
+// the GeoJSON encoded representation of p. If p is nil, an error will be
+// returned, or 'null' if MarshalNilGeometryAsNull is set.
 func (p SFPolygon) MarshalJSON() ([]byte, error) {
 	if p.IsNil() {
+		if MarshalNilGeometryAsNull {
+			return []byte("null"), nil
+		}
 		return nil, fmt.Errorf("types.SFPolygon: cannot unmarshal an uninitialized SFPolygon")
 	}
-	return geojson.Marshal(&p.Polygon)
+	return marshalGeoJSON(&p.Polygon)
 }
 
 // UnmarshalJSON implements the encoding/json Unmarshaler interface. It expects
@@ -161,13 +214,19 @@ func (p SFPolygon) MarshalJSON() ([]byte, error) {
 // the value of that data to p.
 func (p *SFPolygon) UnmarshalJSON(data []byte) error {
 	if p == nil {
-		return fmt.Errorf("types.SFPolygon: UnmarshalJSON called on nil SFLPolygoner")
+		return fmt.Errorf("types.SFPolygon: UnmarshalJSON called on nil pointer")
 	}
 	var gt geom.T
 	if err := geojson.Unmarshal(data, &gt); err != nil {
 		return err
 	}
-	p.Polygon.Swap(gt.(*geom.Polygon))
+	// NB. geojson.Unmarshal infers the layout from the number of components
+	// in each position, so [lng, lat, alt] positions produce an XYZ Polygon.
+	t, ok := gt.(*geom.Polygon)
+	if !ok {
+		return fmt.Errorf("types.SFPolygon: cannot unmarshal GeoJSON geometry of type %T", gt)
+	}
+	p.Polygon.Swap(t)
 	return nil
 }
 
@@ -176,3 +235,80 @@ func (p *SFPolygon) UnmarshalJSON(data []byte) error {
 func (p SFPolygon) MarshalMapValue() (interface{}, error) {
 	return p, nil
 }
+
+// MarshalWKT returns the WKT (Well Known Text) representation of p; eg.
+// "POLYGON ((0 0, 1 0, 1 1, 0 0))". If p is nil, an error will be returned.
+func (p SFPolygon) MarshalWKT() ([]byte, error) {
+	if p.IsNil() {
+		return nil, fmt.Errorf("types.SFPolygon: cannot marshal an uninitialized SFPolygon as WKT")
+	}
+	s, err := wkt.Marshal(&p.Polygon)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(s), nil
+}
+
+// UnmarshalWKT expects to receive a WKT (Well Known Text) Polygon, and will
+// assign the value of that data to p. If data is not well formed, or if it does
+// not describe a Polygon, an error will be returned and p will be unchanged.
+func (p *SFPolygon) UnmarshalWKT(data []byte) error {
+	if p == nil {
+		return fmt.Errorf("types.SFPolygon: UnmarshalWKT called on nil pointer")
+	}
+	gt, err := wkt.Unmarshal(string(data))
+	if err != nil {
+		return err
+	}
+	t, ok := gt.(*geom.Polygon)
+	if !ok {
+		return fmt.Errorf("types.SFPolygon: cannot unmarshal WKT geometry of type %T", gt)
+	}
+	p.Polygon.Swap(t)
+	return nil
+}
+
+// SFPolygonGeoJSON is an SFPolygon that is stored in databases as GeoJSON text,
+// for schemas that keep geometries in text or JSON columns. Its Value and Scan
+// methods always use GeoJSON, regardless of GeometryValueOutput; all other
+// behavior is that of SFPolygon. This allows the same polygon data to back
+// either a geometry column, as an SFPolygon, or a JSON column, as an
+// SFPolygonGeoJSON;
+//   row.Area = types.SFPolygonGeoJSON{SFPolygon: poly}
+type SFPolygonGeoJSON struct {
+	SFPolygon
+}
+
+// Value implements the database/sql/driver Valuer interface. It will return the
+// value of p as GeoJSON text, in a string. GeoJSON has no notion of an SRID, so
+// it will be dropped.
+func (p SFPolygonGeoJSON) Value() (driver.Value, error) {
+	return geoJSONValue(&p.Polygon)
+}
+
+// Scan implements the database/sql Scanner interface. It expects to receive
+// GeoJSON text, as either a string or a []byte, describing a Polygon, and will
+// assign that value to p. If the incoming value is not well formed, or if it
+// does not describe a Polygon, an error will be returned. SQL NULL values, and
+// empty data, cannot be scanned into an SFPolygonGeoJSON.
+func (p *SFPolygonGeoJSON) Scan(src interface{}) error {
+	if p == nil {
+		return fmt.Errorf("types.SFPolygonGeoJSON: Scan called on nil pointer")
+	}
+	g, err := scanGeoJSON("types.SFPolygonGeoJSON", src)
+	if err != nil {
+		return err
+	}
+	t, ok := g.(*geom.Polygon)
+	if !ok {
+		return fmt.Errorf("types.SFPolygonGeoJSON: scan did not return a *geom.Polygon (got a %T)", g)
+	}
+	p.Polygon.Swap(t)
+	return nil
+}
+
+// MarshalMapValue implements the pyrrho/encoding/maps Marshaler interface. It
+// will return p wrapped in an interface{} for use in a map[string]interface{}.
+func (p SFPolygonGeoJSON) MarshalMapValue() (interface{}, error) {
+	return p, nil
+}
@@ -226,6 +226,123 @@ func TestSFPolygonSQLScan(t *testing.T) {
 	require.Error(err)
 }
 
+func TestSFPolygonSQLValueModes(t *testing.T) {
+	require := require.New(t)
+	defer func() { types.GeometryValueOutput = types.GeometryValueWKB }()
+
+	xyz := types.NewSFPolygonXYZ([][3]float64{
+		{30, 10, 1},
+		{40, 40, 2},
+		{20, 40, 3},
+		{10, 20, 4},
+		{30, 10, 1},
+	})
+	for _, mode := range []types.GeometryValueMode{
+		types.GeometryValueWKB,
+		types.GeometryValueEWKB,
+		types.GeometryValueGeoJSON,
+	} {
+		types.GeometryValueOutput = mode
+		for _, in := range []types.SFPolygon{
+			types.NewSFPolygonXY(testPolygonExternal, testPolygonInternal),
+			xyz,
+		} {
+			val, err := in.Value()
+			require.NoError(err)
+
+			var out types.SFPolygon
+			err = out.Scan(val)
+			require.NoError(err)
+			require.Equal(in.Layout(), out.Layout())
+			require.Equal(in.Coords(), out.Coords())
+		}
+	}
+
+	p := types.NewSFPolygonXY(testPolygonExternal, testPolygonInternal)
+
+	types.GeometryValueOutput = types.GeometryValueGeoJSON
+	val, err := p.Value()
+	require.NoError(err)
+	require.Equal(string(testPolygonGeoJSON), val)
+
+	types.GeometryValueOutput = types.GeometryValueWKB
+	val, err = p.Value()
+	require.NoError(err)
+	require.EqualValues(testPolygonWKB, val)
+
+	var bad types.SFPolygon
+	err = bad.Scan(string(testPolygonGeoJSON))
+	require.Error(err)
+}
+
+func TestSFPolygonGeoJSONSQL(t *testing.T) {
+	require := require.New(t)
+
+	// The GeoJSON variant ignores GeometryValueOutput entirely.
+	for _, mode := range []types.GeometryValueMode{
+		types.GeometryValueWKB,
+		types.GeometryValueGeoJSON,
+	} {
+		types.GeometryValueOutput = mode
+
+		in := types.SFPolygonGeoJSON{SFPolygon: types.NewSFPolygonXY(testPolygonExternal, testPolygonInternal)}
+		val, err := in.Value()
+		require.NoError(err)
+		require.Equal(string(testPolygonGeoJSON), val)
+
+		var out types.SFPolygonGeoJSON
+		err = out.Scan(val)
+		require.NoError(err)
+		require.Equal(in.Layout(), out.Layout())
+		require.Equal(in.Coords(), out.Coords())
+
+		err = out.Scan(testPolygonGeoJSON)
+		require.NoError(err)
+		require.Equal(in.Coords(), out.Coords())
+	}
+	types.GeometryValueOutput = types.GeometryValueWKB
+
+	var bad types.SFPolygonGeoJSON
+	err := bad.Scan(testPolygonWKB)
+	require.Error(err)
+	err = bad.Scan(`{"type":"Point","coordinates":[1,2]}`)
+	require.Error(err)
+	err = bad.Scan(42)
+	require.Error(err)
+	err = bad.Scan(nil)
+	require.Error(err)
+}
+
+func TestSFPolygonSRID(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	// The test Polygon as EWKB carrying SRID 4326 (WGS 84); the geometry type
+	// gains the SRID flag, and the SRID follows it.
+	ewkb4326 := append([]byte{
+		0x01, 0x03, 0x00, 0x00, 0x20, 0xe6, 0x10, 0x00,
+		0x00,
+	}, testPolygonWKB[5:]...)
+
+	p := types.NewSFPolygonXY(testPolygonExternal, testPolygonInternal)
+	require.Equal(0, p.SRID())
+	val, err := p.Value()
+	require.NoError(err)
+	require.EqualValues(testPolygonWKB, val)
+
+	p.SetSRID(4326)
+	require.Equal(4326, p.SRID())
+	val, err = p.Value()
+	require.NoError(err)
+	require.EqualValues(ewkb4326, val)
+
+	var s types.SFPolygon
+	err = s.Scan(ewkb4326)
+	require.NoError(err)
+	require.Equal(4326, s.SRID())
+	require.Equal(p.Coords(), s.Coords())
+}
+
 func TestSFPolygonMarshalJSON(t *testing.T) {
 	require := require.New(t)
 	var data []byte
@@ -246,6 +363,67 @@ func TestSFPolygonMarshalJSON(t *testing.T) {
 	require.Error(err)
 }
 
+func TestSFPolygonMarshalJSONPrecision(t *testing.T) {
+	require := require.New(t)
+	var data []byte
+	var err error
+
+	p := types.NewSFPolygonXY(
+		[][2]float64{
+			{30.1234567, 10.9876543},
+			{40.0000001, 40.6},
+			{20, 40},
+			{30.1234567, 10.9876543},
+		},
+		[][2]float64{
+			{28.4444449, 15},
+			{15, 21.1111111},
+			{22, 35},
+			{28.4444449, 15},
+		})
+
+	// The default is full precision.
+	data, err = json.Marshal(p)
+	require.NoError(err)
+	require.EqualValues(`{"type":"Polygon","coordinates":[[[30.1234567,10.9876543],[40.0000001,40.6],[20,40],[30.1234567,10.9876543]],[[28.4444449,15],[15,21.1111111],[22,35],[28.4444449,15]]]}`, data)
+
+	types.GeoJSONPrecision = 6
+	defer func() { types.GeoJSONPrecision = -1 }()
+
+	// Every coordinate is rounded, including those of the interior ring.
+	data, err = json.Marshal(p)
+	require.NoError(err)
+	require.EqualValues(`{"type":"Polygon","coordinates":[[[30.123457,10.987654],[40,40.6],[20,40],[30.123457,10.987654]],[[28.444445,15],[15,21.111111],[22,35],[28.444445,15]]]}`, data)
+
+	types.GeoJSONPrecision = 0
+	data, err = json.Marshal(p)
+	require.NoError(err)
+	require.EqualValues(`{"type":"Polygon","coordinates":[[[30,11],[40,41],[20,40],[30,11]],[[28,15],[15,21],[22,35],[28,15]]]}`, data)
+}
+
+func TestSFPolygonMarshalJSONNilAsNull(t *testing.T) {
+	require := require.New(t)
+	var data []byte
+	var err error
+
+	types.MarshalNilGeometryAsNull = true
+	defer func() { types.MarshalNilGeometryAsNull = false }()
+
+	nul := types.SFPolygon{}
+	data, err = json.Marshal(nul)
+	require.NoError(err)
+	require.EqualValues("null", data)
+	data, err = json.Marshal(&nul)
+	require.NoError(err)
+	require.EqualValues("null", data)
+
+	// Initialized geometries are unaffected.
+	p := types.NewSFPolygonXY(testPolygonExternal, testPolygonInternal)
+	data, err = json.Marshal(p)
+	require.NoError(err)
+	require.EqualValues(testPolygonGeoJSON, data)
+}
+
 func TestSFPolygonUnmarshalJSON(t *testing.T) {
 	require := require.New(t)
 	var err error
@@ -256,6 +434,31 @@ func TestSFPolygonUnmarshalJSON(t *testing.T) {
 	require.Equal(testPolygonCoords, p.Coords())
 }
 
+func TestSFPolygonJSONRoundTripXYZ(t *testing.T) {
+	require := require.New(t)
+
+	in := types.NewSFPolygonXYZ([][3]float64{
+		{30, 10, 1},
+		{40, 40, 2},
+		{20, 40, 3},
+		{10, 20, 4},
+		{30, 10, 5},
+	})
+	data, err := json.Marshal(in)
+	require.NoError(err)
+	require.EqualValues(`{"type":"Polygon","coordinates":[[[30,10,1],[40,40,2],[20,40,3],[10,20,4],[30,10,5]]]}`, data)
+
+	var out types.SFPolygon
+	err = json.Unmarshal(data, &out)
+	require.NoError(err)
+	require.Equal(geom.XYZ, out.Layout())
+	require.Equal(in, out)
+
+	// Geometries other than Polygons are errors, not panics.
+	err = json.Unmarshal([]byte(`{"type":"Point","coordinates":[1,2,3]}`), &out)
+	require.Error(err)
+}
+
 func TestSFPolygonMarshsalMapValue(t *testing.T) {
 	require := require.New(t)
 	type Wrapper struct{ Polygon types.SFPolygon }
@@ -271,3 +474,85 @@ func TestSFPolygonMarshsalMapValue(t *testing.T) {
 	require.NoError(err)
 	require.Equal(types.NewSFPolygon(testPolygonGoGeom), data["Polygon"])
 }
+
+func TestSFPolygonContains(t *testing.T) {
+	require := require.New(t)
+
+	p := types.NewSFPolygonXY(testPolygonExternal, testPolygonInternal)
+	solid := types.NewSFPolygonXY(testPolygonExternal)
+	for _, c := range []struct {
+		point        types.SFPoint
+		solid, holed bool
+		desc         string
+	}{
+		{types.NewSFPointXY(25, 38), true, true, "between the rings"},
+		{types.NewSFPointXY(25, 25), true, false, "inside the hole"},
+		{types.NewSFPointXY(5, 5), false, false, "outside"},
+		{types.NewSFPointXY(45, 25), false, false, "beside"},
+	} {
+		in, err := solid.Contains(c.point)
+		require.NoError(err)
+		require.Equal(c.solid, in, c.desc)
+		in, err = p.Contains(c.point)
+		require.NoError(err)
+		require.Equal(c.holed, in, c.desc)
+	}
+
+	_, err := p.Contains(types.NewSFPointXYZ(25, 38, 0))
+	require.Error(err)
+	_, err = p.Contains(types.SFPoint{})
+	require.Error(err)
+	_, err = types.SFPolygon{}.Contains(types.NewSFPointXY(25, 38))
+	require.Error(err)
+}
+
+func TestSFPolygonWKT(t *testing.T) {
+	require := require.New(t)
+
+	expected := types.NewSFPolygonXY(testPolygonExternal, testPolygonInternal)
+
+	var p types.SFPolygon
+	err := p.UnmarshalWKT(testPolygonWKT)
+	require.NoError(err)
+	require.Equal(expected, p)
+
+	data, err := p.MarshalWKT()
+	require.NoError(err)
+	require.EqualValues(
+		"POLYGON ((30 10, 40 40, 20 40, 10 20, 30 10), (28 15, 15 21, 22 35, 35 35, 28 15))",
+		data)
+
+	var rt types.SFPolygon
+	require.NoError(rt.UnmarshalWKT(data))
+	require.Equal(p, rt)
+
+	// Failed unmarshals leave p unchanged.
+	require.Error(p.UnmarshalWKT([]byte("POLYGON((30 10,40 40")))
+	require.Error(p.UnmarshalWKT(testPointWKT))
+	require.Equal(expected, p)
+
+	_, err = types.SFPolygon{}.MarshalWKT()
+	require.Error(err)
+}
+
+func TestSFPolygonScanNull(t *testing.T) {
+	require := require.New(t)
+
+	// SFPolygons cannot be null, so NULL and empty data are errors, and leave
+	// the SFPolygon unchanged.
+	expected := types.NewSFPolygonXY(testPolygonExternal, testPolygonInternal)
+	for _, src := range []interface{}{nil, []byte(nil), []byte{}, ""} {
+		p := types.NewSFPolygonXY(testPolygonExternal, testPolygonInternal)
+		err := p.Scan(src)
+		require.Error(err)
+		require.Equal(
+			"types.SFPolygon: cannot scan NULL or empty data; use the pyrrho/encoding/types/null equivalent for nullable columns",
+			err.Error())
+		require.Equal(expected, p)
+
+		g := types.SFPolygonGeoJSON{SFPolygon: expected}
+		err = g.Scan(src)
+		require.Error(err)
+		require.Contains(err.Error(), "types.SFPolygonGeoJSON: cannot scan NULL")
+	}
+}
@@ -0,0 +1,44 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// StrictRawJSON is a RawJSON that validates incoming data at decode time.
+// Where RawJSON.UnmarshalJSON will store whatever it is given, and defer any
+// validation to Value or MarshalJSON, StrictRawJSON.UnmarshalJSON will reject
+// malformed JSON immediately, leaving the receiver unchanged.
+//
+// Note that json.Unmarshal validates its entire input before calling any
+// UnmarshalJSON methods; this type is most useful when UnmarshalJSON is called
+// directly, or by decoders that do not perform that validation.
+//
+// All other behavior is inherited from the embedded RawJSON.
+type StrictRawJSON struct {
+	RawJSON
+}
+
+// NewStrictJSON will return a new StrictRawJSON object that has been
+// initialized with a copy of the contents of b, or an error if b is not valid
+// JSON.
+func NewStrictJSON(b []byte) (StrictRawJSON, error) {
+	if !json.Valid(b) {
+		return StrictRawJSON{}, fmt.Errorf("types.StrictRawJSON: invalid JSON %q", b)
+	}
+	return StrictRawJSON{NewJSON(b)}, nil
+}
+
+// UnmarshalJSON implements the encoding/json Unmarshaler interface. It expects
+// to receive a valid JSON value, and will assign that value to j. If data is
+// not valid JSON, an error will be returned and j will be unchanged.
+func (j *StrictRawJSON) UnmarshalJSON(data []byte) error {
+	if j == nil {
+		return fmt.Errorf("types.StrictRawJSON: UnmarshalJSON called on nil pointer")
+	}
+	if !json.Valid(data) {
+		return fmt.Errorf("types.StrictRawJSON: invalid JSON %q", data)
+	}
+	j.Set(data)
+	return nil
+}
@@ -0,0 +1,67 @@
+package types_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pyrrho/encoding/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStrictRawJSONCtors(t *testing.T) {
+	require := require.New(t)
+
+	j, err := types.NewStrictJSON([]byte(`{"foo":"bar"}`))
+	require.NoError(err)
+	require.EqualValues(`{"foo":"bar"}`, j.RawJSON)
+
+	_, err = types.NewStrictJSON([]byte(`{"foo":bar}`))
+	require.Error(err)
+	require.Contains(err.Error(), "StrictRawJSON:")
+}
+
+func TestStrictRawJSONUnmarshalJSON(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	// Successful Parses
+
+	var obj types.StrictRawJSON
+	err = obj.UnmarshalJSON([]byte(`{"foo":42,"bar":[true,null]}`))
+	require.NoError(err)
+	require.EqualValues(`{"foo":42,"bar":[true,null]}`, obj.RawJSON)
+
+	var nul types.StrictRawJSON
+	err = nul.UnmarshalJSON([]byte(`null`))
+	require.NoError(err)
+	require.EqualValues(`null`, nul.RawJSON)
+
+	type Wrapper struct{ JSON types.StrictRawJSON }
+	var wrapper Wrapper
+	err = json.Unmarshal([]byte(`{"JSON":{"foo":"bar"}}`), &wrapper)
+	require.NoError(err)
+	require.EqualValues(`{"foo":"bar"}`, wrapper.JSON.RawJSON)
+
+	// Unsuccessful Parses
+
+	invalid := types.StrictRawJSON{types.NewJSONStr(`"unchanged"`)}
+	err = invalid.UnmarshalJSON([]byte(`{"foo":bar}`))
+	require.Error(err)
+	require.Contains(err.Error(), "StrictRawJSON:")
+	require.EqualValues(`"unchanged"`, invalid.RawJSON)
+
+	var empty types.StrictRawJSON
+	err = empty.UnmarshalJSON([]byte(``))
+	require.Error(err)
+	require.True(empty.IsNil())
+
+	var truncated types.StrictRawJSON
+	err = truncated.UnmarshalJSON([]byte(`[1, 2`))
+	require.Error(err)
+	require.True(truncated.IsNil())
+
+	// RawJSON will accept the same malformed input without complaint.
+	var lax types.RawJSON
+	err = lax.UnmarshalJSON([]byte(`{"foo":bar}`))
+	require.NoError(err)
+}
@@ -0,0 +1,126 @@
+/*
+Package typestest provides utilities for testing implementations of the
+interfaces detailed in the pyrrho/encoding/types and pyrrho/encoding/types/null
+package comments.
+*/
+package typestest
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/pyrrho/encoding"
+	"github.com/pyrrho/encoding/maps"
+)
+
+// AssertInterfaceConformance checks that value, and a pointer to a copy of
+// value, implement all of the pyrrho/encoding/types interfaces; that the
+// Valuer and Scanner, and the encoding/json Marshaler and Unmarshaler, round
+// trip value; and that a value reporting IsNil behaves as a null value should.
+// Failures are reported through t.Errorf. value should be a non-pointer value
+// of the type under test -- eg. null.NewInt64(42), or null.Int64{} -- and
+// should be checked once valid and once null.
+//
+// The null checks are that IsZero is also true, that Value returns nil, that
+// MarshalJSON returns 'null', and that MarshalMapValue returns nil. Types that
+// report IsNil for a value that is not null -- eg. types.Int64, for 0 -- will
+// fail these checks.
+func AssertInterfaceConformance(t testing.TB, value interface{}) {
+	t.Helper()
+
+	typ := reflect.TypeOf(value)
+	if typ == nil || typ.Kind() == reflect.Ptr {
+		t.Errorf("typestest: value must be a non-pointer value, not %T", value)
+		return
+	}
+	newPtr := func() interface{} {
+		return reflect.New(typ).Interface()
+	}
+
+	niler, okNiler := value.(encoding.IsNiler)
+	zeroer, okZeroer := value.(encoding.IsZeroer)
+	valuer, okValuer := value.(driver.Valuer)
+	jsonMarshaler, okJSONMarshaler := value.(json.Marshaler)
+	mapMarshaler, okMapMarshaler := value.(maps.Marshaler)
+	_, okScanner := newPtr().(sql.Scanner)
+	_, okJSONUnmarshaler := newPtr().(json.Unmarshaler)
+	ok := true
+	for _, impl := range []struct {
+		ok   bool
+		name string
+	}{
+		{okNiler, "encoding.IsNiler"},
+		{okZeroer, "encoding.IsZeroer"},
+		{okValuer, "driver.Valuer"},
+		{okScanner, "sql.Scanner (on a pointer)"},
+		{okJSONMarshaler, "json.Marshaler"},
+		{okJSONUnmarshaler, "json.Unmarshaler (on a pointer)"},
+		{okMapMarshaler, "maps.Marshaler"},
+	} {
+		if !impl.ok {
+			t.Errorf("typestest: %s does not implement %s", typ, impl.name)
+			ok = false
+		}
+	}
+	if !ok {
+		return
+	}
+
+	isNil := niler.IsNil()
+	if isNil && !zeroer.IsZero() {
+		t.Errorf("typestest: %s: IsNil is true, but IsZero is false", typ)
+	}
+
+	// database/sql
+	val, err := valuer.Value()
+	if err != nil {
+		t.Errorf("typestest: %s: Value returned an error: %v", typ, err)
+	} else {
+		if isNil && val != nil {
+			t.Errorf("typestest: %s: Value of a null value is %#v, not nil", typ, val)
+		}
+		if !driver.IsValue(val) {
+			t.Errorf("typestest: %s: Value returned %T, which is not a driver.Value", typ, val)
+		}
+		scanned := newPtr()
+		if err := scanned.(sql.Scanner).Scan(val); err != nil {
+			t.Errorf("typestest: %s: Scan of %#v returned an error: %v", typ, val, err)
+		} else if rt, err := scanned.(driver.Valuer).Value(); err != nil {
+			t.Errorf("typestest: %s: Value of a scanned value returned an error: %v", typ, err)
+		} else if !reflect.DeepEqual(val, rt) {
+			t.Errorf("typestest: %s: Value did not round trip through Scan; %#v became %#v", typ, val, rt)
+		}
+	}
+
+	// encoding/json
+	data, err := jsonMarshaler.MarshalJSON()
+	if err != nil {
+		t.Errorf("typestest: %s: MarshalJSON returned an error: %v", typ, err)
+	} else {
+		if isNil && string(data) != "null" {
+			t.Errorf("typestest: %s: MarshalJSON of a null value is %s, not null", typ, data)
+		}
+		unmarshaled := newPtr()
+		if err := unmarshaled.(json.Unmarshaler).UnmarshalJSON(data); err != nil {
+			t.Errorf("typestest: %s: UnmarshalJSON of %s returned an error: %v", typ, data, err)
+		} else if rt, err := unmarshaled.(json.Marshaler).MarshalJSON(); err != nil {
+			t.Errorf("typestest: %s: MarshalJSON of an unmarshaled value returned an error: %v", typ, err)
+		} else if !bytes.Equal(data, rt) {
+			t.Errorf("typestest: %s: JSON did not round trip through UnmarshalJSON; %s became %s", typ, data, rt)
+		} else if got := unmarshaled.(encoding.IsNiler).IsNil(); got != isNil {
+			t.Errorf("typestest: %s: IsNil changed from %t to %t through UnmarshalJSON", typ, isNil, got)
+		}
+	}
+
+	// encoding/maps
+	mv, err := mapMarshaler.MarshalMapValue()
+	if err != nil {
+		t.Errorf("typestest: %s: MarshalMapValue returned an error: %v", typ, err)
+	} else if isNil && mv != nil {
+		t.Errorf("typestest: %s: MarshalMapValue of a null value is %#v, not nil", typ, mv)
+	}
+}
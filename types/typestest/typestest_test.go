@@ -0,0 +1,54 @@
+package typestest_test
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"testing"
+
+	"github.com/pyrrho/encoding/types/null"
+	"github.com/pyrrho/encoding/types/typestest"
+	"github.com/stretchr/testify/require"
+)
+
+// recorder is a testing.TB that records, rather than reports, errors.
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+// LeakyNull is a null.Int64 whose Value leaks 0 for null values.
+type LeakyNull struct {
+	null.Int64
+}
+
+func (l LeakyNull) Value() (driver.Value, error) {
+	return l.Int64.Int64, nil
+}
+
+func TestAssertInterfaceConformance(t *testing.T) {
+	require := require.New(t)
+
+	r := &recorder{TB: t}
+	typestest.AssertInterfaceConformance(r, null.NewString("foo"))
+	typestest.AssertInterfaceConformance(r, null.String{})
+	require.Empty(r.errors)
+
+	r = &recorder{TB: t}
+	typestest.AssertInterfaceConformance(r, LeakyNull{})
+	require.Len(r.errors, 1)
+	require.Contains(r.errors[0], "Value of a null value")
+
+	r = &recorder{TB: t}
+	typestest.AssertInterfaceConformance(r, 42)
+	require.True(len(r.errors) > 0)
+
+	r = &recorder{TB: t}
+	typestest.AssertInterfaceConformance(r, &LeakyNull{})
+	require.Len(r.errors, 1)
+}